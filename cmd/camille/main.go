@@ -0,0 +1,117 @@
+// Command camille holds offline tooling. `camille eval` scores policy fact
+// extraction against the gold set and gates on regressions:
+//
+//    camille eval [-dir eval/goldset] [-baseline eval/baseline.json] [-update-baseline] [-max-fpr 0.02]
+//
+// It exits 1 on a regression or when the false positive rate exceeds -max-fpr,
+// and 2 on usage or load errors.
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "flag"
+    "fmt"
+    "io/fs"
+    "os"
+    "sort"
+
+    "camille/internal/adapters/extract"
+    "camille/internal/eval"
+    "camille/internal/scanners/policy/rules"
+)
+
+func main() {
+    if len(os.Args) < 2 {
+        usage()
+        os.Exit(2)
+    }
+    switch os.Args[1] {
+    case "eval":
+        os.Exit(runEval(os.Args[2:]))
+    default:
+        usage()
+        os.Exit(2)
+    }
+}
+
+func usage() {
+    fmt.Fprintln(os.Stderr, "usage: camille eval [flags]")
+}
+
+func runEval(args []string) int {
+    fl := flag.NewFlagSet("eval", flag.ExitOnError)
+    dir := fl.String("dir", "eval/goldset", "gold-set directory, one case per subdirectory")
+    baseline := fl.String("baseline", "eval/baseline.json", "baseline report to compare against")
+    update := fl.Bool("update-baseline", false, "write the report as the new baseline instead of comparing")
+    maxFPR := fl.Float64("max-fpr", 0.02, "fail when the overall false positive rate exceeds this")
+    rulesPath := fl.String("rules", os.Getenv("POLICY_RULES_PATH"), "policy rule catalog YAML (default: built-in)")
+    ai := fl.Bool("ai", true, "replay each case's recorded AI replies alongside the rules")
+    asJSON := fl.Bool("json", false, "print the full report as JSON")
+    fl.Parse(args)
+
+    catalog, err := rules.Load(*rulesPath)
+    if err != nil { return fail(err) }
+    cases, err := eval.Load(*dir)
+    if err != nil { return fail(err) }
+    runner := &eval.Runner{Extractor: extract.New(), Rules: catalog, AI: *ai}
+    rep, err := runner.Run(context.Background(), cases)
+    if err != nil { return fail(err) }
+
+    if *asJSON {
+        out, _ := json.MarshalIndent(rep, "", "  ")
+        fmt.Println(string(out))
+    } else {
+        printReport(rep)
+    }
+    if *update {
+        if err := eval.WriteReport(*baseline, rep); err != nil { return fail(err) }
+        fmt.Printf("baseline written to %s\n", *baseline)
+        return 0
+    }
+
+    code := 0
+    if rep.Overall.FPR > *maxFPR {
+        fmt.Printf("FAIL: false positive rate %.3f exceeds %.3f\n", rep.Overall.FPR, *maxFPR)
+        code = 1
+    }
+    base, err := eval.ReadReport(*baseline)
+    switch {
+    case errors.Is(err, fs.ErrNotExist):
+        fmt.Printf("no baseline at %s; run with -update-baseline to create one\n", *baseline)
+    case err != nil:
+        return fail(err)
+    default:
+        if regs := eval.Regressions(base, rep); len(regs) > 0 {
+            fmt.Printf("FAIL: %d regressions against %s\n", len(regs), *baseline)
+            for _, r := range regs { fmt.Println("  " + r) }
+            code = 1
+        }
+    }
+    return code
+}
+
+func printReport(rep eval.Report) {
+    fmt.Printf("rules %s, %d cases\n\n", rep.RulesVersion, rep.Cases)
+    fmt.Printf("%-52s %5s %5s %5s %5s %5s %6s %6s %6s %6s\n", "signal", "tp", "fp", "fn", "tn", "unkn", "prec", "recall", "f1", "fpr")
+    codes := make([]string, 0, len(rep.Signals))
+    for code := range rep.Signals { codes = append(codes, code) }
+    sort.Strings(codes)
+    row := func(name string, m eval.Metrics) {
+        fmt.Printf("%-52s %5d %5d %5d %5d %5d %6.3f %6.3f %6.3f %6.3f\n", name, m.TP, m.FP, m.FN, m.TN, m.Unknown, m.Precision, m.Recall, m.F1, m.FPR)
+    }
+    for _, code := range codes { row(code, rep.Signals[code]) }
+    row("overall", rep.Overall)
+    if len(rep.Mismatches) > 0 {
+        fmt.Println("\nmismatches:")
+        for _, m := range rep.Mismatches { fmt.Printf("  %s: %s expected %v, got %v\n", m.Case, m.Code, m.Expected, m.Got) }
+    }
+    fmt.Printf("\nscore checks: %d, failed: %d\n", rep.ScoreChecks, len(rep.ScoreFailures))
+    for _, f := range rep.ScoreFailures { fmt.Printf("  %s: %s %d outside [%d, %d]\n", f.Case, f.Category, f.Got, f.Min, f.Max) }
+}
+
+func fail(err error) int {
+    fmt.Fprintf(os.Stderr, "eval: %v\n", err)
+    return 2
+}
@@ -2,6 +2,7 @@ package main
 
 import (
     "context"
+    "flag"
     "fmt"
     "log"
     "net/http"
@@ -12,18 +13,47 @@ import (
 
     "github.com/go-chi/chi/v5"
 
+    aiadapter "camille/internal/adapters/ai"
+    dnsadapter "camille/internal/adapters/dns"
+    "camille/internal/adapters/extract"
+    fetchadapter "camille/internal/adapters/fetch"
     httpadapter "camille/internal/adapters/http"
+    "camille/internal/adapters/trackerradar"
     pg "camille/internal/adapters/postgres"
     "camille/internal/config"
     ports "camille/internal/ports"
     profsvc "camille/internal/services/profiles"
     scansvc "camille/internal/services/scanner"
     compsvc "camille/internal/services/companies"
+    polsvc "camille/internal/services/policies"
+    "camille/internal/scanners/consent"
+    "camille/internal/scanners/cookies"
+    "camille/internal/scanners/dnshygiene"
+    "camille/internal/scanners/email"
+    "camille/internal/scanners/gpc"
+    "camille/internal/scanners/jurisdiction"
+    "camille/internal/scanners/policy"
+    "camille/internal/scanners/policy/chunks"
+    "camille/internal/scanners/policy/rules"
+    "camille/internal/scanners/mailtransport"
+    "camille/internal/scanners/securitytxt"
+    "camille/internal/scanners/trackers"
     scanworker "camille/internal/workers/scanrunner"
 )
 
 func main() {
+    checkRules := flag.Bool("check-rules", false, "verify the policy rule catalog's golden examples and exit")
+    flag.Parse()
+
     cfg, err := config.Load()
+    if *checkRules {
+        c, err := rules.Load(cfg.PolicyRulesPath)
+        if err != nil {
+            log.Fatalf("policy rules: %v", err)
+        }
+        log.Printf("policy rules %s: %d rules ok", c.Version, len(c.Rules))
+        return
+    }
     if err != nil {
         log.Printf("warning: %v", err)
     }
@@ -44,13 +74,108 @@ func main() {
     var _ ports.DomainRepository = db
     var _ ports.ScanRepository = db
     var _ ports.ScoreRepository = db
+    var _ ports.EvidenceRepository = db
+    var _ ports.SignalsRepository = db
+    var _ ports.AICacheRepository = db
+    var _ ports.AIUsageRepository = db
+    var _ ports.AIShadowRepository = db
 
     scanner := scansvc.New(db, db)
-    profiles := profsvc.New(db)
+    profiles := profsvc.New(db, db)
     companies := compsvc.New()
 
-    processor := scanworker.NoopProcessor{Repo: db}
-    srv := httpadapter.New(scanner, profiles, companies, db, processor)
+    fetcher := fetchadapter.New()
+    resolver, err := dnsadapter.New(cfg.DNSServer)
+    if err != nil {
+        log.Fatalf("dns resolver error: %v", err)
+    }
+    var trackerDir ports.TrackerDirectory
+    if cfg.TrackerRadarPath != "" {
+        radar, err := trackerradar.Load(cfg.TrackerRadarPath)
+        if err != nil {
+            log.Fatalf("tracker radar error: %v", err)
+        }
+        trackerDir = radar
+        log.Printf("tracker radar loaded: %d domains", radar.Len())
+    }
+    policyRules, err := rules.Load(cfg.PolicyRulesPath)
+    if err != nil {
+        log.Fatalf("policy rules error: %v", err)
+    }
+    prompts, err := aiadapter.LoadPrompts(cfg.AIPromptsDir)
+    if err != nil {
+        log.Fatalf("ai prompts error: %v", err)
+    }
+    buildAI := func(version string) (ports.AIExtractor, error) {
+        prompt, err := prompts.Get(version)
+        if err != nil { return nil, err }
+        x, err := aiadapter.New(aiadapter.Config{
+            Provider: cfg.AIProvider, BaseURL: cfg.AIBaseURL, APIKey: cfg.AIAPIKey,
+            Model: cfg.AIModel, Timeout: cfg.AITimeout, Retries: cfg.AIRetries, Prompt: prompt,
+        })
+        if err != nil { return nil, err }
+        return &aiadapter.Cached{Next: x, Store: db}, nil
+    }
+    var aiExtractor ports.AIExtractor
+    shadowVersion := ""
+    if cfg.AIEnabled {
+        aiExtractor, err = buildAI(cfg.AIPromptVersion)
+        if err != nil {
+            log.Printf("AI extraction disabled: %v", err)
+        } else if cfg.AIShadowPromptVersion != "" {
+            candidate, err := buildAI(cfg.AIShadowPromptVersion)
+            if err != nil {
+                log.Printf("AI shadow prompt disabled: %v", err)
+            } else {
+                aiExtractor = &aiadapter.Shadow{Primary: aiExtractor, Candidate: candidate, Store: db}
+                shadowVersion = cfg.AIShadowPromptVersion
+                log.Printf("AI shadow prompt %s running against %s", shadowVersion, cfg.AIPromptVersion)
+            }
+        }
+    }
+    var promptInfo []ports.AIPromptInfo
+    for _, p := range prompts.List() {
+        promptInfo = append(promptInfo, ports.AIPromptInfo{
+            Version: p.Version, Hash: p.Hash,
+            Active: aiExtractor != nil && p.Version == cfg.AIPromptVersion, Shadow: p.Version == shadowVersion,
+        })
+    }
+    policyScanner := policy.New(fetcher, extract.New(), policyRules, aiExtractor)
+    policyScanner.QuoteThreshold = cfg.AIQuoteThreshold
+    policyScanner.Versions = db
+    policyScanner.Resolver = resolver
+    policyScanner.Trackers = trackerDir
+    policyScanner.Recipients = db
+    policyScanner.Chunking = chunks.Options{MaxTokens: cfg.AIChunkTokens, Overlap: cfg.AIChunkOverlap, MinTokens: chunks.DefaultOptions.MinTokens}
+    policyScanner.Budget = &policy.Budget{
+        Usage:      db,
+        Pricing:    ports.AIPricing{PromptPerM: cfg.AIPricePrompt, CompletionPerM: cfg.AIPriceCompletion},
+        ScanTokens: cfg.AIScanMaxTokens, ScanUSD: cfg.AIScanMaxUSD,
+        DailyTokens: cfg.AIDailyMaxTokens, DailyUSD: cfg.AIDailyMaxUSD,
+        GlobalDailyTokens: cfg.AIGlobalMaxTokens, GlobalDailyUSD: cfg.AIGlobalMaxUSD,
+    }
+    processor := &scanworker.Pipeline{
+        Jobs:     db,
+        Scans:    db,
+        Evidence: db,
+        Signals:  db,
+        Scores:   db,
+        Scanners: []ports.SiteScanner{
+            securitytxt.New(fetcher),
+            email.New(resolver, cfg.DKIMSelectors),
+            mailtransport.New(resolver, fetcher),
+            dnshygiene.New(resolver, fetcher, fetcher),
+            cookies.New(fetcher),
+            trackers.New(fetcher, trackerDir, resolver),
+            consent.New(fetcher),
+            gpc.New(fetcher, trackerDir),
+            jurisdiction.New(fetcher),
+            policyScanner,
+        },
+    }
+    policies := polsvc.New(db, db, policyRules)
+    srv := httpadapter.New(scanner, profiles, companies, policies, db, processor)
+    srv.Admin = httpadapter.Admin{Token: cfg.AdminToken, AICache: db, AIUsage: db, AIShadow: db, Prompts: promptInfo}
     r := chi.NewRouter()
     r.Mount("/", srv.Routes())
 
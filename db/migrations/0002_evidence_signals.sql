@@ -0,0 +1,33 @@
+-- +goose Up
+-- evidence and normalized signals produced by scan pipelines
+CREATE TABLE IF NOT EXISTS evidence (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scan_id UUID NOT NULL REFERENCES scans(id) ON DELETE CASCADE,
+    source_type TEXT NOT NULL,
+    source_url TEXT NULL,
+    retrieved_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    hash TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    meta JSONB NOT NULL DEFAULT '{}'::jsonb
+);
+
+CREATE TABLE IF NOT EXISTS signals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain_id UUID NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    scan_id UUID NULL REFERENCES scans(id) ON DELETE SET NULL,
+    code TEXT NOT NULL,
+    value_json JSONB NOT NULL,
+    severity TEXT NULL,
+    confidence REAL NOT NULL DEFAULT 0,
+    source TEXT NOT NULL,
+    evidence_refs TEXT[] NOT NULL DEFAULT '{}',
+    retrieved_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_scan_hash ON evidence(scan_id, hash);
+CREATE INDEX IF NOT EXISTS idx_signals_domain_code ON signals(domain_id, code);
+CREATE INDEX IF NOT EXISTS idx_signals_scan ON signals(scan_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS signals;
+DROP TABLE IF EXISTS evidence;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- quote verification outcomes of AI extraction, per model, prompt version and day
+CREATE OR REPLACE VIEW ai_quote_stats AS
+SELECT
+    meta->>'provider' AS provider,
+    meta->>'model' AS model,
+    meta->>'prompt_version' AS prompt_version,
+    date_trunc('day', retrieved_at) AS day,
+    count(*) AS calls,
+    sum((meta->>'quotes_verified')::int) AS verified,
+    sum((meta->>'quotes_fuzzy')::int) AS fuzzy,
+    sum((meta->>'quotes_dropped')::int) AS dropped,
+    sum((meta->>'quotes_dropped')::int)::real
+        / NULLIF(sum((meta->>'quotes_verified')::int) + sum((meta->>'quotes_dropped')::int), 0) AS drop_rate
+FROM evidence
+WHERE source_type = 'policy.ai' AND meta ? 'quotes_verified'
+GROUP BY 1, 2, 3, 4;
+
+CREATE INDEX IF NOT EXISTS idx_evidence_source_type ON evidence(source_type, retrieved_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_evidence_source_type;
+DROP VIEW IF EXISTS ai_quote_stats;
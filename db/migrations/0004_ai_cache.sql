@@ -0,0 +1,19 @@
+-- +goose Up
+-- content-addressed cache of AI extraction results, shared across domains
+CREATE TABLE IF NOT EXISTS ai_cache (
+    chunk_hash TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    prompt_version TEXT NOT NULL,
+    categories TEXT NOT NULL,
+    facts JSONB NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_hit_at TIMESTAMPTZ NULL,
+    PRIMARY KEY (chunk_hash, provider, model, prompt_version, categories)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_cache_prompt ON ai_cache(prompt_version);
+
+-- +goose Down
+DROP TABLE IF EXISTS ai_cache;
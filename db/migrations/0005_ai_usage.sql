@@ -0,0 +1,26 @@
+-- +goose Up
+-- submitting tenant, for AI budgets and usage reporting
+ALTER TABLE scans ADD COLUMN IF NOT EXISTS tenant TEXT NOT NULL DEFAULT '';
+
+-- one row per AI extractor call (cache hits included, at zero tokens)
+CREATE TABLE IF NOT EXISTS ai_usage (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scan_id UUID NULL REFERENCES scans(id) ON DELETE SET NULL,
+    tenant TEXT NOT NULL DEFAULT '',
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    prompt_version TEXT NOT NULL,
+    calls INT NOT NULL DEFAULT 1,
+    prompt_tokens INT NOT NULL DEFAULT 0,
+    completion_tokens INT NOT NULL DEFAULT 0,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cached BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_usage_tenant ON ai_usage(tenant, created_at);
+CREATE INDEX IF NOT EXISTS idx_ai_usage_scan ON ai_usage(scan_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS ai_usage;
+ALTER TABLE scans DROP COLUMN IF EXISTS tenant;
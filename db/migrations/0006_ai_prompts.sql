@@ -0,0 +1,32 @@
+-- +goose Up
+-- cache entries are tied to the exact prompt text, not just its version name
+ALTER TABLE ai_cache ADD COLUMN IF NOT EXISTS prompt_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE ai_cache DROP CONSTRAINT IF EXISTS ai_cache_pkey;
+ALTER TABLE ai_cache ADD PRIMARY KEY (chunk_hash, provider, model, prompt_version, prompt_hash, categories);
+
+-- one chunk extracted by the active prompt and a shadow candidate
+CREATE TABLE IF NOT EXISTS ai_shadow_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chunk_hash TEXT NOT NULL,
+    source_url TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    primary_version TEXT NOT NULL,
+    primary_hash TEXT NOT NULL,
+    candidate_version TEXT NOT NULL,
+    candidate_hash TEXT NOT NULL,
+    primary_facts JSONB NOT NULL,
+    candidate_facts JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_shadow_versions ON ai_shadow_runs(primary_version, candidate_version, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS ai_shadow_runs;
+DELETE FROM ai_cache WHERE prompt_hash <> '';
+ALTER TABLE ai_cache DROP CONSTRAINT IF EXISTS ai_cache_pkey;
+ALTER TABLE ai_cache DROP COLUMN IF EXISTS prompt_hash;
+ALTER TABLE ai_cache ADD PRIMARY KEY (chunk_hash, provider, model, prompt_version, categories);
@@ -0,0 +1,25 @@
+-- +goose Up
+-- every distinct text of a domain's policy document, keyed by doc_hash; url is
+-- where it was last seen, so a moved page is not a new version
+CREATE TABLE IF NOT EXISTS policy_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain_id UUID NOT NULL REFERENCES domains(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    url TEXT NOT NULL,
+    doc_hash TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    language TEXT NOT NULL DEFAULT '',
+    text TEXT NOT NULL,
+    sections JSONB NOT NULL DEFAULT '[]'::jsonb,
+    first_scan_id UUID NULL REFERENCES scans(id) ON DELETE SET NULL,
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    seen_count INT NOT NULL DEFAULT 1,
+    UNIQUE (domain_id, type, doc_hash)
+);
+
+-- the current text is the one seen last, not the one first seen last
+CREATE INDEX IF NOT EXISTS idx_policy_versions_domain_type ON policy_versions(domain_id, type, last_seen_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS policy_versions;
@@ -0,0 +1,55 @@
+package ai
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "log"
+    "strings"
+
+    "camille/internal/ports"
+)
+
+// Cached serves repeat extractions from Store. Facts are keyed by chunk text, so
+// sites sharing boilerplate policies (hosted shop templates, …) share answers;
+// cached facts are rebased onto the requesting document's section URL. Hits cost
+// nothing and report zero usage.
+type Cached struct {
+    Next  ports.AIExtractor
+    Store ports.AICacheRepository
+}
+
+func (c *Cached) Info() ports.AIModelInfo { return c.Next.Info() }
+
+func (c *Cached) ExtractFacts(ctx context.Context, doc ports.PolicyDoc) ([]ports.Fact, ports.AIUsage, error) {
+    key := CacheKey(c.Next.Info(), doc)
+    if facts, ok, err := c.Store.GetAIFacts(ctx, key); err != nil {
+        log.Printf("ai cache lookup: %v", err)
+    } else if ok {
+        sectionURL := doc.SectionURL
+        if sectionURL == "" { sectionURL = doc.SourceURL }
+        for i := range facts {
+            facts[i].SectionURL = sectionURL
+            if facts[i].Metadata == nil { facts[i].Metadata = map[string]any{} }
+            facts[i].Metadata["cached"] = true
+        }
+        return facts, ports.AIUsage{}, nil
+    }
+    facts, usage, err := c.Next.ExtractFacts(ctx, doc)
+    if err != nil { return nil, usage, err }
+    if err := c.Store.PutAIFacts(ctx, key, facts); err != nil { log.Printf("ai cache store: %v", err) }
+    return facts, usage, nil
+}
+
+// CacheKey addresses doc's extraction under info.
+func CacheKey(info ports.AIModelInfo, doc ports.PolicyDoc) ports.AICacheKey {
+    sum := sha256.Sum256([]byte(doc.Content))
+    return ports.AICacheKey{
+        ChunkHash:     hex.EncodeToString(sum[:]),
+        Provider:      info.Provider,
+        Model:         info.Model,
+        PromptVersion: info.PromptVersion,
+        PromptHash:    info.PromptHash,
+        Categories:    strings.Join(requested(doc), ","),
+    }
+}
@@ -0,0 +1,36 @@
+package ai
+
+import (
+    "fmt"
+    "time"
+
+    "camille/internal/ports"
+)
+
+// Config selects and configures an extractor.
+type Config struct {
+    Provider string // "openai", "ollama" or "fake"
+    BaseURL  string
+    APIKey   string
+    Model    string
+    Timeout  time.Duration
+    Retries  int
+    Prompt   *Prompt // nil uses DefaultPromptVersion
+}
+
+// New builds the extractor for c.Provider.
+func New(c Config) (ports.AIExtractor, error) {
+    switch c.Provider {
+    case "openai":
+        if c.APIKey == "" && (c.BaseURL == "" || c.BaseURL == DefaultOpenAIBaseURL) {
+            return nil, fmt.Errorf("AI_API_KEY required for openai provider")
+        }
+        return &OpenAIExtractor{BaseURL: c.BaseURL, APIKey: c.APIKey, Model: c.Model, Timeout: c.Timeout, Retries: c.Retries, Prompt: c.Prompt}, nil
+    case "ollama":
+        return &OllamaExtractor{BaseURL: c.BaseURL, Model: c.Model, Timeout: c.Timeout, Retries: c.Retries, Prompt: c.Prompt}, nil
+    case "fake":
+        return &Scripted{Model: c.Model, Prompt: c.Prompt}, nil
+    default:
+        return nil, fmt.Errorf("unsupported AI provider: %s", c.Provider)
+    }
+}
@@ -0,0 +1,171 @@
+// Package ai implements ports.AIExtractor against OpenAI-compatible and Ollama
+// chat APIs, plus a scripted fake. All of them render a versioned prompt from the
+// registry, validate model output against factsSchema and drop facts outside the
+// category whitelist.
+package ai
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net"
+    "strings"
+    "time"
+
+    "camille/internal/ports"
+)
+
+const (
+    DefaultTimeout = 30 * time.Second
+    DefaultRetries = 2
+    // maxContent bounds the policy text sent in one request.
+    maxContent = 24000
+)
+
+// ErrInvalidOutput is returned when a model's reply is not valid against factsSchema.
+var ErrInvalidOutput = errors.New("ai: model output does not match schema")
+
+// ErrBudgetExhausted is returned when the doc's meter refuses a request.
+var ErrBudgetExhausted = errors.New("ai: budget exhausted")
+
+// factSchema is the JSON schema of one extracted fact.
+var factSchema = map[string]any{
+    "type":                 "object",
+    "additionalProperties": false,
+    "required":             []any{"quote", "category", "value", "confidence"},
+    "properties": map[string]any{
+        "quote":      map[string]any{"type": "string", "minLength": 8, "maxLength": 1000},
+        "category":   map[string]any{"type": "string", "enum": categoryEnum()},
+        "value":      map[string]any{"type": "boolean"},
+        "confidence": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+    },
+}
+
+// factsSchema is the JSON schema of a reply. It is sent to providers that support
+// structured output and enforced locally for all of them: a malformed envelope
+// fails the reply, a malformed or off-whitelist fact is dropped on its own.
+var factsSchema = map[string]any{
+    "type":                 "object",
+    "additionalProperties": false,
+    "required":             []any{"facts"},
+    "properties": map[string]any{
+        "facts": map[string]any{"type": "array", "items": factSchema},
+    },
+}
+
+// envelopeSchema is factsSchema without item checks.
+var envelopeSchema = map[string]any{
+    "type":       "object",
+    "required":   []any{"facts"},
+    "properties": map[string]any{"facts": map[string]any{"type": "array"}},
+}
+
+func categoryEnum() []any {
+    out := make([]any, 0, len(ports.AICategories))
+    for _, c := range ports.AICategories { out = append(out, c.Name) }
+    return out
+}
+
+// requested returns the categories to extract: the doc's subset of the whitelist, or all of it.
+func requested(doc ports.PolicyDoc) []string {
+    var out []string
+    for _, c := range ports.AICategories {
+        if len(doc.Categories) == 0 || containsString(doc.Categories, c.Name) { out = append(out, c.Name) }
+    }
+    return out
+}
+
+// parseFacts validates a reply and converts it, dropping invalid facts and facts
+// for categories that were not requested.
+func parseFacts(content string, doc ports.PolicyDoc) ([]ports.Fact, error) {
+    var raw any
+    if err := json.Unmarshal([]byte(strings.TrimSpace(stripFences(content))), &raw); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+    }
+    if err := validate(envelopeSchema, raw, "$"); err != nil { return nil, fmt.Errorf("%w: %v", ErrInvalidOutput, err) }
+
+    cats := requested(doc)
+    out := []ports.Fact{}
+    for i, item := range raw.(map[string]any)["facts"].([]any) {
+        if validate(factSchema, item, fmt.Sprintf("$.facts[%d]", i)) != nil { continue }
+        f := item.(map[string]any)
+        category := f["category"].(string)
+        if !containsString(cats, category) { continue }
+        sectionURL := doc.SectionURL
+        if sectionURL == "" { sectionURL = doc.SourceURL }
+        out = append(out, ports.Fact{
+            Quote:      strings.TrimSpace(f["quote"].(string)),
+            SectionURL: sectionURL,
+            Category:   category,
+            Value:      f["value"].(bool),
+            Confidence: f["confidence"].(float64),
+        })
+    }
+    return out, nil
+}
+
+// stripFences removes a ```json fence some models wrap replies in.
+func stripFences(s string) string {
+    s = strings.TrimSpace(s)
+    if !strings.HasPrefix(s, "```") { return s }
+    s = strings.TrimPrefix(strings.TrimPrefix(s, "```json"), "```")
+    return strings.TrimSuffix(strings.TrimSpace(s), "```")
+}
+
+// complete calls send up to 1+retries times, each under its own timeout, until it
+// returns output that parses. Transient transport errors and invalid output are retried.
+// Every attempt must be allowed by doc.Meter and is charged to it as info.
+func complete(ctx context.Context, timeout time.Duration, retries int, info ports.AIModelInfo, doc ports.PolicyDoc, send func(context.Context) (string, ports.AIUsage, error)) ([]ports.Fact, ports.AIUsage, error) {
+    if timeout <= 0 { timeout = DefaultTimeout }
+    if retries < 0 { retries = 0 }
+    var usage ports.AIUsage
+    var err error
+    for attempt := 0; attempt <= retries; attempt++ {
+        if attempt > 0 {
+            select {
+            case <-ctx.Done():
+                return nil, usage, ctx.Err()
+            case <-time.After(time.Duration(1<<(attempt-1)) * time.Second):
+            }
+        }
+        if doc.Meter != nil && !doc.Meter.Allow() { return nil, usage, ErrBudgetExhausted }
+        var content string
+        var u ports.AIUsage
+        actx, cancel := context.WithTimeout(ctx, timeout)
+        content, u, err = send(actx)
+        cancel()
+        u.Calls = 1
+        usage.Add(u)
+        if doc.Meter != nil { doc.Meter.Charge(info, u) }
+        if err == nil {
+            var facts []ports.Fact
+            if facts, err = parseFacts(content, doc); err == nil { return facts, usage, nil }
+        }
+        if ctx.Err() != nil { return nil, usage, ctx.Err() }
+        if !retryable(err) { return nil, usage, err }
+    }
+    return nil, usage, err
+}
+
+// statusError is a non-2xx reply from a provider.
+type statusError struct {
+    Code int
+    Body string
+}
+
+func (e *statusError) Error() string { return fmt.Sprintf("ai: status %d: %s", e.Code, e.Body) }
+
+func retryable(err error) bool {
+    var se *statusError
+    if errors.As(err, &se) { return se.Code == 429 || se.Code >= 500 }
+    var ne net.Error
+    return errors.Is(err, ErrInvalidOutput) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &ne)
+}
+
+func containsString(xs []string, x string) bool {
+    for _, v := range xs {
+        if v == x { return true }
+    }
+    return false
+}
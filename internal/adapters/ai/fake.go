@@ -0,0 +1,71 @@
+package ai
+
+import (
+    "context"
+    "strings"
+    "sync"
+
+    "camille/internal/ports"
+)
+
+// Step is one scripted reply: the first step whose Match appears in the request
+// content answers it with Reply (raw model output) or Err.
+type Step struct {
+    Match string
+    Reply string
+    Err   error
+}
+
+// Scripted is a deterministic extractor for tests and offline runs. Replies go
+// through the same schema validation and whitelisting as real providers.
+type Scripted struct {
+    Model  string
+    Prompt *Prompt // defaults to DefaultPromptVersion
+    Steps  []Step
+    // Default answers requests no step matches; empty means {"facts":[]}.
+    Default string
+
+    mu    sync.Mutex
+    calls int
+}
+
+func (s *Scripted) Info() ports.AIModelInfo {
+    model := s.Model
+    if model == "" { model = "scripted" }
+    return s.prompt().info("fake", model)
+}
+
+func (s *Scripted) prompt() *Prompt {
+    if s.Prompt == nil { return defaultPrompt() }
+    return s.Prompt
+}
+
+// ExtractFacts reports usage estimated at four bytes per token, so budgets can be
+// exercised offline.
+func (s *Scripted) ExtractFacts(ctx context.Context, doc ports.PolicyDoc) ([]ports.Fact, ports.AIUsage, error) {
+    s.mu.Lock()
+    s.calls++
+    s.mu.Unlock()
+    system, user, err := s.prompt().Render(doc)
+    if err != nil { return nil, ports.AIUsage{}, err }
+    prompt := (len(system) + len(user) + 3) / 4
+    return complete(ctx, 0, 0, s.Info(), doc, func(context.Context) (string, ports.AIUsage, error) {
+        reply := s.Default
+        if reply == "" { reply = `{"facts":[]}` }
+        for _, st := range s.Steps {
+            if strings.Contains(doc.Content, st.Match) {
+                if st.Err != nil { return "", ports.AIUsage{PromptTokens: prompt}, st.Err }
+                reply = st.Reply
+                break
+            }
+        }
+        return reply, ports.AIUsage{PromptTokens: prompt, CompletionTokens: (len(reply) + 3) / 4}, nil
+    })
+}
+
+// Calls reports how many extractions were requested.
+func (s *Scripted) Calls() int {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.calls
+}
@@ -0,0 +1,159 @@
+package ai
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "testing"
+    "time"
+
+    "camille/internal/ports"
+)
+
+const saleReply = `{"facts":[
+    {"quote":"We do not sell your personal data.","category":"data_sale","value":false,"confidence":0.9},
+    {"quote":"We do not sell your personal data.","category":"not_a_category","value":false,"confidence":0.9},
+    {"quote":"short","category":"data_sharing","value":true,"confidence":0.9}
+]}`
+
+func TestScriptedValidatesReplies(t *testing.T) {
+    s := &Scripted{Steps: []Step{
+        {Match: "sell", Reply: "```json\n" + saleReply + "\n```"},
+        {Match: "broken", Reply: `{"facts": "none"}`},
+        {Match: "down", Err: errors.New("connection refused")},
+    }}
+    ctx := context.Background()
+
+    facts, usage, err := s.ExtractFacts(ctx, ports.PolicyDoc{Content: "We do not sell your personal data.", SourceURL: "https://example.com/privacy"})
+    if err != nil { t.Fatal(err) }
+    // The off-whitelist category and the too-short quote are dropped on their own.
+    if len(facts) != 1 || facts[0].Category != ports.AIDataSale || facts[0].Value || facts[0].SectionURL != "https://example.com/privacy" {
+        t.Errorf("facts = %+v", facts)
+    }
+    if usage.Calls != 1 || usage.PromptTokens == 0 || usage.CompletionTokens == 0 { t.Errorf("usage = %+v", usage) }
+
+    if _, _, err := s.ExtractFacts(ctx, ports.PolicyDoc{Content: "broken"}); !errors.Is(err, ErrInvalidOutput) {
+        t.Errorf("malformed envelope: err = %v, want ErrInvalidOutput", err)
+    }
+    if _, _, err := s.ExtractFacts(ctx, ports.PolicyDoc{Content: "down"}); err == nil { t.Error("step error not returned") }
+
+    facts, _, err = s.ExtractFacts(ctx, ports.PolicyDoc{Content: "nothing relevant"})
+    if err != nil || len(facts) != 0 { t.Errorf("default reply: facts = %v, err = %v", facts, err) }
+    if s.Calls() != 4 { t.Errorf("Calls() = %d, want 4", s.Calls()) }
+}
+
+// memStore is an in-memory cache and shadow repository.
+type memStore struct {
+    facts map[ports.AICacheKey][]ports.Fact
+    runs  []ports.AIShadowRun
+}
+
+func (m *memStore) GetAIFacts(ctx context.Context, key ports.AICacheKey) ([]ports.Fact, bool, error) {
+    f, ok := m.facts[key]
+    return append([]ports.Fact(nil), f...), ok, nil
+}
+
+func (m *memStore) PutAIFacts(ctx context.Context, key ports.AICacheKey, facts []ports.Fact) error {
+    if m.facts == nil { m.facts = map[ports.AICacheKey][]ports.Fact{} }
+    m.facts[key] = facts
+    return nil
+}
+
+func (m *memStore) InvalidateAIPrompt(ctx context.Context, promptVersion string) (int64, error) { return 0, nil }
+
+func (m *memStore) AICacheStats(ctx context.Context) ([]ports.AICacheStats, error) { return nil, nil }
+
+func (m *memStore) RecordAIShadow(ctx context.Context, run ports.AIShadowRun) error {
+    m.runs = append(m.runs, run)
+    return nil
+}
+
+func (m *memStore) AIShadowReport(ctx context.Context, primary, candidate string, since time.Time) (ports.AIShadowReport, error) {
+    return ports.AIShadowReport{}, nil
+}
+
+func TestCachedServesRepeatChunks(t *testing.T) {
+    next := &Scripted{Steps: []Step{{Match: "sell", Reply: saleReply}}}
+    c := &Cached{Next: next, Store: &memStore{}}
+    ctx := context.Background()
+    text := "We do not sell your personal data."
+
+    if _, usage, err := c.ExtractFacts(ctx, ports.PolicyDoc{Content: text, SectionURL: "https://a.example/privacy#sale"}); err != nil || usage.Calls != 1 {
+        t.Fatalf("miss: usage = %+v, err = %v", usage, err)
+    }
+    facts, usage, err := c.ExtractFacts(ctx, ports.PolicyDoc{Content: text, SectionURL: "https://b.example/privacy"})
+    if err != nil { t.Fatal(err) }
+    if next.Calls() != 1 || usage.Calls != 0 { t.Errorf("hit reached the extractor: calls = %d, usage = %+v", next.Calls(), usage) }
+    if len(facts) != 1 || facts[0].SectionURL != "https://b.example/privacy" || facts[0].Metadata["cached"] != true {
+        t.Errorf("cached facts = %+v", facts)
+    }
+
+    // A different category subset is a different question.
+    if _, _, err := c.ExtractFacts(ctx, ports.PolicyDoc{Content: text, Categories: []string{ports.AIDataSale}}); err != nil { t.Fatal(err) }
+    if next.Calls() != 2 { t.Errorf("calls = %d, want 2", next.Calls()) }
+}
+
+// countMeter allows a fixed number of requests and records what each charged.
+type countMeter struct {
+    left    int
+    charged []string
+}
+
+func (m *countMeter) Allow() bool { return m.left > 0 }
+
+func (m *countMeter) Charge(info ports.AIModelInfo, u ports.AIUsage) {
+    m.left--
+    m.charged = append(m.charged, info.Model)
+}
+
+func TestMeterGatesRequests(t *testing.T) {
+    s := &Scripted{Model: "primary", Steps: []Step{{Match: "sell", Reply: saleReply}}}
+    m := &countMeter{left: 1}
+    facts, _, err := s.ExtractFacts(context.Background(), ports.PolicyDoc{Content: "We do not sell your personal data.", Meter: m})
+    if err != nil || len(facts) != 1 { t.Fatalf("facts = %v, err = %v", facts, err) }
+    if fmt.Sprint(m.charged) != "[primary]" { t.Errorf("charged = %v", m.charged) }
+    if _, _, err := s.ExtractFacts(context.Background(), ports.PolicyDoc{Content: "We do not sell your personal data.", Meter: m}); !errors.Is(err, ErrBudgetExhausted) {
+        t.Errorf("err = %v, want ErrBudgetExhausted", err)
+    }
+}
+
+func TestShadowRecordsBothExtractions(t *testing.T) {
+    store := &memStore{}
+    sh := &Shadow{
+        Primary:   &Scripted{Model: "primary", Steps: []Step{{Match: "sell", Reply: saleReply}}},
+        Candidate: &Scripted{Model: "candidate", Steps: []Step{{Match: "sell", Reply: `{"facts":[{"quote":"We do not sell your personal data.","category":"data_sale","value":true,"confidence":0.6}]}`}}},
+        Store:     store,
+    }
+    facts, usage, err := sh.ExtractFacts(context.Background(), ports.PolicyDoc{Content: "We do not sell your personal data."})
+    if err != nil { t.Fatal(err) }
+    if len(facts) != 1 || facts[0].Value { t.Errorf("shadow must answer with the primary's facts, got %+v", facts) }
+    if usage.Calls != 2 { t.Errorf("usage.Calls = %d, want both extractions counted", usage.Calls) }
+    if len(store.runs) != 1 { t.Fatalf("runs = %d, want 1", len(store.runs)) }
+    run := store.runs[0]
+    if run.Candidate.Model != "candidate" || fmt.Sprint(run.Diff.Conflicts) != "[data_sale]" { t.Errorf("run = %+v", run) }
+
+    sh.Candidate = &Scripted{Steps: []Step{{Match: "sell", Err: errors.New("timeout")}}}
+    if _, _, err := sh.ExtractFacts(context.Background(), ports.PolicyDoc{Content: "We do not sell your personal data."}); err != nil {
+        t.Errorf("candidate failure must not fail the extraction: %v", err)
+    }
+    if got := store.runs[1].Error; got != "timeout" { t.Errorf("run error = %q", got) }
+}
+
+func TestMeterCoversShadowRequests(t *testing.T) {
+    store := &memStore{}
+    sh := &Shadow{
+        Primary:   &Scripted{Model: "primary", Steps: []Step{{Match: "sell", Reply: saleReply}}},
+        Candidate: &Scripted{Model: "candidate", Steps: []Step{{Match: "sell", Reply: saleReply}}},
+        Store:     store,
+    }
+    m := &countMeter{left: 1}
+    facts, _, err := sh.ExtractFacts(context.Background(), ports.PolicyDoc{Content: "We do not sell your personal data.", Meter: m})
+    if err != nil || len(facts) != 1 { t.Fatalf("facts = %v, err = %v", facts, err) }
+    if fmt.Sprint(m.charged) != "[primary]" { t.Errorf("charged = %v, want only the primary", m.charged) }
+    if got := store.runs[0].Error; got != ErrBudgetExhausted.Error() { t.Errorf("candidate run error = %q, want budget exhausted", got) }
+
+    m = &countMeter{}
+    if _, _, err := sh.ExtractFacts(context.Background(), ports.PolicyDoc{Content: "We do not sell your personal data.", Meter: m}); !errors.Is(err, ErrBudgetExhausted) {
+        t.Errorf("err = %v, want ErrBudgetExhausted", err)
+    }
+}
@@ -0,0 +1,85 @@
+package ai
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "time"
+
+    "camille/internal/ports"
+)
+
+const DefaultOllamaBaseURL = "http://localhost:11434"
+
+// OllamaExtractor uses Ollama's /api/chat with the facts schema as the structured
+// output format.
+type OllamaExtractor struct {
+    BaseURL    string // defaults to DefaultOllamaBaseURL
+    Model      string // e.g., "llama3.2", "mistral"
+    Timeout    time.Duration
+    Retries    int
+    Prompt     *Prompt // defaults to DefaultPromptVersion
+    HTTPClient *http.Client
+}
+
+type ollamaRequest struct {
+    Model    string         `json:"model"`
+    Messages []message      `json:"messages"`
+    Stream   bool           `json:"stream"`
+    Format   any            `json:"format"`
+    Options  map[string]any `json:"options,omitempty"`
+}
+
+type ollamaResponse struct {
+    Message struct {
+        Content string `json:"content"`
+    } `json:"message"`
+    PromptEvalCount int    `json:"prompt_eval_count"`
+    EvalCount       int    `json:"eval_count"`
+    Error string `json:"error,omitempty"`
+}
+
+func (e *OllamaExtractor) Info() ports.AIModelInfo {
+    return e.prompt().info("ollama", e.Model)
+}
+
+func (e *OllamaExtractor) prompt() *Prompt {
+    if e.Prompt == nil { return defaultPrompt() }
+    return e.Prompt
+}
+
+func (e *OllamaExtractor) ExtractFacts(ctx context.Context, doc ports.PolicyDoc) ([]ports.Fact, ports.AIUsage, error) {
+    system, user, err := e.prompt().Render(doc)
+    if err != nil { return nil, ports.AIUsage{}, err }
+    body, err := json.Marshal(ollamaRequest{
+        Model: e.Model,
+        Messages: []message{
+            {Role: "system", Content: system},
+            {Role: "user", Content: user},
+        },
+        Format:  wireSchema(factsSchema),
+        Options: map[string]any{"temperature": 0},
+    })
+    if err != nil { return nil, ports.AIUsage{}, fmt.Errorf("marshal request: %w", err) }
+
+    base := e.BaseURL
+    if base == "" { base = DefaultOllamaBaseURL }
+    endpoint := strings.TrimSuffix(base, "/") + "/api/chat"
+    return complete(ctx, e.Timeout, e.Retries, e.Info(), doc, func(ctx context.Context) (string, ports.AIUsage, error) {
+        var usage ports.AIUsage
+        req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+        if err != nil { return "", usage, err }
+        req.Header.Set("Content-Type", "application/json")
+
+        raw, err := post(e.HTTPClient, req)
+        if err != nil { return "", usage, err }
+        var resp ollamaResponse
+        if err := json.Unmarshal(raw, &resp); err != nil { return "", usage, fmt.Errorf("%w: %v", ErrInvalidOutput, err) }
+        usage.PromptTokens, usage.CompletionTokens = resp.PromptEvalCount, resp.EvalCount
+        if resp.Error != "" { return "", usage, fmt.Errorf("ollama error: %s", resp.Error) }
+        return resp.Message.Content, usage, nil
+    })
+}
@@ -0,0 +1,121 @@
+package ai
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "strings"
+    "time"
+
+    "camille/internal/ports"
+)
+
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIExtractor talks to any OpenAI-compatible chat completions endpoint
+// (OpenAI itself, vLLM, llama.cpp server, LM Studio, …).
+type OpenAIExtractor struct {
+    BaseURL string // defaults to DefaultOpenAIBaseURL
+    APIKey  string
+    Model   string // e.g., "gpt-4o-mini"
+    Timeout time.Duration
+    Retries int
+    Prompt  *Prompt // defaults to DefaultPromptVersion
+    // JSONObject asks for plain JSON mode instead of a strict json_schema, for
+    // compatible servers that do not implement structured outputs.
+    JSONObject bool
+    HTTPClient *http.Client
+}
+
+type openAIRequest struct {
+    Model          string         `json:"model"`
+    Messages       []message      `json:"messages"`
+    Temperature    float64        `json:"temperature"`
+    ResponseFormat map[string]any `json:"response_format,omitempty"`
+}
+
+type message struct {
+    Role    string `json:"role"`
+    Content string `json:"content"`
+}
+
+type openAIResponse struct {
+    Choices []struct {
+        Message struct {
+            Content string `json:"content"`
+        } `json:"message"`
+    } `json:"choices"`
+    Usage struct {
+        PromptTokens     int `json:"prompt_tokens"`
+        CompletionTokens int `json:"completion_tokens"`
+    } `json:"usage"`
+    Error *struct {
+        Message string `json:"message"`
+    } `json:"error,omitempty"`
+}
+
+func (e *OpenAIExtractor) Info() ports.AIModelInfo {
+    return e.prompt().info("openai", e.Model)
+}
+
+func (e *OpenAIExtractor) prompt() *Prompt {
+    if e.Prompt == nil { return defaultPrompt() }
+    return e.Prompt
+}
+
+func (e *OpenAIExtractor) ExtractFacts(ctx context.Context, doc ports.PolicyDoc) ([]ports.Fact, ports.AIUsage, error) {
+    system, user, err := e.prompt().Render(doc)
+    if err != nil { return nil, ports.AIUsage{}, err }
+    format := map[string]any{
+        "type":        "json_schema",
+        "json_schema": map[string]any{"name": "policy_facts", "strict": true, "schema": wireSchema(factsSchema)},
+    }
+    if e.JSONObject { format = map[string]any{"type": "json_object"} }
+    body, err := json.Marshal(openAIRequest{
+        Model:       e.Model,
+        Temperature: 0,
+        Messages: []message{
+            {Role: "system", Content: system},
+            {Role: "user", Content: user},
+        },
+        ResponseFormat: format,
+    })
+    if err != nil { return nil, ports.AIUsage{}, fmt.Errorf("marshal request: %w", err) }
+
+    base := e.BaseURL
+    if base == "" { base = DefaultOpenAIBaseURL }
+    endpoint := strings.TrimSuffix(base, "/") + "/chat/completions"
+    return complete(ctx, e.Timeout, e.Retries, e.Info(), doc, func(ctx context.Context) (string, ports.AIUsage, error) {
+        var usage ports.AIUsage
+        req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+        if err != nil { return "", usage, err }
+        req.Header.Set("Content-Type", "application/json")
+        if e.APIKey != "" { req.Header.Set("Authorization", "Bearer "+e.APIKey) }
+
+        raw, err := post(e.HTTPClient, req)
+        if err != nil { return "", usage, err }
+        var resp openAIResponse
+        if err := json.Unmarshal(raw, &resp); err != nil { return "", usage, fmt.Errorf("%w: %v", ErrInvalidOutput, err) }
+        usage.PromptTokens, usage.CompletionTokens = resp.Usage.PromptTokens, resp.Usage.CompletionTokens
+        if resp.Error != nil { return "", usage, fmt.Errorf("openai error: %s", resp.Error.Message) }
+        if len(resp.Choices) == 0 { return "", usage, fmt.Errorf("%w: no choices in response", ErrInvalidOutput) }
+        return resp.Choices[0].Message.Content, usage, nil
+    })
+}
+
+// post sends req and returns the body of a 2xx reply; other statuses become *statusError.
+func post(client *http.Client, req *http.Request) ([]byte, error) {
+    if client == nil { client = http.DefaultClient }
+    resp, err := client.Do(req)
+    if err != nil { return nil, err }
+    defer resp.Body.Close()
+    body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+    if err != nil { return nil, err }
+    if resp.StatusCode/100 != 2 {
+        return nil, &statusError{Code: resp.StatusCode, Body: string(body[:min(len(body), 300)])}
+    }
+    return body, nil
+}
@@ -0,0 +1,135 @@
+package ai
+
+import (
+    "crypto/sha256"
+    "embed"
+    "encoding/hex"
+    "fmt"
+    "io/fs"
+    "os"
+    "path"
+    "sort"
+    "strings"
+    "sync"
+    "text/template"
+    "unicode/utf8"
+
+    "camille/internal/ports"
+)
+
+// DefaultPromptVersion is the prompt extractors use unless configured otherwise.
+const DefaultPromptVersion = "facts-v1"
+
+//go:embed prompts/*.tmpl
+var builtinPrompts embed.FS
+
+// Prompt is a versioned prompt template. A template file defines a "system" and a
+// "user" block; its version is the file name without ".tmpl" and its hash the
+// sha256 of the file, so an edited prompt is told apart even if its name is not.
+type Prompt struct {
+    Version string
+    Hash    string
+    tmpl    *template.Template
+}
+
+// promptData is what a template sees.
+type promptData struct {
+    URL        string
+    Language   string
+    Content    string
+    Categories []struct{ Name, Description string }
+}
+
+// Render builds the system and user messages for doc.
+func (p *Prompt) Render(doc ports.PolicyDoc) (system, user string, err error) {
+    data := promptData{URL: doc.SectionURL, Language: doc.Language, Content: doc.Content}
+    if len(data.Content) > maxContent {
+        cut := maxContent
+        for !utf8.RuneStart(data.Content[cut]) { cut-- }
+        data.Content = data.Content[:cut]
+    }
+    cats := requested(doc)
+    for _, c := range ports.AICategories {
+        if containsString(cats, c.Name) { data.Categories = append(data.Categories, c) }
+    }
+    var sb, ub strings.Builder
+    if err := p.tmpl.ExecuteTemplate(&sb, "system", data); err != nil { return "", "", err }
+    if err := p.tmpl.ExecuteTemplate(&ub, "user", data); err != nil { return "", "", err }
+    return sb.String(), ub.String(), nil
+}
+
+// info stamps provider and model with the prompt's identity.
+func (p *Prompt) info(provider, model string) ports.AIModelInfo {
+    return ports.AIModelInfo{Provider: provider, Model: model, PromptVersion: p.Version, PromptHash: p.Hash}
+}
+
+// Prompts is a registry of prompt versions.
+type Prompts struct {
+    byVersion map[string]*Prompt
+}
+
+// LoadPrompts reads the built-in prompts and then every *.tmpl in dir (if set),
+// which may add versions but not redefine built-in ones.
+func LoadPrompts(dir string) (*Prompts, error) {
+    r := &Prompts{byVersion: map[string]*Prompt{}}
+    if err := r.load(builtinPrompts, "prompts", false); err != nil { return nil, err }
+    if dir != "" {
+        if err := r.load(os.DirFS(dir), ".", true); err != nil { return nil, err }
+    }
+    return r, nil
+}
+
+func (r *Prompts) load(fsys fs.FS, dir string, external bool) error {
+    names, err := fs.Glob(fsys, path.Join(dir, "*.tmpl"))
+    if err != nil { return err }
+    for _, name := range names {
+        raw, err := fs.ReadFile(fsys, name)
+        if err != nil { return err }
+        p, err := ParsePrompt(strings.TrimSuffix(path.Base(name), ".tmpl"), raw)
+        if err != nil { return err }
+        if prev, ok := r.byVersion[p.Version]; ok && external && prev.Hash != p.Hash {
+            return fmt.Errorf("prompt %s: differs from the built-in version; save it under a new name", p.Version)
+        }
+        r.byVersion[p.Version] = p
+    }
+    return nil
+}
+
+// ParsePrompt compiles a template and checks it renders both messages.
+func ParsePrompt(version string, raw []byte) (*Prompt, error) {
+    tmpl, err := template.New(version).Option("missingkey=error").Parse(string(raw))
+    if err != nil { return nil, fmt.Errorf("prompt %s: %w", version, err) }
+    sum := sha256.Sum256(raw)
+    p := &Prompt{Version: version, Hash: hex.EncodeToString(sum[:]), tmpl: tmpl}
+    for _, block := range []string{"system", "user"} {
+        if tmpl.Lookup(block) == nil { return nil, fmt.Errorf("prompt %s: missing %q block", version, block) }
+    }
+    if _, _, err := p.Render(ports.PolicyDoc{Content: "sample", SectionURL: "https://example.com/privacy"}); err != nil {
+        return nil, fmt.Errorf("prompt %s: %w", version, err)
+    }
+    return p, nil
+}
+
+// Get returns a prompt by version.
+func (r *Prompts) Get(version string) (*Prompt, error) {
+    p, ok := r.byVersion[version]
+    if !ok { return nil, fmt.Errorf("unknown prompt version %q", version) }
+    return p, nil
+}
+
+// List returns every prompt, by version.
+func (r *Prompts) List() []*Prompt {
+    out := make([]*Prompt, 0, len(r.byVersion))
+    for _, p := range r.byVersion { out = append(out, p) }
+    sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+    return out
+}
+
+// defaultPrompt is the built-in DefaultPromptVersion, for extractors built without one.
+var defaultPrompt = sync.OnceValue(func() *Prompt {
+    r, err := LoadPrompts("")
+    if err != nil { panic(err) }
+    p, err := r.Get(DefaultPromptVersion)
+    if err != nil { panic(err) }
+    return p
+})
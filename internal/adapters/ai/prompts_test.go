@@ -0,0 +1,27 @@
+package ai
+
+import (
+    "strings"
+    "testing"
+    "unicode/utf8"
+
+    "camille/internal/ports"
+)
+
+func TestRenderCutsAtRuneBoundary(t *testing.T) {
+    // 'x' then two-byte runes: maxContent (even) lands mid-rune.
+    content := "x" + strings.Repeat("é", maxContent)
+    _, user, err := defaultPrompt().Render(ports.PolicyDoc{Content: content})
+    if err != nil { t.Fatal(err) }
+    if !utf8.ValidString(user) { t.Fatal("rendered prompt is not valid UTF-8") }
+    body := user[strings.Index(user, "<<<\n")+4 : strings.Index(user, "\n>>>")]
+    if len(body) != maxContent-1 { t.Errorf("content cut to %d bytes, want %d", len(body), maxContent-1) }
+}
+
+func TestRenderRequestedCategories(t *testing.T) {
+    _, user, err := defaultPrompt().Render(ports.PolicyDoc{Content: "We never sell data.", Categories: []string{ports.AIDataSale}})
+    if err != nil { t.Fatal(err) }
+    if !strings.Contains(user, "- data_sale:") || strings.Contains(user, "- data_sharing:") {
+        t.Errorf("prompt lists the wrong categories:\n%s", user)
+    }
+}
@@ -0,0 +1,97 @@
+package ai
+
+import (
+    "fmt"
+    "unicode/utf8"
+)
+
+// validate checks v (decoded JSON) against the subset of JSON Schema factsSchema
+// uses: type, properties, required, additionalProperties, items, enum,
+// minLength/maxLength and minimum/maximum.
+func validate(schema map[string]any, v any, path string) error {
+    switch schema["type"] {
+    case "object":
+        obj, ok := v.(map[string]any)
+        if !ok { return fmt.Errorf("%s: want object", path) }
+        props, _ := schema["properties"].(map[string]any)
+        for _, r := range asSlice(schema["required"]) {
+            if _, ok := obj[r.(string)]; !ok { return fmt.Errorf("%s: missing %q", path, r) }
+        }
+        for k, val := range obj {
+            sub, ok := props[k].(map[string]any)
+            if !ok {
+                if schema["additionalProperties"] == false { return fmt.Errorf("%s: unexpected property %q", path, k) }
+                continue
+            }
+            if err := validate(sub, val, path+"."+k); err != nil { return err }
+        }
+    case "array":
+        arr, ok := v.([]any)
+        if !ok { return fmt.Errorf("%s: want array", path) }
+        if items, ok := schema["items"].(map[string]any); ok {
+            for i, item := range arr {
+                if err := validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil { return err }
+            }
+        }
+    case "string":
+        s, ok := v.(string)
+        if !ok { return fmt.Errorf("%s: want string", path) }
+        n := utf8.RuneCountInString(s)
+        if min, ok := asFloat(schema["minLength"]); ok && float64(n) < min { return fmt.Errorf("%s: shorter than %v", path, min) }
+        if max, ok := asFloat(schema["maxLength"]); ok && float64(n) > max { return fmt.Errorf("%s: longer than %v", path, max) }
+    case "number":
+        f, ok := v.(float64)
+        if !ok { return fmt.Errorf("%s: want number", path) }
+        if min, ok := asFloat(schema["minimum"]); ok && f < min { return fmt.Errorf("%s: below %v", path, min) }
+        if max, ok := asFloat(schema["maximum"]); ok && f > max { return fmt.Errorf("%s: above %v", path, max) }
+    case "boolean":
+        if _, ok := v.(bool); !ok { return fmt.Errorf("%s: want boolean", path) }
+    }
+    if enum := asSlice(schema["enum"]); enum != nil {
+        for _, e := range enum {
+            if e == v { return nil }
+        }
+        return fmt.Errorf("%s: %v not allowed", path, v)
+    }
+    return nil
+}
+
+func asSlice(v any) []any {
+    s, _ := v.([]any)
+    return s
+}
+
+func asFloat(v any) (float64, bool) {
+    switch n := v.(type) {
+    case int:
+        return float64(n), true
+    case float64:
+        return n, true
+    }
+    return 0, false
+}
+
+// wireSchema returns schema without the bounds keywords (minLength, maximum, …)
+// strict structured-output modes reject; validate still enforces them locally.
+func wireSchema(schema map[string]any) map[string]any {
+    out := map[string]any{}
+    for k, v := range schema {
+        switch k {
+        case "minLength", "maxLength", "minimum", "maximum":
+            continue
+        }
+        switch t := v.(type) {
+        case map[string]any:
+            if k == "properties" {
+                props := map[string]any{}
+                for name, sub := range t { props[name] = wireSchema(sub.(map[string]any)) }
+                out[k] = props
+            } else {
+                out[k] = wireSchema(t)
+            }
+        default:
+            out[k] = v
+        }
+    }
+    return out
+}
@@ -0,0 +1,76 @@
+package ai
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "log"
+
+    "camille/internal/ports"
+)
+
+// Shadow answers with Primary and also runs Candidate on every chunk, recording
+// both fact sets and their per-category diff so a new prompt can be judged on live
+// traffic before it is promoted. The candidate's usage counts against budgets.
+type Shadow struct {
+    Primary   ports.AIExtractor
+    Candidate ports.AIExtractor
+    Store     ports.AIShadowRepository
+}
+
+func (s *Shadow) Info() ports.AIModelInfo { return s.Primary.Info() }
+
+func (s *Shadow) ExtractFacts(ctx context.Context, doc ports.PolicyDoc) ([]ports.Fact, ports.AIUsage, error) {
+    facts, usage, err := s.Primary.ExtractFacts(ctx, doc)
+    if err != nil { return nil, usage, err }
+    cand, cu, cerr := s.Candidate.ExtractFacts(ctx, doc)
+    usage.Add(cu)
+    sum := sha256.Sum256([]byte(doc.Content))
+    run := ports.AIShadowRun{
+        ChunkHash: hex.EncodeToString(sum[:]), SourceURL: doc.SectionURL,
+        Primary: s.Primary.Info(), Candidate: s.Candidate.Info(),
+        PrimaryFacts: facts, CandidateFacts: cand,
+    }
+    if run.SourceURL == "" { run.SourceURL = doc.SourceURL }
+    if cerr != nil {
+        run.Error = cerr.Error()
+    } else {
+        run.Diff = CompareFacts(facts, cand)
+    }
+    if err := s.Store.RecordAIShadow(ctx, run); err != nil { log.Printf("ai shadow record: %v", err) }
+    return facts, usage, nil
+}
+
+// CompareFacts diffs two extractions of the same text by category, in
+// ports.AICategories order. Quotes are not compared: prompts may pick different
+// sentences for the same finding.
+func CompareFacts(primary, candidate []ports.Fact) ports.AIFactDiff {
+    a, b := factValues(primary), factValues(candidate)
+    d := ports.AIFactDiff{Agreed: []string{}, Conflicts: []string{}, PrimaryOnly: []string{}, CandidateOnly: []string{}}
+    for _, c := range ports.AICategories {
+        va, inA := a[c.Name]
+        vb, inB := b[c.Name]
+        switch {
+        case inA && inB && va == vb:
+            d.Agreed = append(d.Agreed, c.Name)
+        case inA && inB:
+            d.Conflicts = append(d.Conflicts, c.Name)
+        case inA:
+            d.PrimaryOnly = append(d.PrimaryOnly, c.Name)
+        case inB:
+            d.CandidateOnly = append(d.CandidateOnly, c.Name)
+        }
+    }
+    return d
+}
+
+// factValues maps each category to the set of values found for it (bit 0 false, bit 1 true).
+func factValues(facts []ports.Fact) map[string]int {
+    out := map[string]int{}
+    for _, f := range facts {
+        bit := 1
+        if f.Value { bit = 2 }
+        out[f.Category] |= bit
+    }
+    return out
+}
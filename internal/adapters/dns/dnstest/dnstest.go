@@ -0,0 +1,93 @@
+// Package dnstest runs an in-process authoritative DNS server over a fixed set
+// of records, for testing scanners against dnsadapter.Resolver without the network.
+package dnstest
+
+import (
+    "net"
+    "strings"
+    "sync"
+    "testing"
+
+    "github.com/miekg/dns"
+
+    dnsadapter "camille/internal/adapters/dns"
+)
+
+// Server answers from its records. Names with no records at all get NXDOMAIN;
+// names listed in Fail get SERVFAIL; Validated sets the AD bit on every answer.
+type Server struct {
+    Addr      string
+    Validated bool
+
+    mu      sync.Mutex
+    records map[string][]dns.RR // lower-cased FQDN
+    fail    map[string]bool
+    srv     *dns.Server
+}
+
+// Start serves records, given in zone file syntax ("example.com. 300 IN TXT \"v=spf1 -all\""),
+// on a random local UDP port and stops the server when the test ends.
+func Start(t testing.TB, records ...string) *Server {
+    t.Helper()
+    s := &Server{records: map[string][]dns.RR{}, fail: map[string]bool{}}
+    for _, r := range records { s.Add(t, r) }
+    pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+    if err != nil { t.Fatalf("dnstest: listen: %v", err) }
+    started := make(chan struct{})
+    s.srv = &dns.Server{PacketConn: pc, Handler: s, NotifyStartedFunc: func() { close(started) }}
+    go func() { _ = s.srv.ActivateAndServe() }()
+    <-started
+    s.Addr = pc.LocalAddr().String()
+    t.Cleanup(func() { _ = s.srv.Shutdown() })
+    return s
+}
+
+// Add parses one record in zone file syntax and serves it.
+func (s *Server) Add(t testing.TB, record string) {
+    t.Helper()
+    rr, err := dns.NewRR(record)
+    if err != nil { t.Fatalf("dnstest: %q: %v", record, err) }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    name := strings.ToLower(rr.Header().Name)
+    s.records[name] = append(s.records[name], rr)
+}
+
+// Fail makes every question about name answer SERVFAIL.
+func (s *Server) Fail(name string) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.fail[strings.ToLower(dns.Fqdn(name))] = true
+}
+
+// Resolver returns a resolver pointed at the server.
+func (s *Server) Resolver() *dnsadapter.Resolver {
+    return &dnsadapter.Resolver{Server: s.Addr, Timeout: dnsadapter.DefaultTimeout}
+}
+
+func (s *Server) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+    m := new(dns.Msg)
+    m.SetReply(req)
+    m.Authoritative = true
+    q := req.Question[0]
+    name := strings.ToLower(q.Name)
+
+    s.mu.Lock()
+    rrs, known := s.records[name]
+    failed := s.fail[name]
+    s.mu.Unlock()
+    switch {
+    case failed:
+        m.Rcode = dns.RcodeServerFailure
+    case !known:
+        m.Rcode = dns.RcodeNameError
+    default:
+        for _, rr := range rrs {
+            if rr.Header().Rrtype == q.Qtype || (rr.Header().Rrtype == dns.TypeCNAME && q.Qtype != dns.TypeCNAME) {
+                m.Answer = append(m.Answer, dns.Copy(rr))
+            }
+        }
+        m.AuthenticatedData = s.Validated
+    }
+    _ = w.WriteMsg(m)
+}
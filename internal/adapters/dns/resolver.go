@@ -0,0 +1,156 @@
+package dnsadapter
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "strings"
+    "time"
+
+    "github.com/miekg/dns"
+
+    "camille/internal/ports"
+)
+
+const DefaultTimeout = 5 * time.Second
+
+// Resolver queries a single DNS server directly. Pointing Server at a local
+// stand-in (e.g. a miekg/dns server on 127.0.0.1) makes scanners testable offline.
+type Resolver struct {
+    Server  string // host:port
+    Timeout time.Duration
+}
+
+// New returns a resolver for server ("host" or "host:port"). An empty server
+// uses the first nameserver from /etc/resolv.conf.
+func New(server string) (*Resolver, error) {
+    if server == "" {
+        conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+        if err != nil { return nil, fmt.Errorf("dns: %w", err) }
+        if len(conf.Servers) == 0 { return nil, fmt.Errorf("dns: no nameservers configured") }
+        server = net.JoinHostPort(conf.Servers[0], conf.Port)
+    }
+    if _, _, err := net.SplitHostPort(server); err != nil {
+        server = net.JoinHostPort(server, "53")
+    }
+    return &Resolver{Server: server, Timeout: DefaultTimeout}, nil
+}
+
+func (r *Resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+    msg, err := r.query(ctx, name, dns.TypeTXT)
+    if err != nil { return nil, err }
+    var out []string
+    for _, rr := range msg.Answer {
+        if txt, ok := rr.(*dns.TXT); ok {
+            out = append(out, strings.Join(txt.Txt, ""))
+        }
+    }
+    return out, nil
+}
+
+func (r *Resolver) LookupMX(ctx context.Context, name string) ([]ports.MXRecord, error) {
+    msg, err := r.query(ctx, name, dns.TypeMX)
+    if err != nil { return nil, err }
+    var out []ports.MXRecord
+    for _, rr := range msg.Answer {
+        if mx, ok := rr.(*dns.MX); ok {
+            out = append(out, ports.MXRecord{Host: strings.TrimSuffix(strings.ToLower(mx.Mx), "."), Pref: mx.Preference})
+        }
+    }
+    return out, nil
+}
+
+func (r *Resolver) LookupNS(ctx context.Context, name string) ([]string, error) {
+    msg, err := r.query(ctx, name, dns.TypeNS)
+    if err != nil { return nil, err }
+    var out []string
+    for _, rr := range msg.Answer {
+        if ns, ok := rr.(*dns.NS); ok {
+            out = append(out, strings.TrimSuffix(strings.ToLower(ns.Ns), "."))
+        }
+    }
+    return out, nil
+}
+
+// LookupCNAME returns the direct CNAME target of name, or "" when name is not an alias.
+func (r *Resolver) LookupCNAME(ctx context.Context, name string) (string, error) {
+    msg, err := r.query(ctx, name, dns.TypeCNAME)
+    if err != nil { return "", err }
+    for _, rr := range msg.Answer {
+        if c, ok := rr.(*dns.CNAME); ok && strings.EqualFold(c.Hdr.Name, dns.Fqdn(name)) {
+            return strings.TrimSuffix(strings.ToLower(c.Target), "."), nil
+        }
+    }
+    return "", nil
+}
+
+func (r *Resolver) LookupIP(ctx context.Context, name string) ([]net.IP, error) {
+    var out []net.IP
+    for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+        msg, err := r.query(ctx, name, qtype)
+        if err != nil { return nil, err }
+        for _, rr := range msg.Answer {
+            switch a := rr.(type) {
+            case *dns.A:
+                out = append(out, a.A)
+            case *dns.AAAA:
+                out = append(out, a.AAAA)
+            }
+        }
+    }
+    return out, nil
+}
+
+func (r *Resolver) LookupCAA(ctx context.Context, name string) ([]ports.CAARecord, error) {
+    msg, err := r.query(ctx, name, dns.TypeCAA)
+    if err != nil { return nil, err }
+    var out []ports.CAARecord
+    for _, rr := range msg.Answer {
+        if c, ok := rr.(*dns.CAA); ok {
+            out = append(out, ports.CAARecord{Flag: c.Flag, Tag: strings.ToLower(c.Tag), Value: c.Value})
+        }
+    }
+    return out, nil
+}
+
+// LookupDNSSEC asks for DNSKEY and DS with the DO bit set. Validated relies on
+// the configured server being a validating resolver.
+func (r *Resolver) LookupDNSSEC(ctx context.Context, name string) (ports.DNSSECStatus, error) {
+    var st ports.DNSSECStatus
+    msg, err := r.query(ctx, name, dns.TypeDNSKEY)
+    if err != nil { return st, err }
+    for _, rr := range msg.Answer {
+        if _, ok := rr.(*dns.DNSKEY); ok { st.DNSKEY = true }
+    }
+    st.Validated = msg.AuthenticatedData
+    msg, err = r.query(ctx, name, dns.TypeDS)
+    if err != nil { return st, err }
+    for _, rr := range msg.Answer {
+        if _, ok := rr.(*dns.DS); ok { st.DS = true }
+    }
+    return st, nil
+}
+
+// query sends a recursive question, retrying over TCP when the UDP answer is truncated.
+func (r *Resolver) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+    m := new(dns.Msg)
+    m.SetQuestion(dns.Fqdn(name), qtype)
+    m.RecursionDesired = true
+    m.SetEdns0(4096, qtype == dns.TypeDNSKEY || qtype == dns.TypeDS)
+
+    timeout := r.Timeout
+    if timeout <= 0 { timeout = DefaultTimeout }
+    c := &dns.Client{Net: "udp", Timeout: timeout}
+    resp, _, err := c.ExchangeContext(ctx, m, r.Server)
+    if err == nil && resp.Truncated {
+        c.Net = "tcp"
+        resp, _, err = c.ExchangeContext(ctx, m, r.Server)
+    }
+    if err != nil { return nil, fmt.Errorf("dns %s %s: %w", dns.TypeToString[qtype], name, err) }
+    switch resp.Rcode {
+    case dns.RcodeSuccess, dns.RcodeNameError:
+        return resp, nil
+    default:
+        return nil, fmt.Errorf("dns %s %s: %s", dns.TypeToString[qtype], name, dns.RcodeToString[resp.Rcode])
+    }
+}
@@ -0,0 +1,116 @@
+// Package extract implements ports.DocumentExtractor for the document formats
+// policies are published in, and a Mux that picks one by content type.
+package extract
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "mime"
+    "strings"
+    "unicode"
+
+    "golang.org/x/text/unicode/norm"
+
+    "camille/internal/ports"
+)
+
+// Mux dispatches to an extractor by media type, sniffing the body when the
+// declared type is missing or generic.
+type Mux struct {
+    ByType map[string]ports.DocumentExtractor
+}
+
+// New returns a Mux with the HTML, PDF and plain text extractors registered.
+func New() *Mux {
+    h := &HTML{}
+    return &Mux{ByType: map[string]ports.DocumentExtractor{
+        "text/html":             h,
+        "application/xhtml+xml": h,
+        "application/pdf":       &PDF{},
+        "text/plain":            &Text{},
+    }}
+}
+
+func (m *Mux) Extract(ctx context.Context, raw ports.RawDocument) (ports.Document, error) {
+    ex, ok := m.ByType[mediaType(raw)]
+    if !ok { return ports.Document{}, ports.ErrUnsupportedDocument }
+    return ex.Extract(ctx, raw)
+}
+
+func mediaType(raw ports.RawDocument) string {
+    mt, _, _ := mime.ParseMediaType(raw.ContentType)
+    mt = strings.ToLower(mt)
+    if mt != "" && mt != "application/octet-stream" && mt != "binary/octet-stream" { return mt }
+    head := strings.ToLower(strings.TrimSpace(string(raw.Body[:min(len(raw.Body), 512)])))
+    switch {
+    case strings.HasPrefix(head, "%pdf-"):
+        return "application/pdf"
+    case strings.HasPrefix(head, "<!doctype html"), strings.HasPrefix(head, "<html"), strings.Contains(head, "<body"):
+        return "text/html"
+    }
+    return "text/plain"
+}
+
+// DocHash is the doc_hash of text: sha256 over its NFC form with all whitespace
+// runs collapsed, so reflowed or re-marked-up copies of a policy hash the same.
+func DocHash(text string) string {
+    sum := sha256.Sum256([]byte(strings.Join(strings.Fields(norm.NFC.String(text)), " ")))
+    return hex.EncodeToString(sum[:])
+}
+
+// builder accumulates text blocks while tracking the section hierarchy and offset map.
+type builder struct {
+    text     strings.Builder
+    spans    []ports.SourceSpan
+    sections []ports.Section
+    open     []int
+}
+
+// block appends one normalized block of text and returns its start offset, or -1 if empty.
+func (b *builder) block(s, path string, page int) int {
+    s = clean(s)
+    if s == "" { return -1 }
+    if b.text.Len() > 0 { b.text.WriteByte('\n') }
+    start := b.text.Len()
+    b.text.WriteString(s)
+    b.spans = append(b.spans, ports.SourceSpan{Start: start, End: b.text.Len(), Path: path, Page: page})
+    return start
+}
+
+// heading appends a heading block and opens a section, closing any open sections
+// of the same or a deeper level.
+func (b *builder) heading(s string, level int, anchor, path string, page int) {
+    start := b.block(s, path, page)
+    if start < 0 { return }
+    for len(b.open) > 0 && b.sections[b.open[len(b.open)-1]].Level >= level {
+        b.sections[b.open[len(b.open)-1]].End = start
+        b.open = b.open[:len(b.open)-1]
+    }
+    parent := -1
+    if len(b.open) > 0 { parent = b.open[len(b.open)-1] }
+    b.sections = append(b.sections, ports.Section{
+        Heading: clean(s), Level: level, Anchor: anchor, Parent: parent, Start: start, Page: page,
+    })
+    b.open = append(b.open, len(b.sections)-1)
+}
+
+func (b *builder) document(raw ports.RawDocument) ports.Document {
+    for _, i := range b.open { b.sections[i].End = b.text.Len() }
+    b.open = nil
+    text := b.text.String()
+    return ports.Document{
+        URL:         raw.URL,
+        ContentType: raw.ContentType,
+        Text:        text,
+        Sections:    b.sections,
+        Spans:       b.spans,
+        Hash:        DocHash(text),
+        Unreadable:  strings.TrimSpace(text) == "",
+    }
+}
+
+// clean collapses whitespace (including no-break spaces) and normalizes to NFC.
+func clean(s string) string {
+    return norm.NFC.String(strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " "))
+}
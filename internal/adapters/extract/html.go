@@ -0,0 +1,248 @@
+package extract
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "regexp"
+    "strings"
+
+    "golang.org/x/net/html"
+
+    "camille/internal/ports"
+)
+
+// HTML extracts the readable body of a page: it prefers <main> or a lone <article>,
+// drops navigation, banners and scripts, keeps h1–h6 with their anchor ids and
+// records the DOM path of every text block.
+type HTML struct {
+    // MinMainText is the text length below which the chosen content root is
+    // abandoned for the whole <body>.
+    MinMainText int
+}
+
+var skipTags = map[string]bool{
+    "head": true, "script": true, "style": true, "noscript": true, "template": true, "svg": true,
+    "canvas": true, "iframe": true, "object": true, "embed": true, "button": true, "select": true,
+    "textarea": true, "input": true, "form": true, "nav": true, "aside": true, "dialog": true,
+}
+
+var blockTags = map[string]bool{
+    "address": true, "article": true, "blockquote": true, "body": true, "caption": true, "dd": true,
+    "details": true, "div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true,
+    "figure": true, "header": true, "footer": true, "li": true, "main": true, "ol": true, "p": true,
+    "pre": true, "section": true, "summary": true, "table": true, "tbody": true, "thead": true,
+    "tfoot": true, "tr": true, "ul": true, "br": true, "hr": true,
+}
+
+var skipRoles = map[string]bool{"navigation": true, "banner": true, "contentinfo": true, "search": true, "dialog": true, "alertdialog": true}
+
+// boilerplate matches id/class tokens of navigation, menus and consent banners.
+var boilerplate = regexp.MustCompile(`(?i)(^|[\s_-])(nav|navbar|navigation|menu|breadcrumbs?|sidebar|skip-?link|cookie-?(banner|consent|notice|bar)|consent-?banner|social|share)([\s_-]|$)`)
+
+func (x *HTML) Extract(_ context.Context, raw ports.RawDocument) (ports.Document, error) {
+    root, err := html.Parse(bytes.NewReader(raw.Body))
+    if err != nil { return ports.Document{}, err }
+    body := find(root, func(n *html.Node) bool { return n.Data == "body" })
+    if body == nil { body = root }
+
+    minText := x.MinMainText
+    if minText == 0 { minText = 500 }
+    var doc ports.Document
+    if content := contentRoot(body); content != nil { doc = walkDocument(raw, content, false) }
+    if len(doc.Text) < minText {
+        if whole := walkDocument(raw, body, true); len(whole.Text) > len(doc.Text) { doc = whole }
+    }
+
+    if t := find(root, func(n *html.Node) bool { return n.Data == "title" }); t != nil { doc.Title = clean(textOf(t)) }
+    if doc.Title == "" {
+        for _, s := range doc.Sections {
+            if s.Level == 1 { doc.Title = s.Heading; break }
+        }
+    }
+    if h := find(root, func(n *html.Node) bool { return n.Data == "html" }); h != nil {
+        lang, _, _ := strings.Cut(strings.ToLower(attr(h, "lang")), "-")
+        doc.Language = lang
+    }
+    return doc, nil
+}
+
+// contentRoot returns <main>, role=main, or the page's only <article>.
+func contentRoot(body *html.Node) *html.Node {
+    if m := find(body, func(n *html.Node) bool { return n.Data == "main" || attr(n, "role") == "main" }); m != nil { return m }
+    var articles []*html.Node
+    walkElements(body, func(n *html.Node) bool {
+        if n.Data == "article" { articles = append(articles, n); return false }
+        return true
+    })
+    if len(articles) == 1 { return articles[0] }
+    return nil
+}
+
+type walker struct {
+    b         builder
+    cur       strings.Builder
+    curPath   string
+    stripPage bool // also drop page-level header/footer (no content root was found)
+}
+
+func walkDocument(raw ports.RawDocument, root *html.Node, stripPage bool) ports.Document {
+    w := &walker{stripPage: stripPage}
+    path := domPath(root)
+    w.curPath = path
+    w.node(root, path)
+    w.flush()
+    return w.b.document(raw)
+}
+
+func (w *walker) node(n *html.Node, path string) {
+    switch n.Type {
+    case html.TextNode:
+        w.cur.WriteString(n.Data)
+        return
+    case html.ElementNode:
+    default:
+        for c := n.FirstChild; c != nil; c = c.NextSibling { w.node(c, path) }
+        return
+    }
+    if w.skip(n) { return }
+    if level := headingLevelOf(n); level > 0 {
+        w.flush()
+        w.b.heading(textOf(n), level, anchorFor(n), path, 0)
+        return
+    }
+    if n.Data == "td" || n.Data == "th" {
+        if strings.TrimSpace(w.cur.String()) != "" { w.cur.WriteString(" | ") }
+    }
+    block := blockTags[n.Data]
+    parentPath := w.curPath
+    if block {
+        w.flush()
+        w.curPath = path
+    }
+    counts := map[string]int{}
+    for c := n.FirstChild; c != nil; c = c.NextSibling {
+        childPath := path
+        if c.Type == html.ElementNode {
+            counts[c.Data]++
+            childPath = fmt.Sprintf("%s/%s[%d]", path, c.Data, counts[c.Data])
+        }
+        w.node(c, childPath)
+    }
+    if block {
+        w.flush()
+        w.curPath = parentPath
+    }
+}
+
+func (w *walker) flush() {
+    w.b.block(w.cur.String(), w.curPath, 0)
+    w.cur.Reset()
+}
+
+func (w *walker) skip(n *html.Node) bool {
+    if skipTags[n.Data] || skipRoles[attr(n, "role")] { return true }
+    if hasAttr(n, "hidden") || attr(n, "aria-hidden") == "true" { return true }
+    if strings.Contains(strings.ReplaceAll(strings.ToLower(attr(n, "style")), " ", ""), "display:none") { return true }
+    if w.stripPage && (n.Data == "header" || n.Data == "footer") && !insideTag(n, "article", "section") { return true }
+    return boilerplate.MatchString(attr(n, "id")) || boilerplate.MatchString(attr(n, "class"))
+}
+
+func headingLevelOf(n *html.Node) int {
+    if len(n.Data) == 2 && n.Data[0] == 'h' && n.Data[1] >= '1' && n.Data[1] <= '6' { return int(n.Data[1] - '0') }
+    if attr(n, "role") == "heading" {
+        if l := attr(n, "aria-level"); len(l) == 1 && l[0] >= '1' && l[0] <= '6' { return int(l[0] - '0') }
+        return 2
+    }
+    return 0
+}
+
+// anchorFor finds the fragment id that links to a heading: its own id, a named
+// anchor inside or just before it, or the id of a wrapper it opens.
+func anchorFor(h *html.Node) string {
+    if id := attr(h, "id"); id != "" { return id }
+    if a := find(h, func(n *html.Node) bool { return n.Data == "a" && (attr(n, "id") != "" || attr(n, "name") != "") }); a != nil {
+        return firstNonEmpty(attr(a, "id"), attr(a, "name"))
+    }
+    prev := h.PrevSibling
+    for prev != nil && prev.Type == html.TextNode && strings.TrimSpace(prev.Data) == "" { prev = prev.PrevSibling }
+    if prev != nil && prev.Type == html.ElementNode && strings.TrimSpace(textOf(prev)) == "" {
+        if id := firstNonEmpty(attr(prev, "id"), attr(prev, "name")); id != "" { return id }
+    }
+    if p := h.Parent; p != nil && prev == nil && p.Data != "body" && p.Data != "main" {
+        return attr(p, "id")
+    }
+    return ""
+}
+
+// domPath is an XPath-like location such as /html/body/main[1].
+func domPath(n *html.Node) string {
+    var parts []string
+    for ; n != nil && n.Type == html.ElementNode; n = n.Parent {
+        idx := 1
+        for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+            if s.Type == html.ElementNode && s.Data == n.Data { idx++ }
+        }
+        parts = append([]string{fmt.Sprintf("%s[%d]", n.Data, idx)}, parts...)
+    }
+    return "/" + strings.Join(parts, "/")
+}
+
+func find(n *html.Node, match func(*html.Node) bool) *html.Node {
+    var found *html.Node
+    walkElements(n, func(c *html.Node) bool {
+        if found != nil { return false }
+        if match(c) { found = c; return false }
+        return true
+    })
+    return found
+}
+
+// walkElements visits element nodes depth-first; visit returns false to skip children.
+func walkElements(n *html.Node, visit func(*html.Node) bool) {
+    if n.Type == html.ElementNode && !visit(n) { return }
+    for c := n.FirstChild; c != nil; c = c.NextSibling { walkElements(c, visit) }
+}
+
+func insideTag(n *html.Node, tags ...string) bool {
+    for p := n.Parent; p != nil; p = p.Parent {
+        for _, t := range tags {
+            if p.Data == t { return true }
+        }
+    }
+    return false
+}
+
+func textOf(n *html.Node) string {
+    var b strings.Builder
+    var walk func(*html.Node)
+    walk = func(n *html.Node) {
+        if n.Type == html.TextNode { b.WriteString(n.Data) }
+        if n.Type == html.ElementNode && skipTags[n.Data] { return }
+        if n.Type == html.ElementNode && n.Data == "br" { b.WriteByte(' ') }
+        for c := n.FirstChild; c != nil; c = c.NextSibling { walk(c) }
+    }
+    walk(n)
+    return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+    for _, a := range n.Attr {
+        if a.Key == key { return a.Val }
+    }
+    return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+    for _, a := range n.Attr {
+        if a.Key == key { return true }
+    }
+    return false
+}
+
+func firstNonEmpty(vals ...string) string {
+    for _, v := range vals {
+        if v != "" { return v }
+    }
+    return ""
+}
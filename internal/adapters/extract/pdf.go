@@ -0,0 +1,183 @@
+package extract
+
+import (
+    "bytes"
+    "context"
+    "fmt"
+    "math"
+    "sort"
+    "strings"
+    "unicode/utf8"
+
+    "github.com/ledongthuc/pdf"
+
+    "camille/internal/ports"
+)
+
+// PDF extracts text page by page. Lines set noticeably larger than the body text
+// become headings, ranked by size; every block records its page number. Documents
+// whose pages carry images but (almost) no text are marked Unreadable.
+type PDF struct {
+    // MaxPages bounds work on very long documents; 0 means 200.
+    MaxPages int
+}
+
+// minTextPerPage is the average characters per page below which an image-bearing
+// PDF is treated as scanned.
+const minTextPerPage = 40
+
+type pdfLine struct {
+    text string
+    size float64
+    bold bool
+    y    float64
+    page int
+}
+
+// Extract reports malformed PDFs as errors: the reader panics on bad objects
+// wherever it resolves them, including the page tree and the Info dictionary.
+func (x *PDF) Extract(ctx context.Context, raw ports.RawDocument) (doc ports.Document, err error) {
+    defer func() {
+        if p := recover(); p != nil { doc, err = ports.Document{}, fmt.Errorf("pdf: malformed: %v", p) }
+    }()
+    r, err := pdf.NewReader(bytes.NewReader(raw.Body), int64(len(raw.Body)))
+    if err != nil { return ports.Document{}, fmt.Errorf("pdf: %w", err) }
+    maxPages := x.MaxPages
+    if maxPages == 0 { maxPages = 200 }
+
+    var lines []pdfLine
+    images, pages := false, min(r.NumPage(), maxPages)
+    for n := 1; n <= pages; n++ {
+        if ctx.Err() != nil { return ports.Document{}, ctx.Err() }
+        p := r.Page(n)
+        if p.V.IsNull() { continue }
+        images = images || hasImages(p)
+        lines = append(lines, pageLines(p, n)...)
+    }
+
+    var b builder
+    chars := 0
+    for _, l := range lines { chars += utf8.RuneCountInString(l.text) }
+    if pages > 0 && chars >= minTextPerPage*pages || !images {
+        buildPDF(&b, lines)
+    }
+    doc = b.document(raw)
+    doc.Title = pdfTitle(r, doc)
+    return doc, nil
+}
+
+// buildPDF turns positioned lines into heading and paragraph blocks.
+func buildPDF(b *builder, lines []pdfLine) {
+    body := bodySize(lines)
+    levels := headingSizes(lines, body)
+    var para []string
+    var paraPage int
+    var lastY float64
+    flush := func() {
+        if len(para) > 0 { b.block(strings.Join(para, " "), fmt.Sprintf("page[%d]", paraPage), paraPage) }
+        para = nil
+    }
+    for _, l := range lines {
+        if level, ok := levels[roundSize(l.size)]; ok && isPDFHeading(l) {
+            flush()
+            b.heading(l.text, level, "", fmt.Sprintf("page[%d]", l.page), l.page)
+            continue
+        }
+        // A new page or a gap wider than ~1.5 lines starts a new paragraph.
+        if len(para) > 0 && (l.page != paraPage || lastY-l.y > body*1.8) { flush() }
+        if len(para) == 0 { paraPage = l.page }
+        para = append(para, l.text)
+        lastY = l.y
+    }
+    flush()
+}
+
+// pageLines groups a page's glyphs into lines, top to bottom.
+func pageLines(p pdf.Page, page int) (out []pdfLine) {
+    defer func() {
+        if recover() != nil { out = nil } // malformed content streams panic inside the reader
+    }()
+    glyphs := p.Content().Text
+    sort.SliceStable(glyphs, func(i, j int) bool {
+        if math.Abs(glyphs[i].Y-glyphs[j].Y) > 2 { return glyphs[i].Y > glyphs[j].Y }
+        return glyphs[i].X < glyphs[j].X
+    })
+    var cur strings.Builder
+    var line pdfLine
+    var prev pdf.Text
+    emit := func() {
+        if t := strings.TrimSpace(cur.String()); t != "" {
+            line.text = t
+            out = append(out, line)
+        }
+        cur.Reset()
+    }
+    for i, g := range glyphs {
+        if i == 0 || math.Abs(g.Y-prev.Y) > 2 {
+            if i > 0 { emit() }
+            line = pdfLine{y: g.Y, page: page}
+        } else if gap := g.X - (prev.X + prev.W); gap > g.FontSize*0.2 && !strings.HasSuffix(cur.String(), " ") && g.S != " " {
+            cur.WriteByte(' ')
+        }
+        cur.WriteString(g.S)
+        if g.FontSize > line.size { line.size = g.FontSize }
+        if strings.Contains(strings.ToLower(g.Font), "bold") { line.bold = true }
+        prev = g
+    }
+    emit()
+    return out
+}
+
+// bodySize is the font size carrying the most characters.
+func bodySize(lines []pdfLine) float64 {
+    weight := map[float64]int{}
+    for _, l := range lines { weight[roundSize(l.size)] += utf8.RuneCountInString(l.text) }
+    var best float64
+    for size, w := range weight {
+        if w > weight[best] || (w == weight[best] && size < best) { best = size }
+    }
+    return best
+}
+
+// headingSizes ranks sizes at least 15% above body text, largest first, as levels 1–6;
+// bold body-size lines take the next level down.
+func headingSizes(lines []pdfLine, body float64) map[float64]int {
+    seen := map[float64]bool{}
+    var sizes []float64
+    for _, l := range lines {
+        s := roundSize(l.size)
+        if s >= body*1.15 && !seen[s] && isPDFHeading(l) {
+            seen[s] = true
+            sizes = append(sizes, s)
+        }
+    }
+    sort.Sort(sort.Reverse(sort.Float64Slice(sizes)))
+    levels := map[float64]int{}
+    for i, s := range sizes { levels[s] = min(i+1, 6) }
+    return levels
+}
+
+func isPDFHeading(l pdfLine) bool {
+    n := utf8.RuneCountInString(l.text)
+    return n > 1 && n <= 120 && !strings.HasSuffix(l.text, ".") && !strings.HasSuffix(l.text, ",")
+}
+
+func roundSize(s float64) float64 { return math.Round(s*2) / 2 }
+
+func hasImages(p pdf.Page) (found bool) {
+    defer func() {
+        if recover() != nil { found = false }
+    }()
+    xobj := p.Resources().Key("XObject")
+    for _, k := range xobj.Keys() {
+        if xobj.Key(k).Key("Subtype").Name() == "Image" { return true }
+    }
+    return false
+}
+
+// pdfTitle prefers the Info dictionary title, then the first heading.
+func pdfTitle(r *pdf.Reader, doc ports.Document) string {
+    if t := clean(r.Trailer().Key("Info").Key("Title").Text()); t != "" { return t }
+    if len(doc.Sections) > 0 { return doc.Sections[0].Heading }
+    return ""
+}
@@ -0,0 +1,18 @@
+package extract
+
+import (
+    "context"
+    "os"
+    "testing"
+
+    "camille/internal/ports"
+)
+
+// malformed-pages.pdf parses, but its page tree object has a stray ')' where
+// /Count should be; the reader panics resolving it from NumPage.
+func TestPDFMalformedPageTree(t *testing.T) {
+    body, err := os.ReadFile("testdata/malformed-pages.pdf")
+    if err != nil { t.Fatal(err) }
+    raw := ports.RawDocument{URL: "https://example.com/privacy.pdf", Body: body}
+    if _, err := New().Extract(context.Background(), raw); err == nil { t.Error("malformed PDF extracted without error") }
+}
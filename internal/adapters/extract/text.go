@@ -0,0 +1,56 @@
+package extract
+
+import (
+    "context"
+    "strings"
+    "unicode/utf8"
+
+    "camille/internal/ports"
+)
+
+// Text extracts plain text documents. Paragraphs are blank-line separated; short
+// lines in capitals or numbered like "3. Your rights" are taken as headings.
+type Text struct{}
+
+func (x *Text) Extract(_ context.Context, raw ports.RawDocument) (ports.Document, error) {
+    var b builder
+    body := strings.ReplaceAll(string(raw.Body), "\r\n", "\n")
+    for _, para := range strings.Split(body, "\n\n") {
+        lines := strings.Split(strings.TrimSpace(para), "\n")
+        if len(lines) == 1 && isTextHeading(lines[0]) {
+            b.heading(lines[0], headingLevel(lines[0]), "", "", 0)
+            continue
+        }
+        b.block(para, "", 0)
+    }
+    doc := b.document(raw)
+    if len(doc.Sections) > 0 { doc.Title = doc.Sections[0].Heading }
+    return doc, nil
+}
+
+func isTextHeading(line string) bool {
+    line = strings.TrimSpace(line)
+    n := utf8.RuneCountInString(line)
+    if n == 0 || n > 80 || strings.HasSuffix(line, ".") { return false }
+    if strings.ToUpper(line) == line && strings.ToLower(line) != line { return true }
+    return numbered(line) > 0
+}
+
+// headingLevel derives depth from outline numbering: "2" → 1, "2.1" → 2.
+func headingLevel(line string) int {
+    if n := numbered(line); n > 0 { return min(n, 6) }
+    return 1
+}
+
+// numbered returns the depth of a leading "1.", "1.2" or "1.2.3" outline number, or 0.
+func numbered(line string) int {
+    num, rest, ok := strings.Cut(strings.TrimSpace(line), " ")
+    if !ok || rest == "" { return 0 }
+    num = strings.TrimSuffix(num, ".")
+    if num == "" { return 0 }
+    parts := strings.Split(num, ".")
+    for _, p := range parts {
+        if p == "" || len(p) > 2 || strings.Trim(p, "0123456789") != "" { return 0 }
+    }
+    return len(parts)
+}
@@ -0,0 +1,92 @@
+package httpadapter
+
+import (
+    "context"
+    "crypto/subtle"
+    "net/http"
+    "time"
+
+    openapi_types "github.com/oapi-codegen/runtime/types"
+
+    api "camille/internal/api"
+    "camille/internal/ports"
+)
+
+// authorized reports whether token matches the configured admin token.
+func (s *Server) authorized(token *string) bool {
+    if s.Admin.Token == "" || token == nil { return false }
+    return subtle.ConstantTimeCompare([]byte(*token), []byte(s.Admin.Token)) == 1
+}
+
+func (s *Server) GetAdminAiCache(ctx context.Context, req api.GetAdminAiCacheRequestObject) (api.GetAdminAiCacheResponseObject, error) {
+    if !s.authorized(req.Params.XAdminToken) { return api.GetAdminAiCache403Response{}, nil }
+    if s.Admin.AICache == nil { return nil, &runtimeError{code: http.StatusServiceUnavailable, msg: "ai cache not configured"} }
+    stats, err := s.Admin.AICache.AICacheStats(ctx)
+    if err != nil { return nil, err }
+    out := make([]api.AICacheStat, 0, len(stats))
+    for _, st := range stats {
+        out = append(out, api.AICacheStat{
+            Provider: st.Provider, Model: st.Model, PromptVersion: st.PromptVersion,
+            Entries: st.Entries, Hits: st.Hits, HitRate: float32(st.HitRate),
+        })
+    }
+    return api.GetAdminAiCache200JSONResponse{Stats: out}, nil
+}
+
+func (s *Server) DeleteAdminAiCache(ctx context.Context, req api.DeleteAdminAiCacheRequestObject) (api.DeleteAdminAiCacheResponseObject, error) {
+    if !s.authorized(req.Params.XAdminToken) { return api.DeleteAdminAiCache403Response{}, nil }
+    if s.Admin.AICache == nil { return nil, &runtimeError{code: http.StatusServiceUnavailable, msg: "ai cache not configured"} }
+    n, err := s.Admin.AICache.InvalidateAIPrompt(ctx, req.Params.PromptVersion)
+    if err != nil { return nil, err }
+    return api.DeleteAdminAiCache200JSONResponse{PromptVersion: req.Params.PromptVersion, Deleted: n}, nil
+}
+
+func (s *Server) GetAdminAiUsage(ctx context.Context, req api.GetAdminAiUsageRequestObject) (api.GetAdminAiUsageResponseObject, error) {
+    if !s.authorized(req.Params.XAdminToken) { return api.GetAdminAiUsage403Response{}, nil }
+    if s.Admin.AIUsage == nil { return nil, &runtimeError{code: http.StatusServiceUnavailable, msg: "ai usage not configured"} }
+    f := ports.AIUsageFilter{Since: sinceDays(req.Params.Days)}
+    if req.Params.Tenant != nil { f.Tenant = *req.Params.Tenant }
+    if req.Params.ScanId != nil { f.ScanID = req.Params.ScanId.String() }
+    totals, err := s.Admin.AIUsage.AIUsageTotals(ctx, f)
+    if err != nil { return nil, err }
+    out := make([]api.AIUsageTotal, 0, len(totals))
+    for _, t := range totals {
+        out = append(out, api.AIUsageTotal{
+            Tenant: t.Tenant, Day: openapi_types.Date{Time: t.Day}, Calls: t.Calls, CachedCalls: t.Cached,
+            PromptTokens: t.PromptTokens, CompletionTokens: t.CompletionTokens, CostUsd: t.CostUSD,
+        })
+    }
+    return api.GetAdminAiUsage200JSONResponse{Usage: out}, nil
+}
+
+func (s *Server) GetAdminAiPrompts(ctx context.Context, req api.GetAdminAiPromptsRequestObject) (api.GetAdminAiPromptsResponseObject, error) {
+    if !s.authorized(req.Params.XAdminToken) { return api.GetAdminAiPrompts403Response{}, nil }
+    out := make([]api.AIPrompt, 0, len(s.Admin.Prompts))
+    for _, p := range s.Admin.Prompts {
+        out = append(out, api.AIPrompt{Version: p.Version, Hash: p.Hash, Active: p.Active, Shadow: p.Shadow})
+    }
+    return api.GetAdminAiPrompts200JSONResponse{Prompts: out}, nil
+}
+
+func (s *Server) GetAdminAiPromptsCompare(ctx context.Context, req api.GetAdminAiPromptsCompareRequestObject) (api.GetAdminAiPromptsCompareResponseObject, error) {
+    if !s.authorized(req.Params.XAdminToken) { return api.GetAdminAiPromptsCompare403Response{}, nil }
+    if s.Admin.AIShadow == nil { return nil, &runtimeError{code: http.StatusServiceUnavailable, msg: "ai shadow runs not configured"} }
+    rep, err := s.Admin.AIShadow.AIShadowReport(ctx, req.Params.Primary, req.Params.Candidate, sinceDays(req.Params.Days))
+    if err != nil { return nil, err }
+    cats := make([]api.AIPromptCategoryComparison, 0, len(rep.Categories))
+    for _, c := range rep.Categories {
+        cats = append(cats, api.AIPromptCategoryComparison{
+            Category: c.Category, Agreed: c.Agreed, Conflicts: c.Conflicts, PrimaryOnly: c.PrimaryOnly, CandidateOnly: c.CandidateOnly,
+        })
+    }
+    return api.GetAdminAiPromptsCompare200JSONResponse{
+        Primary: rep.Primary, Candidate: rep.Candidate, Runs: rep.Runs, Failed: rep.Failed, Agreement: rep.Agreement, Categories: cats,
+    }, nil
+}
+
+// sinceDays is the start of the UTC day days-1 before today; nil means a week.
+func sinceDays(days *int) time.Time {
+    n := 7
+    if days != nil && *days > 0 { n = min(*days, 366) }
+    return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-n)
+}
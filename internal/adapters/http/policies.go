@@ -0,0 +1,68 @@
+package httpadapter
+
+import (
+    "context"
+    "errors"
+
+    api "camille/internal/api"
+    "camille/internal/ports"
+    policysvc "camille/internal/services/policies"
+)
+
+func (s *Server) GetProfilesDomainPoliciesTypeVersions(ctx context.Context, req api.GetProfilesDomainPoliciesTypeVersionsRequestObject) (api.GetProfilesDomainPoliciesTypeVersionsResponseObject, error) {
+    vs, err := s.policies.Versions(ctx, req.Domain, req.Type)
+    if errors.Is(err, policysvc.ErrNotFound) { return api.GetProfilesDomainPoliciesTypeVersions404Response{}, nil }
+    if err != nil { return nil, err }
+    out := make([]api.PolicyVersion, 0, len(vs))
+    for _, v := range vs { out = append(out, policyVersion(v)) }
+    return api.GetProfilesDomainPoliciesTypeVersions200JSONResponse{Domain: req.Domain, Type: req.Type, Versions: out}, nil
+}
+
+func (s *Server) GetProfilesDomainPoliciesTypeDiff(ctx context.Context, req api.GetProfilesDomainPoliciesTypeDiffRequestObject) (api.GetProfilesDomainPoliciesTypeDiffResponseObject, error) {
+    from, to := "", ""
+    if req.Params.From != nil { from = *req.Params.From }
+    if req.Params.To != nil { to = *req.Params.To }
+    d, err := s.policies.Diff(ctx, req.Domain, req.Type, from, to)
+    if errors.Is(err, policysvc.ErrNotFound) { return api.GetProfilesDomainPoliciesTypeDiff404Response{}, nil }
+    if err != nil { return nil, err }
+    resp := api.GetProfilesDomainPoliciesTypeDiff200JSONResponse{
+        Domain: req.Domain, Type: req.Type, From: policyVersion(d.From), To: policyVersion(d.To),
+        Summary: api.PolicyDiffSummary{
+            Added: d.Summary.Added, Removed: d.Summary.Removed, Modified: d.Summary.Modified,
+            SectionsAdded: d.Summary.SectionsAdded, SectionsRemoved: d.Summary.SectionsRemoved,
+        },
+        Categories:    d.Categories,
+        SignalChanges: make([]api.PolicySignalChange, 0, len(d.SignalChanges)),
+        Changes:       make([]api.PolicyChange, 0, len(d.Changes)),
+    }
+    for _, c := range d.SignalChanges {
+        resp.SignalChanges = append(resp.SignalChanges, api.PolicySignalChange{Code: c.Code, From: c.From, To: c.To})
+    }
+    for _, c := range d.Changes {
+        ch := api.PolicyChange{
+            Kind: api.PolicyChangeKind(c.Kind), Section: optString(c.Section), From: optString(c.From), To: optString(c.To),
+            Signals: nonNil(c.Signals), Categories: nonNil(c.Categories),
+        }
+        if len(c.Path) > 0 { ch.Path = &c.Path }
+        if c.Similarity > 0 { ch.Similarity = &c.Similarity }
+        resp.Changes = append(resp.Changes, ch)
+    }
+    return resp, nil
+}
+
+func policyVersion(v ports.PolicyVersion) api.PolicyVersion {
+    return api.PolicyVersion{
+        Id: v.ID, Url: v.URL, DocHash: v.Hash, Title: optString(v.Title), Language: optString(v.Language),
+        FirstScanId: optString(v.FirstScanID), FirstSeenAt: v.FirstSeen, LastSeenAt: v.LastSeen, SeenCount: v.Seen,
+    }
+}
+
+func optString(s string) *string {
+    if s == "" { return nil }
+    return &s
+}
+
+func nonNil(xs []string) []string {
+    if xs == nil { return []string{} }
+    return xs
+}
@@ -0,0 +1,41 @@
+package httpadapter
+
+import (
+    "context"
+    "errors"
+
+    api "camille/internal/api"
+    "camille/internal/ports"
+    policysvc "camille/internal/services/policies"
+)
+
+func (s *Server) GetProfilesDomainRecipients(ctx context.Context, req api.GetProfilesDomainRecipientsRequestObject) (api.GetProfilesDomainRecipientsResponseObject, error) {
+    l, err := s.policies.Recipients(ctx, req.Domain)
+    if errors.Is(err, policysvc.ErrNotFound) { return api.GetProfilesDomainRecipients404Response{}, nil }
+    if err != nil { return nil, err }
+    resp := api.GetProfilesDomainRecipients200JSONResponse{
+        Domain: req.Domain, ScanId: l.Current.ScanID, RetrievedAt: l.Current.Retrieved,
+        Recipients: make([]api.Recipient, 0, len(l.Current.Recipients)),
+        Changes:    make([]api.RecipientChange, 0, len(l.Changes)),
+    }
+    if l.Previous != nil { resp.PreviousScanId = &l.Previous.ScanID }
+    for _, r := range l.Current.Recipients { resp.Recipients = append(resp.Recipients, recipient(r)) }
+    for _, c := range l.Changes {
+        ch := api.RecipientChange{Kind: api.RecipientChangeKind(c.Kind), Name: c.Name}
+        if c.From != nil { from := recipient(*c.From); ch.From = &from }
+        if c.To != nil { to := recipient(*c.To); ch.To = &to }
+        resp.Changes = append(resp.Changes, ch)
+    }
+    return resp, nil
+}
+
+func recipient(r ports.Recipient) api.Recipient {
+    out := api.Recipient{
+        Name: r.Name, Entity: optString(r.Entity), Owner: optString(r.Owner), Domain: optString(r.Domain),
+        Role: r.Role, Purpose: optString(r.Purpose), Location: optString(r.Location),
+        Quote: r.Quote, SpanStart: r.SpanStart, SpanEnd: r.SpanEnd, DocType: r.DocType, SectionUrl: r.SectionURL,
+    }
+    if len(r.Categories) > 0 { out.Categories = &r.Categories }
+    if len(r.Countries) > 0 { out.Countries = &r.Countries }
+    return out
+}
@@ -3,6 +3,7 @@ package httpadapter
 import (
     "context"
     "net/http"
+    "strings"
     "time"
 
     "github.com/go-chi/chi/v5"
@@ -17,12 +18,24 @@ type Server struct {
     scanner   ports.Scanner
     profiles  ports.Profiles
     companies ports.Companies
+    policies  ports.Policies
     jobs      ports.JobRepository
     processor scanrunner.ScanProcessor
+    // Admin enables the /admin endpoints; they answer 403 until Admin.Token is set.
+    Admin Admin
 }
 
-func New(scanner ports.Scanner, profiles ports.Profiles, companies ports.Companies, jobs ports.JobRepository, processor scanrunner.ScanProcessor) *Server {
-    return &Server{scanner: scanner, profiles: profiles, companies: companies, jobs: jobs, processor: processor}
+// Admin configures the operator endpoints.
+type Admin struct {
+    Token    string
+    AICache  ports.AICacheRepository
+    AIUsage  ports.AIUsageRepository
+    AIShadow ports.AIShadowRepository
+    Prompts  []ports.AIPromptInfo
+}
+
+func New(scanner ports.Scanner, profiles ports.Profiles, companies ports.Companies, policies ports.Policies, jobs ports.JobRepository, processor scanrunner.ScanProcessor) *Server {
+    return &Server{scanner: scanner, profiles: profiles, companies: companies, policies: policies, jobs: jobs, processor: processor}
 }
 
 // Routes returns a chi.Router mounting the generated handlers.
@@ -45,7 +58,12 @@ func (s *Server) PostScan(ctx context.Context, req api.PostScanRequestObject) (a
     if req.Body == nil {
         return nil, &runtimeError{code: http.StatusBadRequest, msg: "missing body"}
     }
-    id, err := s.scanner.Enqueue(ctx, req.Body.Url)
+    tenant := ""
+    if req.Params.XTenantId != nil { tenant = strings.TrimSpace(*req.Params.XTenantId) }
+    if len(tenant) > 128 {
+        return nil, &runtimeError{code: http.StatusBadRequest, msg: "X-Tenant-Id too long"}
+    }
+    id, err := s.scanner.Enqueue(ctx, req.Body.Url, tenant)
     if err != nil {
         return nil, err
     }
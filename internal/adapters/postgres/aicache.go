@@ -0,0 +1,66 @@
+package postgres
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+
+    "github.com/jackc/pgx/v5"
+
+    "camille/internal/ports"
+)
+
+// GetAIFacts returns cached facts for key and counts the hit.
+func (db *DB) GetAIFacts(ctx context.Context, key ports.AICacheKey) ([]ports.Fact, bool, error) {
+    var raw []byte
+    err := db.Pool.QueryRow(ctx, `
+        UPDATE ai_cache SET hits = hits + 1, last_hit_at = now()
+        WHERE chunk_hash = $1 AND provider = $2 AND model = $3 AND prompt_version = $4 AND prompt_hash = $5 AND categories = $6
+        RETURNING facts
+    `, key.ChunkHash, key.Provider, key.Model, key.PromptVersion, key.PromptHash, key.Categories).Scan(&raw)
+    if errors.Is(err, pgx.ErrNoRows) { return nil, false, nil }
+    if err != nil { return nil, false, err }
+    var facts []ports.Fact
+    if err := json.Unmarshal(raw, &facts); err != nil { return nil, false, err }
+    return facts, true, nil
+}
+
+// PutAIFacts stores an extraction result; a concurrent insert of the same key wins.
+func (db *DB) PutAIFacts(ctx context.Context, key ports.AICacheKey, facts []ports.Fact) error {
+    if facts == nil { facts = []ports.Fact{} }
+    raw, err := json.Marshal(facts)
+    if err != nil { return err }
+    _, err = db.Pool.Exec(ctx, `
+        INSERT INTO ai_cache (chunk_hash, provider, model, prompt_version, prompt_hash, categories, facts)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT DO NOTHING
+    `, key.ChunkHash, key.Provider, key.Model, key.PromptVersion, key.PromptHash, key.Categories, raw)
+    return err
+}
+
+// InvalidateAIPrompt deletes every entry produced with promptVersion.
+func (db *DB) InvalidateAIPrompt(ctx context.Context, promptVersion string) (int64, error) {
+    tag, err := db.Pool.Exec(ctx, `DELETE FROM ai_cache WHERE prompt_version = $1`, promptVersion)
+    if err != nil { return 0, err }
+    return tag.RowsAffected(), nil
+}
+
+// AICacheStats reports entries and hits per provider, model and prompt version.
+func (db *DB) AICacheStats(ctx context.Context) ([]ports.AICacheStats, error) {
+    rows, err := db.Pool.Query(ctx, `
+        SELECT provider, model, prompt_version, count(*), COALESCE(sum(hits), 0)
+        FROM ai_cache
+        GROUP BY provider, model, prompt_version
+        ORDER BY provider, model, prompt_version
+    `)
+    if err != nil { return nil, err }
+    defer rows.Close()
+    out := []ports.AICacheStats{}
+    for rows.Next() {
+        var st ports.AICacheStats
+        if err := rows.Scan(&st.Provider, &st.Model, &st.PromptVersion, &st.Entries, &st.Hits); err != nil { return nil, err }
+        if total := st.Hits + st.Entries; total > 0 { st.HitRate = float64(st.Hits) / float64(total) }
+        out = append(out, st)
+    }
+    return out, rows.Err()
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+    "context"
+    "encoding/json"
+    "sort"
+    "time"
+
+    "camille/internal/ports"
+)
+
+// RecordAIShadow stores one shadow comparison.
+func (db *DB) RecordAIShadow(ctx context.Context, run ports.AIShadowRun) error {
+    primary, err := json.Marshal(nonNilFacts(run.PrimaryFacts))
+    if err != nil { return err }
+    candidate, err := json.Marshal(nonNilFacts(run.CandidateFacts))
+    if err != nil { return err }
+    diff, err := json.Marshal(run.Diff)
+    if err != nil { return err }
+    _, err = db.Pool.Exec(ctx, `
+        INSERT INTO ai_shadow_runs (chunk_hash, source_url, provider, model, primary_version, primary_hash,
+            candidate_version, candidate_hash, primary_facts, candidate_facts, diff, error)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `, run.ChunkHash, run.SourceURL, run.Primary.Provider, run.Primary.Model, run.Primary.PromptVersion, run.Primary.PromptHash,
+        run.Candidate.PromptVersion, run.Candidate.PromptHash, primary, candidate, diff, run.Error)
+    return err
+}
+
+// AIShadowReport counts per-category outcomes of candidate against primary since t.
+func (db *DB) AIShadowReport(ctx context.Context, primary, candidate string, since time.Time) (ports.AIShadowReport, error) {
+    rep := ports.AIShadowReport{Primary: primary, Candidate: candidate, Categories: []ports.AIShadowCategory{}}
+    err := db.Pool.QueryRow(ctx, `
+        SELECT count(*), count(*) FILTER (WHERE error <> '')
+        FROM ai_shadow_runs
+        WHERE primary_version = $1 AND candidate_version = $2 AND created_at >= $3
+    `, primary, candidate, since).Scan(&rep.Runs, &rep.Failed)
+    if err != nil { return rep, err }
+
+    rows, err := db.Pool.Query(ctx, `
+        SELECT c.cat, d.kind, count(*)
+        FROM ai_shadow_runs r
+        CROSS JOIN LATERAL jsonb_each(r.diff) AS d(kind, cats)
+        CROSS JOIN LATERAL jsonb_array_elements_text(CASE WHEN jsonb_typeof(d.cats) = 'array' THEN d.cats ELSE '[]'::jsonb END) AS c(cat)
+        WHERE r.primary_version = $1 AND r.candidate_version = $2 AND r.created_at >= $3
+        GROUP BY c.cat, d.kind
+    `, primary, candidate, since)
+    if err != nil { return rep, err }
+    defer rows.Close()
+    byCat := map[string]*ports.AIShadowCategory{}
+    agreed, total := 0, 0
+    for rows.Next() {
+        var cat, kind string
+        var n int
+        if err := rows.Scan(&cat, &kind, &n); err != nil { return rep, err }
+        c := byCat[cat]
+        if c == nil {
+            c = &ports.AIShadowCategory{Category: cat}
+            byCat[cat] = c
+        }
+        switch kind {
+        case "agreed":
+            c.Agreed += n
+            agreed += n
+        case "conflicts":
+            c.Conflicts += n
+        case "primary_only":
+            c.PrimaryOnly += n
+        case "candidate_only":
+            c.CandidateOnly += n
+        }
+        total += n
+    }
+    if err := rows.Err(); err != nil { return rep, err }
+    for _, c := range byCat { rep.Categories = append(rep.Categories, *c) }
+    sort.Slice(rep.Categories, func(i, j int) bool { return rep.Categories[i].Category < rep.Categories[j].Category })
+    if total > 0 { rep.Agreement = float64(agreed) / float64(total) }
+    return rep, nil
+}
+
+func nonNilFacts(facts []ports.Fact) []ports.Fact {
+    if facts == nil { return []ports.Fact{} }
+    return facts
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+    "context"
+    "time"
+
+    "camille/internal/ports"
+)
+
+// RecordAIUsage stores one extractor call.
+func (db *DB) RecordAIUsage(ctx context.Context, rec ports.AIUsageRecord) error {
+    var scanID *string
+    if rec.ScanID != "" { scanID = &rec.ScanID }
+    _, err := db.Pool.Exec(ctx, `
+        INSERT INTO ai_usage (scan_id, tenant, provider, model, prompt_version, calls, prompt_tokens, completion_tokens, cost_usd, cached)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `, scanID, rec.Tenant, rec.Provider, rec.Model, rec.PromptVersion, rec.Calls, rec.PromptTokens, rec.CompletionTokens, rec.CostUSD, rec.Cached)
+    return err
+}
+
+// TenantAIUsage sums a tenant's usage since t.
+func (db *DB) TenantAIUsage(ctx context.Context, tenant string, since time.Time) (ports.AIUsage, error) {
+    var u ports.AIUsage
+    err := db.Pool.QueryRow(ctx, `
+        SELECT COALESCE(sum(calls), 0), COALESCE(sum(prompt_tokens), 0), COALESCE(sum(completion_tokens), 0), COALESCE(sum(cost_usd), 0)
+        FROM ai_usage
+        WHERE tenant = $1 AND created_at >= $2
+    `, tenant, since).Scan(&u.Calls, &u.PromptTokens, &u.CompletionTokens, &u.CostUSD)
+    return u, err
+}
+
+// AIUsageTotals sums usage per tenant and UTC day, newest first.
+func (db *DB) AIUsageTotals(ctx context.Context, f ports.AIUsageFilter) ([]ports.AIUsageTotal, error) {
+    var scanID *string
+    if f.ScanID != "" { scanID = &f.ScanID }
+    rows, err := db.Pool.Query(ctx, `
+        SELECT tenant, date_trunc('day', created_at AT TIME ZONE 'UTC') AS day,
+               sum(calls), count(*) FILTER (WHERE cached), sum(prompt_tokens), sum(completion_tokens), sum(cost_usd)
+        FROM ai_usage
+        WHERE ($1 = '' OR tenant = $1) AND ($2::uuid IS NULL OR scan_id = $2) AND created_at >= $3
+        GROUP BY tenant, day
+        ORDER BY day DESC, tenant
+    `, f.Tenant, scanID, f.Since)
+    if err != nil { return nil, err }
+    defer rows.Close()
+    out := []ports.AIUsageTotal{}
+    for rows.Next() {
+        var t ports.AIUsageTotal
+        if err := rows.Scan(&t.Tenant, &t.Day, &t.Calls, &t.Cached, &t.PromptTokens, &t.CompletionTokens, &t.CostUSD); err != nil { return nil, err }
+        t.Day = t.Day.UTC()
+        out = append(out, t)
+    }
+    return out, rows.Err()
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "strings"
+
+    "github.com/jackc/pgx/v5"
+
+    "camille/internal/ports"
+)
+
+// RecordPolicyVersion stores doc as a version of typ, or bumps the sighting of an
+// identical text wherever it was published before. changed reports a text other
+// than the one seen last for the domain and type, so a revert is a change and a
+// move to a new URL is not.
+func (db *DB) RecordPolicyVersion(ctx context.Context, domainID, scanID, typ string, doc ports.Document) (string, bool, error) {
+    sections := doc.Sections
+    if sections == nil { sections = []ports.Section{} }
+    raw, err := json.Marshal(sections)
+    if err != nil { return "", false, err }
+    var scan *string
+    if scanID != "" { scan = &scanID }
+    var id string
+    var changed bool
+    // Both statements see the table as it was before the upsert.
+    err = db.Pool.QueryRow(ctx, `
+        WITH prev AS (
+            SELECT doc_hash FROM policy_versions
+            WHERE domain_id = $1 AND type = $2
+            ORDER BY last_seen_at DESC, id
+            LIMIT 1
+        ), up AS (
+            INSERT INTO policy_versions (domain_id, type, url, doc_hash, title, language, text, sections, first_scan_id)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+            ON CONFLICT (domain_id, type, doc_hash) DO UPDATE SET
+                url = EXCLUDED.url,
+                last_seen_at = now(),
+                seen_count = policy_versions.seen_count + 1
+            RETURNING id
+        )
+        SELECT up.id, COALESCE((SELECT doc_hash <> $4 FROM prev), false) FROM up
+    `, domainID, typ, doc.URL, doc.Hash, doc.Title, doc.Language, doc.Text, raw, scan).Scan(&id, &changed)
+    return id, changed, err
+}
+
+// PolicyVersions lists a domain's versions of typ, most recently seen first, without text.
+func (db *DB) PolicyVersions(ctx context.Context, registrable, typ string) ([]ports.PolicyVersion, error) {
+    rows, err := db.Pool.Query(ctx, `
+        SELECT `+versionColumns+`, '', '[]'::jsonb
+        FROM policy_versions v
+        JOIN domains d ON d.id = v.domain_id
+        WHERE d.registrable_domain = $1 AND v.type = $2
+        ORDER BY v.last_seen_at DESC, v.id
+    `, strings.ToLower(registrable), typ)
+    if err != nil { return nil, err }
+    defer rows.Close()
+    out := []ports.PolicyVersion{}
+    for rows.Next() {
+        v, err := scanVersion(rows)
+        if err != nil { return nil, err }
+        out = append(out, v)
+    }
+    return out, rows.Err()
+}
+
+// PolicyVersion loads one version with its text; found is false unless it is a
+// version of typ for the domain.
+func (db *DB) PolicyVersion(ctx context.Context, registrable, typ, id string) (ports.PolicyVersion, bool, error) {
+    v, err := scanVersion(db.Pool.QueryRow(ctx, `
+        SELECT `+versionColumns+`, v.text, v.sections
+        FROM policy_versions v
+        JOIN domains d ON d.id = v.domain_id
+        WHERE d.registrable_domain = $1 AND v.type = $2 AND v.id::text = $3
+    `, strings.ToLower(registrable), typ, id))
+    if errors.Is(err, pgx.ErrNoRows) { return v, false, nil }
+    return v, err == nil, err
+}
+
+const versionColumns = `v.id, v.type, v.url, v.doc_hash, v.title, v.language, COALESCE(v.first_scan_id::text, ''),
+            v.first_seen_at, v.last_seen_at, v.seen_count`
+
+func scanVersion(row pgx.Row) (ports.PolicyVersion, error) {
+    var v ports.PolicyVersion
+    var sections []byte
+    err := row.Scan(&v.ID, &v.Type, &v.URL, &v.Hash, &v.Title, &v.Language, &v.FirstScanID,
+        &v.FirstSeen, &v.LastSeen, &v.Seen, &v.Text, &sections)
+    if err != nil { return v, err }
+    err = json.Unmarshal(sections, &v.Sections)
+    return v, err
+}
+
+// LatestRetention returns the retention schedule stored by the domain's most recent
+// scan that read a privacy policy.
+func (db *DB) LatestRetention(ctx context.Context, registrable string) ([]ports.RetentionEntry, bool, error) {
+    var raw []byte
+    err := db.Pool.QueryRow(ctx, `
+        SELECT COALESCE(e.payload->'entries', '[]'::jsonb)
+        FROM evidence e
+        JOIN scans s ON s.id = e.scan_id
+        JOIN domains d ON d.id = s.domain_id
+        WHERE d.registrable_domain = $1 AND e.source_type = 'policy.retention'
+        ORDER BY e.retrieved_at DESC
+        LIMIT 1
+    `, strings.ToLower(registrable)).Scan(&raw)
+    if errors.Is(err, pgx.ErrNoRows) { return nil, false, nil }
+    if err != nil { return nil, false, err }
+    var out []ports.RetentionEntry
+    if err := json.Unmarshal(raw, &out); err != nil { return nil, false, err }
+    return out, true, nil
+}
+
+// RecipientSnapshots returns the recipient lists stored by the domain's latest
+// scans, newest first.
+func (db *DB) RecipientSnapshots(ctx context.Context, registrable string, limit int) ([]ports.RecipientSnapshot, error) {
+    rows, err := db.Pool.Query(ctx, `
+        SELECT s.id::text, e.retrieved_at, COALESCE(e.payload->'recipients', '[]'::jsonb)
+        FROM evidence e
+        JOIN scans s ON s.id = e.scan_id
+        JOIN domains d ON d.id = s.domain_id
+        WHERE d.registrable_domain = $1 AND e.source_type = 'policy.recipients'
+        ORDER BY e.retrieved_at DESC
+        LIMIT $2
+    `, strings.ToLower(registrable), limit)
+    if err != nil { return nil, err }
+    defer rows.Close()
+    var out []ports.RecipientSnapshot
+    for rows.Next() {
+        var snap ports.RecipientSnapshot
+        var raw []byte
+        if err := rows.Scan(&snap.ScanID, &snap.Retrieved, &raw); err != nil { return nil, err }
+        if err := json.Unmarshal(raw, &snap.Recipients); err != nil { return nil, err }
+        out = append(out, snap)
+    }
+    return out, rows.Err()
+}
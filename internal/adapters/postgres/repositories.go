@@ -6,6 +6,8 @@ import (
     "strings"
 
     "github.com/jackc/pgx/v5"
+
+    "camille/internal/ports"
 )
 
 // DomainRepository
@@ -22,13 +24,13 @@ func (db *DB) GetOrCreate(ctx context.Context, registrable string) (string, erro
 }
 
 // ScanRepository
-func (db *DB) Create(ctx context.Context, domainID string, url string) (string, error) {
+func (db *DB) Create(ctx context.Context, domainID, url, tenant string) (string, error) {
     var scanID string
     err := db.Pool.QueryRow(ctx, `
-        INSERT INTO scans (domain_id, url, status, progress)
-        VALUES ($1, $2, 'queued', 0)
+        INSERT INTO scans (domain_id, url, tenant, status, progress)
+        VALUES ($1, $2, $3, 'queued', 0)
         RETURNING id
-    `, domainID, url).Scan(&scanID)
+    `, domainID, url, tenant).Scan(&scanID)
     if err != nil {
         return "", err
     }
@@ -47,6 +49,21 @@ func (db *DB) Status(ctx context.Context, scanID string) (string, float64, error
     return status, progress, err
 }
 
+// Target resolves the domain and URL a scan runs against.
+func (db *DB) Target(ctx context.Context, scanID string) (ports.ScanTarget, error) {
+    t := ports.ScanTarget{ScanID: scanID}
+    err := db.Pool.QueryRow(ctx, `
+        SELECT s.domain_id, d.registrable_domain, s.url, s.tenant
+        FROM scans s
+        JOIN domains d ON d.id = s.domain_id
+        WHERE s.id = $1
+    `, scanID).Scan(&t.DomainID, &t.Domain, &t.URL, &t.Tenant)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return t, ErrNotFound
+    }
+    return t, err
+}
+
 // ScoreRepository
 func (db *DB) GetLatestByDomain(ctx context.Context, registrable string) (bool, struct{
     Privacy, Security, Governance, Esg, Overall int
@@ -73,6 +90,25 @@ func (db *DB) GetLatestByDomain(ctx context.Context, registrable string) (bool,
     return exists, out, nil
 }
 
+// UpsertScore replaces the score row for a domain.
+func (db *DB) UpsertScore(ctx context.Context, domainID string, score ports.Scores, badges []string, methodVersion string) error {
+    if badges == nil { badges = []string{} }
+    _, err := db.Pool.Exec(ctx, `
+        INSERT INTO scores (domain_id, privacy, security, governance, esg, overall, badges, method_version, computed_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+        ON CONFLICT (domain_id) DO UPDATE SET
+            privacy = EXCLUDED.privacy,
+            security = EXCLUDED.security,
+            governance = EXCLUDED.governance,
+            esg = EXCLUDED.esg,
+            overall = EXCLUDED.overall,
+            badges = EXCLUDED.badges,
+            method_version = EXCLUDED.method_version,
+            computed_at = now()
+    `, domainID, score.Privacy, score.Security, score.Governance, score.Esg, score.Overall, badges, methodVersion)
+    return err
+}
+
 var ErrNotFound = errString("not found")
 type errString string
 func (e errString) Error() string { return string(e) }
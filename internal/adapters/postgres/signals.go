@@ -0,0 +1,47 @@
+package postgres
+
+import (
+    "context"
+    "encoding/json"
+
+    "github.com/jackc/pgx/v5"
+
+    "camille/internal/domain"
+)
+
+// AddEvidence stores a raw artifact for a scan and returns its id.
+func (db *DB) AddEvidence(ctx context.Context, scanID string, ev domain.Evidence) (string, error) {
+    payload, err := json.Marshal(ev.Payload)
+    if err != nil { return "", err }
+    meta := ev.Meta
+    if meta == nil { meta = map[string]any{} }
+    metaJSON, err := json.Marshal(meta)
+    if err != nil { return "", err }
+    var id string
+    err = db.Pool.QueryRow(ctx, `
+        INSERT INTO evidence (scan_id, source_type, source_url, hash, payload, meta)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id
+    `, scanID, ev.SourceType, ev.SourceURL, ev.Hash, payload, metaJSON).Scan(&id)
+    return id, err
+}
+
+// UpsertSignals inserts the signals produced by a scan. Each scan appends a fresh set;
+// readers pick the latest scan per domain.
+func (db *DB) UpsertSignals(ctx context.Context, domainID, scanID string, sigs []domain.Signal) error {
+    if len(sigs) == 0 { return nil }
+    batch := &pgx.Batch{}
+    for _, sig := range sigs {
+        value, err := json.Marshal(sig.Value)
+        if err != nil { return err }
+        refs := sig.EvidenceRefs
+        if refs == nil { refs = []string{} }
+        var severity *string
+        if sig.Severity != "" { severity = &sig.Severity }
+        batch.Queue(`
+            INSERT INTO signals (domain_id, scan_id, code, value_json, severity, confidence, source, evidence_refs)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        `, domainID, scanID, sig.Code, value, severity, sig.Confidence, sig.Source, refs)
+    }
+    return db.Pool.SendBatch(ctx, batch).Close()
+}
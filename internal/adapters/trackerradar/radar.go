@@ -0,0 +1,101 @@
+// Package trackerradar loads a local copy of DuckDuckGo Tracker Radar, either
+// the aggregated tds.json or the repository's domains/ tree.
+package trackerradar
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "camille/internal/ports"
+)
+
+type owner struct {
+    Name        string `json:"name"`
+    DisplayName string `json:"displayName"`
+}
+
+type domainEntry struct {
+    Domain     string   `json:"domain"`
+    Owner      owner    `json:"owner"`
+    Categories []string `json:"categories"`
+    Prevalence float64  `json:"prevalence"`
+}
+
+// Radar is an in-memory tracker directory keyed by domain.
+type Radar struct {
+    domains map[string]ports.Tracker
+}
+
+// Load reads path as a tds.json file or a Tracker Radar checkout directory.
+func Load(path string) (*Radar, error) {
+    info, err := os.Stat(path)
+    if err != nil { return nil, err }
+    r := &Radar{domains: map[string]ports.Tracker{}}
+    if info.IsDir() {
+        err = r.loadDir(path)
+    } else {
+        err = r.loadTDS(path)
+    }
+    if err != nil { return nil, fmt.Errorf("tracker radar %s: %w", path, err) }
+    return r, nil
+}
+
+func (r *Radar) loadTDS(path string) error {
+    b, err := os.ReadFile(path)
+    if err != nil { return err }
+    var tds struct {
+        Trackers map[string]domainEntry `json:"trackers"`
+    }
+    if err := json.Unmarshal(b, &tds); err != nil { return err }
+    for d, e := range tds.Trackers {
+        if e.Domain == "" { e.Domain = d }
+        r.add(e)
+    }
+    return nil
+}
+
+// loadDir walks domains/<region>/<domain>.json; the first region seen for a domain wins.
+func (r *Radar) loadDir(root string) error {
+    dir := filepath.Join(root, "domains")
+    if _, err := os.Stat(dir); err != nil { dir = root }
+    return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+        if err != nil { return err }
+        if d.IsDir() || !strings.HasSuffix(p, ".json") { return nil }
+        b, err := os.ReadFile(p)
+        if err != nil { return err }
+        var e domainEntry
+        if err := json.Unmarshal(b, &e); err != nil { return fmt.Errorf("%s: %w", p, err) }
+        if _, dup := r.domains[e.Domain]; !dup { r.add(e) }
+        return nil
+    })
+}
+
+func (r *Radar) add(e domainEntry) {
+    d := strings.ToLower(e.Domain)
+    if d == "" { return }
+    r.domains[d] = ports.Tracker{
+        Domain:      d,
+        Owner:       e.Owner.Name,
+        DisplayName: e.Owner.DisplayName,
+        Categories:  e.Categories,
+        Prevalence:  e.Prevalence,
+    }
+}
+
+// Len returns the number of domains loaded.
+func (r *Radar) Len() int { return len(r.domains) }
+
+func (r *Radar) Lookup(host string) (ports.Tracker, bool) {
+    host = strings.TrimSuffix(strings.ToLower(host), ".")
+    for host != "" {
+        if t, ok := r.domains[host]; ok { return t, true }
+        _, parent, found := strings.Cut(host, ".")
+        if !found { break }
+        host = parent
+    }
+    return ports.Tracker{}, false
+}
@@ -3,6 +3,9 @@ package config
 import (
     "fmt"
     "os"
+    "strconv"
+    "strings"
+    "time"
 )
 
 type Config struct {
@@ -10,6 +13,44 @@ type Config struct {
     ListenAddr  string
     DatabaseURL string
     ScanWorkers int
+    // DNSServer is the resolver scanners query (host or host:port); empty uses /etc/resolv.conf.
+    DNSServer   string
+    DKIMSelectors []string
+    // TrackerRadarPath points at a Tracker Radar tds.json or repository checkout.
+    TrackerRadarPath string
+    // PolicyRulesPath overrides the built-in policy rule catalog (YAML).
+    PolicyRulesPath string
+    // AI extraction of policy facts; without a usable provider only the rule catalog runs.
+    AIEnabled  bool
+    AIProvider string
+    AIModel    string
+    AIAPIKey   string
+    AIBaseURL  string
+    AITimeout  time.Duration
+    AIRetries  int
+    // AIQuoteThreshold is the similarity (0–1) a model quote needs to match the source.
+    AIQuoteThreshold float64
+    // AIPromptVersion answers scans; AIShadowPromptVersion, when set, runs alongside
+    // it for comparison. AIPromptsDir adds prompt templates to the built-in ones.
+    AIPromptVersion       string
+    AIShadowPromptVersion string
+    AIPromptsDir          string
+    // AIChunkTokens and AIChunkOverlap bound the policy chunks sent per extractor call.
+    AIChunkTokens  int
+    AIChunkOverlap int
+    // AI prices in USD per million tokens, and spend limits per scan, per tenant per
+    // UTC day and across all tenants per UTC day (0 = unlimited). Exhausted budgets
+    // fall back to rules only.
+    AIPricePrompt     float64
+    AIPriceCompletion float64
+    AIScanMaxTokens   int
+    AIScanMaxUSD      float64
+    AIDailyMaxTokens  int
+    AIDailyMaxUSD     float64
+    AIGlobalMaxTokens int
+    AIGlobalMaxUSD    float64
+    // AdminToken guards the /admin endpoints (X-Admin-Token); empty disables them.
+    AdminToken string
 }
 
 func getenv(key, def string) string {
@@ -25,6 +66,32 @@ func Load() (Config, error) {
         ListenAddr:  getenv("LISTEN_ADDR", ":8080"),
         DatabaseURL: os.Getenv("DATABASE_URL"),
         ScanWorkers: getenvInt("SCAN_WORKERS", 0),
+        DNSServer:   os.Getenv("DNS_SERVER"),
+        DKIMSelectors: getenvList("DKIM_SELECTORS"),
+        TrackerRadarPath: os.Getenv("TRACKER_RADAR_PATH"),
+        PolicyRulesPath: os.Getenv("POLICY_RULES_PATH"),
+        AIEnabled:   getenvBool("AI_ENABLED", true),
+        AIProvider:  getenv("AI_PROVIDER", "openai"),
+        AIModel:     getenv("AI_MODEL", "gpt-4o-mini"),
+        AIAPIKey:    os.Getenv("AI_API_KEY"),
+        AIBaseURL:   os.Getenv("AI_BASE_URL"),
+        AITimeout:   getenvDuration("AI_TIMEOUT", 30*time.Second),
+        AIRetries:   getenvInt("AI_RETRIES", 2),
+        AIQuoteThreshold: getenvFloat("AI_QUOTE_THRESHOLD", 0.85),
+        AIPromptVersion:       getenv("AI_PROMPT_VERSION", "facts-v1"),
+        AIShadowPromptVersion: os.Getenv("AI_SHADOW_PROMPT_VERSION"),
+        AIPromptsDir:          os.Getenv("AI_PROMPTS_DIR"),
+        AIChunkTokens:  getenvInt("AI_CHUNK_TOKENS", 1200),
+        AIChunkOverlap: getenvInt("AI_CHUNK_OVERLAP", 80),
+        AIPricePrompt:     getenvFloat("AI_PRICE_PROMPT", 0),
+        AIPriceCompletion: getenvFloat("AI_PRICE_COMPLETION", 0),
+        AIScanMaxTokens:   getenvInt("AI_SCAN_MAX_TOKENS", 40000),
+        AIScanMaxUSD:      getenvFloat("AI_SCAN_MAX_USD", 0),
+        AIDailyMaxTokens:  getenvInt("AI_DAILY_MAX_TOKENS", 0),
+        AIDailyMaxUSD:     getenvFloat("AI_DAILY_MAX_USD", 0),
+        AIGlobalMaxTokens: getenvInt("AI_GLOBAL_DAILY_MAX_TOKENS", 0),
+        AIGlobalMaxUSD:    getenvFloat("AI_GLOBAL_DAILY_MAX_USD", 0),
+        AdminToken:  os.Getenv("ADMIN_TOKEN"),
     }
     if cfg.DatabaseURL == "" {
         // Not fatal for early local runs; warn via error value so callers can decide.
@@ -41,3 +108,26 @@ func getenvInt(key string, def int) int {
     }
     return def
 }
+
+func getenvList(key string) []string {
+    var out []string
+    for _, v := range strings.Split(os.Getenv(key), ",") {
+        if v = strings.TrimSpace(v); v != "" { out = append(out, v) }
+    }
+    return out
+}
+
+func getenvBool(key string, def bool) bool {
+    if v, err := strconv.ParseBool(os.Getenv(key)); err == nil { return v }
+    return def
+}
+
+func getenvDuration(key string, def time.Duration) time.Duration {
+    if v, err := time.ParseDuration(os.Getenv(key)); err == nil { return v }
+    return def
+}
+
+func getenvFloat(key string, def float64) float64 {
+    if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil { return v }
+    return def
+}
@@ -38,6 +38,7 @@ type Evidence struct {
     RetrievedAt time.Time
     Hash       string
     Payload    any
+    Meta       map[string]any
 }
 
 type Signal struct {
@@ -49,8 +50,20 @@ type Signal struct {
     Confidence float64
     Source     string
     RetrievedAt time.Time
+    EvidenceRefs []string // hashes of the evidence rows backing this signal
 }
 
+// Policy document types discovered on a site.
+const (
+    PolicyPrivacy       = "privacy"
+    PolicyTerms         = "terms"
+    PolicyCookies       = "cookies"
+    PolicySubprocessors = "subprocessors" // a published list of sub-processors
+)
+
+// PolicyTypes lists document types in discovery order.
+var PolicyTypes = []string{PolicyPrivacy, PolicyTerms, PolicyCookies, PolicySubprocessors}
+
 type Issue struct {
     ID       string
     ScanRef  string
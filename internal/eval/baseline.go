@@ -0,0 +1,64 @@
+package eval
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+)
+
+// Tolerance absorbs float noise when comparing rates against a baseline.
+const Tolerance = 1e-9
+
+// ReadReport loads a baseline written by WriteReport.
+func ReadReport(path string) (Report, error) {
+    var r Report
+    raw, err := os.ReadFile(path)
+    if err != nil { return r, err }
+    err = json.Unmarshal(raw, &r)
+    return r, err
+}
+
+// WriteReport stores r as indented JSON, stable for review in diffs.
+func WriteReport(path string, r Report) error {
+    raw, err := json.MarshalIndent(r, "", "  ")
+    if err != nil { return err }
+    return os.WriteFile(path, append(raw, '\n'), 0o644)
+}
+
+// Regressions lists how cur is worse than base: lower precision, recall or F1 or a
+// higher false positive rate for any signal, and mismatches or score failures that
+// the baseline did not have. Improvements are not reported.
+func Regressions(base, cur Report) []string {
+    var out []string
+    for _, code := range sortedKeys(cur.Signals) {
+        c := cur.Signals[code]
+        b, ok := base.Signals[code]
+        if !ok { continue }
+        out = append(out, worse(code, "precision", b.Precision, c.Precision, false)...)
+        out = append(out, worse(code, "recall", b.Recall, c.Recall, false)...)
+        out = append(out, worse(code, "f1", b.F1, c.F1, false)...)
+        out = append(out, worse(code, "fpr", b.FPR, c.FPR, true)...)
+    }
+    known := map[string]bool{}
+    for _, m := range base.Mismatches { known[m.Case+"\x00"+m.Code] = true }
+    for _, m := range cur.Mismatches {
+        if !known[m.Case+"\x00"+m.Code] {
+            out = append(out, fmt.Sprintf("%s: %s expected %v, got %v", m.Case, m.Code, m.Expected, m.Got))
+        }
+    }
+    failed := map[string]bool{}
+    for _, f := range base.ScoreFailures { failed[f.Case+"\x00"+f.Category] = true }
+    for _, f := range cur.ScoreFailures {
+        if !failed[f.Case+"\x00"+f.Category] {
+            out = append(out, fmt.Sprintf("%s: %s score %d outside [%d, %d]", f.Case, f.Category, f.Got, f.Min, f.Max))
+        }
+    }
+    return out
+}
+
+func worse(code, metric string, base, cur float64, higherIsWorse bool) []string {
+    if (higherIsWorse && cur > base+Tolerance) || (!higherIsWorse && cur < base-Tolerance) {
+        return []string{fmt.Sprintf("%s: %s %.3f -> %.3f", code, metric, base, cur)}
+    }
+    return nil
+}
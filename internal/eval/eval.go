@@ -0,0 +1,190 @@
+package eval
+
+import (
+    "context"
+    "fmt"
+    "sort"
+
+    aiadapter "camille/internal/adapters/ai"
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners/policy"
+    "camille/internal/scanners/policy/rules"
+    "camille/internal/services/scoring"
+)
+
+// Runner evaluates cases with the rule catalog and, when AI is set, a scripted
+// extractor replaying each case's recorded replies.
+type Runner struct {
+    Extractor ports.DocumentExtractor
+    Rules     *rules.Catalog
+    AI        bool
+}
+
+// Metrics counts outcomes for boolean signals, true being the positive class. A
+// signal that is missing or not a boolean is Unknown: it is a false negative when
+// true was expected and a miss, not a true negative, when an explicit false was.
+// Only an Absent expectation is met by silence. Any other expected string is a
+// value the signal must equal: a match is a true positive, anything else a false
+// negative.
+type Metrics struct {
+    TP        int     `json:"tp"`
+    FP        int     `json:"fp"`
+    FN        int     `json:"fn"`
+    TN        int     `json:"tn"`
+    Unknown   int     `json:"unknown"`
+    Precision float64 `json:"precision"`
+    Recall    float64 `json:"recall"`
+    F1        float64 `json:"f1"`
+    FPR       float64 `json:"fpr"`
+}
+
+// Mismatch is an expected signal a case got wrong.
+type Mismatch struct {
+    Case     string `json:"case"`
+    Code     string `json:"code"`
+    Expected any    `json:"expected"`
+    Got      any    `json:"got"`
+}
+
+// ScoreFailure is a sub-score outside a case's expected range.
+type ScoreFailure struct {
+    Case     string `json:"case"`
+    Category string `json:"category"`
+    Got      int    `json:"got"`
+    Min      int    `json:"min"`
+    Max      int    `json:"max"`
+}
+
+// Report is the outcome of a run; it doubles as the baseline format.
+type Report struct {
+    RulesVersion  string             `json:"rules_version"`
+    Cases         int                `json:"cases"`
+    Overall       Metrics            `json:"overall"`
+    Signals       map[string]Metrics `json:"signals"`
+    Mismatches    []Mismatch         `json:"mismatches"`
+    ScoreChecks   int                `json:"score_checks"`
+    ScoreFailures []ScoreFailure     `json:"score_failures"`
+}
+
+// Run evaluates every case.
+func (r *Runner) Run(ctx context.Context, cases []Case) (Report, error) {
+    rep := Report{RulesVersion: r.Rules.Version, Cases: len(cases), Signals: map[string]Metrics{}, Mismatches: []Mismatch{}, ScoreFailures: []ScoreFailure{}}
+    for _, c := range cases {
+        sigs, err := r.scan(ctx, c)
+        if err != nil { return rep, fmt.Errorf("case %s: %w", c.Name, err) }
+        got := map[string]any{}
+        for _, s := range sigs { got[s.Code] = s.Value }
+
+        codes := make([]string, 0, len(c.Expect))
+        for code := range c.Expect { codes = append(codes, code) }
+        sort.Strings(codes)
+        for _, code := range codes {
+            want, v := c.Expect[code], got[code]
+            m := rep.Signals[code]
+            ok := m.add(want, v)
+            rep.Signals[code] = m
+            rep.Overall.add(want, v)
+            if !ok { rep.Mismatches = append(rep.Mismatches, Mismatch{Case: c.Name, Code: code, Expected: want, Got: v}) }
+        }
+
+        scores, _ := scoring.Compute(sigs)
+        for _, cat := range sortedKeys(c.Scores) {
+            rng := c.Scores[cat]
+            v, ok := subScore(scores, cat)
+            if !ok { return rep, fmt.Errorf("case %s: unknown score category %q", c.Name, cat) }
+            rep.ScoreChecks++
+            if v < rng[0] || v > rng[1] {
+                rep.ScoreFailures = append(rep.ScoreFailures, ScoreFailure{Case: c.Name, Category: cat, Got: v, Min: rng[0], Max: rng[1]})
+            }
+        }
+    }
+    rep.Overall.finish()
+    for code, m := range rep.Signals {
+        m.finish()
+        rep.Signals[code] = m
+    }
+    return rep, nil
+}
+
+// scan extracts the case document and runs the policy scanner's fact extraction on it.
+func (r *Runner) scan(ctx context.Context, c Case) ([]domain.Signal, error) {
+    doc, err := c.extract(ctx, r.Extractor)
+    if err != nil { return nil, err }
+    var ai ports.AIExtractor
+    if r.AI {
+        steps := make([]aiadapter.Step, 0, len(c.AI))
+        for _, rec := range c.AI { steps = append(steps, aiadapter.Step{Match: rec.Match, Reply: rec.Reply}) }
+        ai = &aiadapter.Scripted{Model: "recorded", Steps: steps}
+    }
+    s := policy.New(nil, r.Extractor, r.Rules, ai)
+    t := ports.ScanTarget{Domain: c.Name, URL: c.URL, Tenant: "eval"}
+    return s.Analyze(ctx, t, map[string]ports.Document{c.Type: doc}).Signals, nil
+}
+
+// add counts one expectation and reports whether the prediction met it.
+func (m *Metrics) add(want, v any) bool {
+    if w, ok := want.(string); ok && w != Absent {
+        if v == w {
+            m.TP++
+            return true
+        }
+        m.FN++
+        if v == nil { m.Unknown++ }
+        return false
+    }
+    got, known := v.(bool)
+    switch {
+    case known && got:
+        if want == true {
+            m.TP++
+            return true
+        }
+        m.FP++
+    case want == true:
+        m.FN++
+        if !known { m.Unknown++ }
+    case want == Absent || known:
+        m.TN++
+        return true
+    default:
+        m.Unknown++
+    }
+    return false
+}
+
+// finish derives rates. With nothing predicted positive precision is 1, with
+// nothing to find recall is 1: neither case is an error.
+func (m *Metrics) finish() {
+    m.Precision, m.Recall = ratio(m.TP, m.TP+m.FP, 1), ratio(m.TP, m.TP+m.FN, 1)
+    if m.Precision+m.Recall > 0 { m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall) }
+    m.FPR = ratio(m.FP, m.FP+m.TN, 0)
+}
+
+func ratio(n, d int, empty float64) float64 {
+    if d == 0 { return empty }
+    return float64(n) / float64(d)
+}
+
+func subScore(s ports.Scores, cat string) (int, bool) {
+    switch cat {
+    case scoring.Privacy:
+        return s.Privacy, true
+    case scoring.Security:
+        return s.Security, true
+    case scoring.Governance:
+        return s.Governance, true
+    case scoring.Esg:
+        return s.Esg, true
+    case "overall":
+        return s.Overall, true
+    }
+    return 0, false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+    out := make([]string, 0, len(m))
+    for k := range m { out = append(out, k) }
+    sort.Strings(out)
+    return out
+}
@@ -0,0 +1,31 @@
+package eval
+
+import "testing"
+
+func TestMetricsAdd(t *testing.T) {
+    for _, tc := range []struct {
+        want, got any
+        ok        bool
+        m         Metrics
+    }{
+        {true, true, true, Metrics{TP: 1}},
+        {true, false, false, Metrics{FN: 1}},
+        {true, nil, false, Metrics{FN: 1, Unknown: 1}},
+        {true, "unknown", false, Metrics{FN: 1, Unknown: 1}},
+        {false, true, false, Metrics{FP: 1}},
+        {false, false, true, Metrics{TN: 1}},
+        {false, nil, false, Metrics{Unknown: 1}},
+        {false, "unknown", false, Metrics{Unknown: 1}},
+        {Absent, nil, true, Metrics{TN: 1}},
+        {Absent, false, true, Metrics{TN: 1}},
+        {Absent, true, false, Metrics{FP: 1}},
+        {"specific", "specific", true, Metrics{TP: 1}},
+        {"specific", "vague", false, Metrics{FN: 1}},
+        {"specific", nil, false, Metrics{FN: 1, Unknown: 1}},
+    } {
+        var m Metrics
+        if ok := m.add(tc.want, tc.got); ok != tc.ok || m != tc.m {
+            t.Errorf("add(%v, %v) = %v, %+v; want %v, %+v", tc.want, tc.got, ok, m, tc.ok, tc.m)
+        }
+    }
+}
@@ -0,0 +1,122 @@
+// Package eval scores policy fact extraction against a gold set of stored
+// documents with hand-labelled signals, and diffs the result against a baseline.
+package eval
+
+import (
+    "context"
+    "fmt"
+    "mime"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+)
+
+// Case is one gold-set directory: a stored policy document and what a scan of it
+// should report. It is described by case.yaml:
+//
+//    url: https://acme.example/privacy
+//    type: privacy                 # default
+//    document: policy.html         # default: the only other file in the directory
+//    expect:                       # true, false (explicitly denied), absent (not addressed)
+//      policy.data.sale.present: true  # or the exact value of a string signal
+//      policy.ai.training.userdata: absent
+//      policy.data.storage.retention.clarity: specific
+//    scores:
+//      privacy: [0, 60]            # inclusive range
+//    ai:                           # recorded extractor replies, first match wins
+//      - match: "sell your personal information"
+//        reply: '{"facts": [...]}'
+type Case struct {
+    Name        string            `yaml:"-"`
+    URL         string            `yaml:"url"`
+    Type        string            `yaml:"type"`
+    Document    string            `yaml:"document"`
+    ContentType string            `yaml:"content_type"`
+    Expect      map[string]any    `yaml:"expect"`
+    Scores      map[string][2]int `yaml:"scores"`
+    AI          []Recorded        `yaml:"ai"`
+
+    body []byte
+}
+
+// Absent expects a signal to be missing or not a boolean: the document does not
+// address it.
+const Absent = "absent"
+
+// Recorded is a stored AI reply replayed for chunks containing Match.
+type Recorded struct {
+    Match string `yaml:"match"`
+    Reply string `yaml:"reply"`
+}
+
+// Load reads every case directory under dir, by name.
+func Load(dir string) ([]Case, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil { return nil, err }
+    var out []Case
+    for _, e := range entries {
+        if !e.IsDir() { continue }
+        c, err := loadCase(filepath.Join(dir, e.Name()))
+        if err != nil { return nil, fmt.Errorf("case %s: %w", e.Name(), err) }
+        c.Name = e.Name()
+        out = append(out, c)
+    }
+    if len(out) == 0 { return nil, fmt.Errorf("no cases in %s", dir) }
+    sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+    return out, nil
+}
+
+func loadCase(dir string) (Case, error) {
+    var c Case
+    raw, err := os.ReadFile(filepath.Join(dir, "case.yaml"))
+    if err != nil { return c, err }
+    dec := yaml.NewDecoder(strings.NewReader(string(raw)))
+    dec.KnownFields(true)
+    if err := dec.Decode(&c); err != nil { return c, err }
+    if c.Type == "" { c.Type = domain.PolicyPrivacy }
+    if !validType(c.Type) { return c, fmt.Errorf("unknown policy type %q", c.Type) }
+    if len(c.Expect) == 0 { return c, fmt.Errorf("no expected signals") }
+    for code, v := range c.Expect {
+        switch v.(type) {
+        case bool, string:
+        default:
+            return c, fmt.Errorf("expect %s: want true, false, %s or a string value, got %v", code, Absent, v)
+        }
+    }
+    if c.Document == "" {
+        if c.Document, err = soleDocument(dir); err != nil { return c, err }
+    }
+    if c.body, err = os.ReadFile(filepath.Join(dir, c.Document)); err != nil { return c, err }
+    if c.ContentType == "" { c.ContentType = mime.TypeByExtension(filepath.Ext(c.Document)) }
+    if c.URL == "" { c.URL = "https://" + c.Name + ".example/" + c.Document }
+    return c, nil
+}
+
+func soleDocument(dir string) (string, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil { return "", err }
+    var names []string
+    for _, e := range entries {
+        if !e.IsDir() && e.Name() != "case.yaml" { names = append(names, e.Name()) }
+    }
+    if len(names) != 1 { return "", fmt.Errorf("set document: found %d candidate files", len(names)) }
+    return names[0], nil
+}
+
+func validType(typ string) bool {
+    for _, t := range domain.PolicyTypes {
+        if t == typ { return true }
+    }
+    return false
+}
+
+// extract turns the stored document into text with ex.
+func (c Case) extract(ctx context.Context, ex ports.DocumentExtractor) (ports.Document, error) {
+    return ex.Extract(ctx, ports.RawDocument{URL: c.URL, ContentType: c.ContentType, Body: c.body})
+}
@@ -0,0 +1,222 @@
+package ports
+
+import (
+    "context"
+    "time"
+)
+
+// AI extraction categories. Extractors drop facts outside this whitelist.
+const (
+    AIDataSale             = "data_sale"
+    AIDataSharing          = "data_sharing"
+    AITraining             = "ai_training"
+    AIRetentionSpecified   = "retention_specified"
+    AIRetentionIndefinite  = "retention_indefinite"
+    AIDeletionEmail        = "deletion_email"
+    AIChildrenRestrictions = "children_restrictions"
+)
+
+// AICategories describes each category for prompts, in a stable order.
+var AICategories = []struct{ Name, Description string }{
+    {AIDataSale, "selling personal data, or stating that it is not sold"},
+    {AIDataSharing, "sharing or disclosing personal data to third parties or partners"},
+    {AITraining, "using user data or content to train AI or machine learning models"},
+    {AIRetentionSpecified, "a concrete retention period (days, months, years) for some data"},
+    {AIRetentionIndefinite, "keeping data indefinitely, permanently or without a fixed period"},
+    {AIDeletionEmail, "an email address users can write to for deletion or other data rights requests"},
+    {AIChildrenRestrictions, "minimum age requirements or how children's data is handled"},
+}
+
+// AIExtractor processes policy documents and extracts structured facts.
+type AIExtractor interface {
+    // ExtractFacts also reports the tokens the call consumed, retries included,
+    // even when it fails.
+    ExtractFacts(ctx context.Context, doc PolicyDoc) ([]Fact, AIUsage, error)
+    // Info identifies the model and prompt behind every fact, for evidence.
+    Info() AIModelInfo
+}
+
+// AIModelInfo is recorded on every evidence row produced from an extractor's output.
+type AIModelInfo struct {
+    Provider      string `json:"provider"`
+    Model         string `json:"model"`
+    PromptVersion string `json:"prompt_version"`
+    PromptHash    string `json:"prompt_hash,omitempty"`
+}
+
+// AIUsage is what extractor calls consumed. Providers report tokens; CostUSD is
+// filled in from AIPricing by the caller.
+type AIUsage struct {
+    Calls            int     `json:"calls"`
+    PromptTokens     int     `json:"prompt_tokens"`
+    CompletionTokens int     `json:"completion_tokens"`
+    CostUSD          float64 `json:"cost_usd"`
+}
+
+// Tokens is the prompt and completion total.
+func (u AIUsage) Tokens() int { return u.PromptTokens + u.CompletionTokens }
+
+// Add accumulates o into u.
+func (u *AIUsage) Add(o AIUsage) {
+    u.Calls += o.Calls
+    u.PromptTokens += o.PromptTokens
+    u.CompletionTokens += o.CompletionTokens
+    u.CostUSD += o.CostUSD
+}
+
+// AIPricing is a model's price in USD per million tokens.
+type AIPricing struct {
+    PromptPerM     float64
+    CompletionPerM float64
+}
+
+// Cost estimates the price of u.
+func (p AIPricing) Cost(u AIUsage) float64 {
+    return (float64(u.PromptTokens)*p.PromptPerM + float64(u.CompletionTokens)*p.CompletionPerM) / 1e6
+}
+
+// PolicyDoc represents a document or chunk to be analyzed.
+type PolicyDoc struct {
+    Content    string   // The text content to analyze
+    SourceURL  string   // URL of the policy page
+    SectionURL string   // Optional: specific section/anchor URL
+    Language   string   // Optional: e.g., "en", "es"
+    Categories []string // Optional: restrict extraction to these categories
+    Meter      AIMeter  // Optional: consulted before and charged after every provider request
+}
+
+// AIMeter enforces a spend budget across every provider request an extraction
+// makes, retries and shadow runs included.
+type AIMeter interface {
+    // Allow reports whether another request may be sent.
+    Allow() bool
+    // Charge counts what one request consumed, successful or not.
+    Charge(info AIModelInfo, u AIUsage)
+}
+
+// Fact represents a single extracted claim from a policy document.
+type Fact struct {
+    Quote      string         `json:"quote"`               // REQUIRED: Exact quote from the policy
+    SectionURL string         `json:"section_url"`         // REQUIRED: URL including section anchor if available
+    Category   string         `json:"category"`            // one of the AI categories
+    Value      bool           `json:"value"`               // whether the practice is affirmed (false for "we do not sell")
+    Confidence float64        `json:"confidence"`          // 0.0 to 1.0
+    Metadata   map[string]any `json:"metadata,omitempty"`  // Optional additional context
+}
+
+// AICacheKey addresses a cached extraction: the same chunk text sent with the same
+// model, prompt and categories yields a reusable answer, whatever site it came from.
+type AICacheKey struct {
+    ChunkHash     string
+    Provider      string
+    Model         string
+    PromptVersion string
+    PromptHash    string
+    Categories    string // comma-separated, in AICategories order
+}
+
+// AICacheStats summarizes cache use for one model and prompt version.
+type AICacheStats struct {
+    Provider      string  `json:"provider"`
+    Model         string  `json:"model"`
+    PromptVersion string  `json:"prompt_version"`
+    Entries       int64   `json:"entries"`
+    Hits          int64   `json:"hits"`
+    HitRate       float64 `json:"hit_rate"` // hits / (hits + entries); every entry began as a miss
+}
+
+// AICacheRepository persists extractor results.
+type AICacheRepository interface {
+    GetAIFacts(ctx context.Context, key AICacheKey) ([]Fact, bool, error)
+    PutAIFacts(ctx context.Context, key AICacheKey, facts []Fact) error
+    // InvalidateAIPrompt drops every entry produced with promptVersion.
+    InvalidateAIPrompt(ctx context.Context, promptVersion string) (int64, error)
+    AICacheStats(ctx context.Context) ([]AICacheStats, error)
+}
+
+// AIUsageRecord is one extractor call attributed to a scan and its tenant.
+type AIUsageRecord struct {
+    ScanID string
+    Tenant string
+    AIModelInfo
+    AIUsage
+    Cached bool
+}
+
+// AIUsageTotal sums usage for a tenant on one UTC day.
+type AIUsageTotal struct {
+    Tenant string    `json:"tenant"`
+    Day    time.Time `json:"day"`
+    Cached int       `json:"cached_calls"`
+    AIUsage
+}
+
+// AIUsageFilter narrows usage totals; zero fields match everything.
+type AIUsageFilter struct {
+    Tenant string
+    ScanID string
+    Since  time.Time
+}
+
+// AIUsageRepository persists extractor spend for budgets and reporting.
+type AIUsageRepository interface {
+    RecordAIUsage(ctx context.Context, rec AIUsageRecord) error
+    // TenantAIUsage sums a tenant's usage since t.
+    TenantAIUsage(ctx context.Context, tenant string, since time.Time) (AIUsage, error)
+    AIUsageTotals(ctx context.Context, f AIUsageFilter) ([]AIUsageTotal, error)
+}
+
+// AIPromptInfo describes a registered prompt version.
+type AIPromptInfo struct {
+    Version string
+    Hash    string
+    Active  bool // answers scans
+    Shadow  bool // runs alongside the active prompt for comparison only
+}
+
+// AIFactDiff compares two extractions of one chunk by category. A category agrees
+// when both found it with the same values, conflicts when the values differ.
+type AIFactDiff struct {
+    Agreed        []string `json:"agreed"`
+    Conflicts     []string `json:"conflicts"`
+    PrimaryOnly   []string `json:"primary_only"`
+    CandidateOnly []string `json:"candidate_only"`
+}
+
+// AIShadowRun is one chunk extracted by the active prompt and a shadow candidate.
+type AIShadowRun struct {
+    ChunkHash      string
+    SourceURL      string
+    Primary        AIModelInfo
+    Candidate      AIModelInfo
+    PrimaryFacts   []Fact
+    CandidateFacts []Fact
+    Diff           AIFactDiff
+    Error          string // candidate failure, if any
+}
+
+// AIShadowCategory counts outcomes for one category across shadow runs.
+type AIShadowCategory struct {
+    Category      string
+    Agreed        int
+    Conflicts     int
+    PrimaryOnly   int
+    CandidateOnly int
+}
+
+// AIShadowReport summarizes shadow runs of a candidate prompt against a primary one.
+type AIShadowReport struct {
+    Primary    string
+    Candidate  string
+    Runs       int
+    Failed     int
+    Categories []AIShadowCategory
+    // Agreement is agreed categories over all categories either prompt found.
+    Agreement float64
+}
+
+// AIShadowRepository stores shadow runs and reports on them.
+type AIShadowRepository interface {
+    RecordAIShadow(ctx context.Context, run AIShadowRun) error
+    AIShadowReport(ctx context.Context, primary, candidate string, since time.Time) (AIShadowReport, error)
+}
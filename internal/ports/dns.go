@@ -0,0 +1,38 @@
+package ports
+
+import (
+    "context"
+    "net"
+)
+
+// MXRecord is a mail exchanger for a domain.
+type MXRecord struct {
+    Host string
+    Pref uint16
+}
+
+// CAARecord is a certification authority authorization record (RFC 8659).
+type CAARecord struct {
+    Flag  uint8
+    Tag   string // issue|issuewild|iodef|...
+    Value string
+}
+
+// DNSSECStatus describes a zone's DNSSEC state as seen by a validating resolver.
+type DNSSECStatus struct {
+    DNSKEY    bool // zone publishes DNSKEY records
+    DS        bool // parent publishes a DS record
+    Validated bool // resolver set the AD bit on the answer
+}
+
+// Resolver answers the DNS questions scanners ask. NXDOMAIN and empty answers
+// return no records and a nil error; transport failures return an error.
+type Resolver interface {
+    LookupTXT(ctx context.Context, name string) ([]string, error)
+    LookupMX(ctx context.Context, name string) ([]MXRecord, error)
+    LookupNS(ctx context.Context, name string) ([]string, error)
+    LookupCNAME(ctx context.Context, name string) (string, error)
+    LookupIP(ctx context.Context, name string) ([]net.IP, error)
+    LookupCAA(ctx context.Context, name string) ([]CAARecord, error)
+    LookupDNSSEC(ctx context.Context, name string) (DNSSECStatus, error)
+}
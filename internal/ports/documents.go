@@ -0,0 +1,81 @@
+package ports
+
+import (
+    "context"
+    "errors"
+)
+
+// ErrUnsupportedDocument is returned by extractors for content types they do not handle.
+var ErrUnsupportedDocument = errors.New("unsupported document type")
+
+// RawDocument is a fetched policy document before extraction.
+type RawDocument struct {
+    URL         string
+    ContentType string
+    Body        []byte
+}
+
+// Section is a heading and the text it governs. Start and End are byte offsets into
+// Document.Text; End runs to the next heading of the same or a higher level.
+type Section struct {
+    Heading string `json:"heading"`
+    Level   int    `json:"level"`            // 1 for h1 … 6 for h6
+    Anchor  string `json:"anchor,omitempty"` // fragment id, when the source has one
+    Parent  int    `json:"parent"`           // index of the enclosing section, -1 at top level
+    Start   int    `json:"start"`
+    End     int    `json:"end"`
+    Page    int    `json:"page,omitempty"`
+}
+
+// SourceSpan maps a range of Document.Text back to where it came from: a DOM path
+// for markup, a page number for paginated formats.
+type SourceSpan struct {
+    Start int    `json:"start"`
+    End   int    `json:"end"`
+    Path  string `json:"path,omitempty"`
+    Page  int    `json:"page,omitempty"`
+}
+
+// Document is readable text with its heading structure and offset map.
+type Document struct {
+    URL         string
+    ContentType string
+    Title       string
+    Language    string
+    Text        string
+    Sections    []Section
+    Spans       []SourceSpan
+    // Hash is the doc_hash: hex sha256 of the normalized text.
+    Hash string
+    // Unreadable marks documents with no extractable text (e.g. scanned PDFs).
+    Unreadable bool
+}
+
+// SectionAt returns the innermost section containing offset.
+func (d Document) SectionAt(offset int) (Section, bool) {
+    best := -1
+    for i, s := range d.Sections {
+        if offset >= s.Start && offset < s.End { best = i }
+    }
+    if best < 0 { return Section{}, false }
+    return d.Sections[best], true
+}
+
+// Locate returns the source span containing offset.
+func (d Document) Locate(offset int) (SourceSpan, bool) {
+    for _, s := range d.Spans {
+        if offset >= s.Start && offset < s.End { return s, true }
+    }
+    return SourceSpan{}, false
+}
+
+// SectionURL returns the document URL with the section's anchor, if any.
+func (d Document) SectionURL(s Section) string {
+    if s.Anchor == "" { return d.URL }
+    return d.URL + "#" + s.Anchor
+}
+
+// DocumentExtractor turns a fetched document into structured readable text.
+type DocumentExtractor interface {
+    Extract(ctx context.Context, raw RawDocument) (Document, error)
+}
@@ -0,0 +1,45 @@
+package ports
+
+import (
+    "context"
+    "crypto/x509"
+    "net/http"
+)
+
+// FetchRequest describes an outbound HTTP GET made on behalf of a scan.
+type FetchRequest struct {
+    URL    string
+    Header http.Header
+    // SameSite restricts redirects to the registrable domain (eTLD+1) of URL.
+    SameSite bool
+    // MaxBody overrides the fetcher's body cap when positive (e.g. for PDF documents).
+    MaxBody int64
+}
+
+// FetchHop records one response in a redirect chain.
+type FetchHop struct {
+    URL        string
+    StatusCode int
+    Header     http.Header
+}
+
+// FetchResponse is the final response of a fetch; Hops includes every response
+// in the redirect chain, the final one last.
+type FetchResponse struct {
+    URL        string
+    StatusCode int
+    Header     http.Header
+    Body       []byte
+    Truncated  bool
+    Hops       []FetchHop
+}
+
+// Fetcher retrieves remote resources with scan-safe limits (timeouts, body caps, SSRF guards).
+type Fetcher interface {
+    Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error)
+}
+
+// TLSProber reports the certificate chain a host presents on port 443.
+type TLSProber interface {
+    PeerCertificates(ctx context.Context, host string) ([]*x509.Certificate, error)
+}
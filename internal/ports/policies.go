@@ -0,0 +1,188 @@
+package ports
+
+import (
+    "context"
+    "strings"
+    "time"
+)
+
+// PolicyVersion is one distinct text of a domain's policy document, keyed by
+// doc_hash; URL is where it was last seen.
+// Text and Sections are only filled when a single version is loaded.
+type PolicyVersion struct {
+    ID          string
+    Type        string
+    URL         string
+    Hash        string
+    Title       string
+    Language    string
+    Text        string
+    Sections    []Section
+    FirstScanID string
+    FirstSeen   time.Time
+    LastSeen    time.Time
+    Seen        int
+}
+
+// Document rebuilds the stored version as an extracted document.
+func (v PolicyVersion) Document() Document {
+    return Document{URL: v.URL, Title: v.Title, Language: v.Language, Text: v.Text, Sections: v.Sections, Hash: v.Hash}
+}
+
+// Policy change kinds.
+const (
+    ChangeAdded    = "added"
+    ChangeRemoved  = "removed"
+    ChangeModified = "modified"
+)
+
+// PolicyChange is one clause added, removed or reworded between two versions.
+// Path lists the headings above the clause in the newer version (the older one for
+// removals), outermost first. Signals are the rule codes the clause text yields in
+// either version; Categories are the extraction categories it touches.
+type PolicyChange struct {
+    Kind       string   `json:"kind"`
+    Section    string   `json:"section,omitempty"`
+    Path       []string `json:"path,omitempty"`
+    From       string   `json:"from,omitempty"`
+    To         string   `json:"to,omitempty"`
+    Similarity float64  `json:"similarity,omitempty"`
+    Signals    []string `json:"signals,omitempty"`
+    Categories []string `json:"categories,omitempty"`
+}
+
+// PolicySignalChange is a rule signal whose value differs between two versions; a
+// nil side had no fact for the code.
+type PolicySignalChange struct {
+    Code string `json:"code"`
+    From any    `json:"from"`
+    To   any    `json:"to"`
+}
+
+// PolicyDiffSummary counts changes by kind, and sections with no counterpart.
+type PolicyDiffSummary struct {
+    Added, Removed, Modified       int
+    SectionsAdded, SectionsRemoved int
+}
+
+// PolicyDiff is the section-aligned difference between two versions of a document.
+type PolicyDiff struct {
+    From, To      PolicyVersion
+    Summary       PolicyDiffSummary
+    Categories    []string
+    SignalChanges []PolicySignalChange
+    Changes       []PolicyChange
+}
+
+// PolicyVersionRepository stores policy document versions per domain and type.
+type PolicyVersionRepository interface {
+    // RecordPolicyVersion stores doc as a version, or marks an identical one seen
+    // again. changed is true when the version seen last has a different text.
+    RecordPolicyVersion(ctx context.Context, domainID, scanID, typ string, doc Document) (id string, changed bool, err error)
+    // PolicyVersions lists a domain's versions of typ, most recently seen first, without text.
+    PolicyVersions(ctx context.Context, registrable, typ string) ([]PolicyVersion, error)
+    // PolicyVersion loads one version with its text.
+    PolicyVersion(ctx context.Context, registrable, typ, id string) (v PolicyVersion, found bool, err error)
+}
+
+// Policies serves stored policy versions, the differences between them and the
+// recipients they name.
+type Policies interface {
+    Versions(ctx context.Context, domain, typ string) ([]PolicyVersion, error)
+    // Diff compares version from to version to; an empty to means the latest and
+    // an empty from the version before to.
+    Diff(ctx context.Context, domain, typ, from, to string) (PolicyDiff, error)
+    // Recipients returns the latest recipient list with changes since the scan before.
+    Recipients(ctx context.Context, domain string) (RecipientList, error)
+}
+
+// Retention period kinds.
+const (
+    RetentionDays       = "days"
+    RetentionIndefinite = "indefinite"
+    RetentionAsNeeded   = "as_long_as_necessary"
+)
+
+// RetentionEntry is one row of a policy's retention schedule: how long a category
+// of data is kept and why, as stated in Quote (Document.Text[SpanStart:SpanEnd]).
+type RetentionEntry struct {
+    Category   string `json:"category"`
+    Period     string `json:"period"`                // a Retention* kind
+    Days       int    `json:"days,omitempty"`        // for RetentionDays, the longest period stated
+    LegalBasis string `json:"legal_basis,omitempty"` // legal_obligation, legal_claims, legitimate_interests, contract, consent
+    Trigger    string `json:"trigger,omitempty"`     // what starts the clock, e.g. account_closure
+    Quote      string `json:"quote"`
+    SpanStart  int    `json:"span_start"`
+    SpanEnd    int    `json:"span_end"`
+    DocType    string `json:"doc_type,omitempty"`
+    Section    string `json:"section,omitempty"`
+    SectionURL string `json:"section_url"`
+}
+
+// RetentionRepository reads stored retention schedules.
+type RetentionRepository interface {
+    // LatestRetention returns the schedule from the domain's latest scan that read
+    // a privacy policy; found is false when none has.
+    LatestRetention(ctx context.Context, registrable string) (entries []RetentionEntry, found bool, err error)
+}
+
+// Recipient roles.
+const (
+    RoleSubprocessor = "subprocessor" // listed on a sub-processor page or table
+    RoleRecipient    = "recipient"    // named in policy text as receiving data
+)
+
+// Recipient is a third party a site shares personal data with, as named in a policy
+// or listed on its sub-processor page. Entity, Owner and Domain are filled when the
+// name matches a known company; Countries holds ISO 3166 codes read from Location.
+type Recipient struct {
+    Name       string   `json:"name"`
+    Entity     string   `json:"entity,omitempty"`
+    Owner      string   `json:"owner,omitempty"`
+    Domain     string   `json:"domain,omitempty"`
+    Categories []string `json:"categories,omitempty"`
+    Role       string   `json:"role"`
+    Purpose    string   `json:"purpose,omitempty"`
+    Location   string   `json:"location,omitempty"`
+    Countries  []string `json:"countries,omitempty"`
+    Quote      string   `json:"quote"`
+    SpanStart  int      `json:"span_start"`
+    SpanEnd    int      `json:"span_end"`
+    DocType    string   `json:"doc_type"`
+    SectionURL string   `json:"section_url"`
+}
+
+// Key identifies a recipient across scans: its known entity, else its name.
+func (r Recipient) Key() string {
+    if r.Entity != "" { return strings.ToLower(r.Entity) }
+    return strings.ToLower(r.Name)
+}
+
+// RecipientSnapshot is the recipient list one scan stored.
+type RecipientSnapshot struct {
+    ScanID     string
+    Retrieved  time.Time
+    Recipients []Recipient
+}
+
+// RecipientChange is a recipient added, removed or with a changed purpose or
+// location between two snapshots; a nil side had no such recipient.
+type RecipientChange struct {
+    Kind string     `json:"kind"`
+    Name string     `json:"name"`
+    From *Recipient `json:"from,omitempty"`
+    To   *Recipient `json:"to,omitempty"`
+}
+
+// RecipientList is a domain's latest recipients and what changed since the scan before.
+type RecipientList struct {
+    Current  RecipientSnapshot
+    Previous *RecipientSnapshot
+    Changes  []RecipientChange
+}
+
+// RecipientRepository reads stored recipient lists.
+type RecipientRepository interface {
+    // RecipientSnapshots returns up to limit of the domain's latest snapshots, newest first.
+    RecipientSnapshots(ctx context.Context, registrable string, limit int) ([]RecipientSnapshot, error)
+}
@@ -4,7 +4,7 @@ import "context"
 
 // Scanner enqueues and tracks scans.
 type Scanner interface {
-    Enqueue(ctx context.Context, url string) (scanID string, err error)
+    Enqueue(ctx context.Context, url, tenant string) (scanID string, err error)
     Status(ctx context.Context, scanID string) (status string, progress float64, err error)
 }
 
@@ -1,6 +1,10 @@
 package ports
 
-import "context"
+import (
+    "context"
+
+    "camille/internal/domain"
+)
 
 // DomainRepository stores and fetches domains by registrable domain (eTLD+1).
 type DomainRepository interface {
@@ -9,8 +13,14 @@ type DomainRepository interface {
 
 // ScanRepository manages scan records and job tracking.
 type ScanRepository interface {
-    Create(ctx context.Context, domainID string, url string) (scanID string, err error)
+    Create(ctx context.Context, domainID, url, tenant string) (scanID string, err error)
     Status(ctx context.Context, scanID string) (status string, progress float64, err error)
+    Target(ctx context.Context, scanID string) (ScanTarget, error)
+}
+
+// Scores are the per-category sub-scores and overall grade for a domain.
+type Scores struct {
+    Privacy, Security, Governance, Esg, Overall int
 }
 
 // ScoreRepository provides latest score aggregates per domain.
@@ -19,5 +29,15 @@ type ScoreRepository interface {
         Privacy, Security, Governance, Esg, Overall int
         Badges []string
     }, err error)
+    UpsertScore(ctx context.Context, domainID string, score Scores, badges []string, methodVersion string) error
 }
 
+// EvidenceRepository stores raw artifacts backing signals.
+type EvidenceRepository interface {
+    AddEvidence(ctx context.Context, scanID string, ev domain.Evidence) (id string, err error)
+}
+
+// SignalsRepository stores normalized signals per domain.
+type SignalsRepository interface {
+    UpsertSignals(ctx context.Context, domainID, scanID string, sigs []domain.Signal) error
+}
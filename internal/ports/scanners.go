@@ -0,0 +1,49 @@
+package ports
+
+import (
+    "context"
+    "net/url"
+
+    "camille/internal/domain"
+)
+
+// ScanTarget identifies the site a scan runs against.
+type ScanTarget struct {
+    ScanID   string
+    DomainID string
+    Domain   string // registrable domain (eTLD+1)
+    URL      string // URL as submitted to POST /scan
+    Tenant   string // submitter, for AI budgets; empty when anonymous
+}
+
+// Origin returns scheme://host for the submitted URL, defaulting to https on the registrable domain.
+func (t ScanTarget) Origin() string {
+    u, err := url.Parse(t.URL)
+    if err != nil || u.Host == "" {
+        return "https://" + t.Domain
+    }
+    scheme := u.Scheme
+    if scheme == "" { scheme = "https" }
+    return scheme + "://" + u.Host
+}
+
+// Host returns the hostname of the submitted URL, defaulting to the registrable domain.
+func (t ScanTarget) Host() string {
+    if u, err := url.Parse(t.URL); err == nil && u.Hostname() != "" {
+        return u.Hostname()
+    }
+    return t.Domain
+}
+
+// ScanResult is the output of a site scanner: normalized signals plus the evidence backing them.
+// Signals reference evidence by hash through domain.Signal.EvidenceRefs.
+type ScanResult struct {
+    Signals  []domain.Signal
+    Evidence []domain.Evidence
+}
+
+// SiteScanner inspects a target site and reports evidence-backed signals.
+type SiteScanner interface {
+    Name() string
+    Scan(ctx context.Context, target ScanTarget) (ScanResult, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+// Tracker is a third-party domain attributed to its owning entity.
+type Tracker struct {
+    Domain      string
+    Owner       string   // legal entity name, e.g. "Google LLC"
+    DisplayName string   // e.g. "Google"
+    Categories  []string // e.g. "Advertising", "Analytics"
+    Prevalence  float64
+}
+
+// TrackerDirectory maps hosts to tracker entities (DuckDuckGo Tracker Radar).
+type TrackerDirectory interface {
+    // Lookup matches host or its closest listed parent domain.
+    Lookup(host string) (Tracker, bool)
+}
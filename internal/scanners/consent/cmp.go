@@ -0,0 +1,86 @@
+package consent
+
+import (
+    "bytes"
+    "regexp"
+)
+
+// CMP fingerprints a consent management platform by script hosts and markup tokens.
+type CMP struct {
+    Name    string
+    Markers []string // case-sensitive substrings of page markup
+    // Banner tokens appear only when the first-layer banner is server-rendered.
+    Banner  []string
+    // RejectAll tokens identify a first-layer "reject all" control.
+    RejectAll []string
+}
+
+var CMPs = []CMP{
+    {Name: "OneTrust", Markers: []string{"cdn.cookielaw.org", "otSDKStub.js", "optanon.blob.core.windows.net", "OptanonWrapper"},
+        Banner: []string{"onetrust-banner-sdk", "onetrust-consent-sdk"}, RejectAll: []string{"onetrust-reject-all-handler"}},
+    {Name: "Cookiebot", Markers: []string{"consent.cookiebot.com", "consent.cookiebot.eu", "Cookiebot"},
+        Banner: []string{"CybotCookiebotDialog"}, RejectAll: []string{"CybotCookiebotDialogBodyButtonDecline"}},
+    {Name: "Didomi", Markers: []string{"sdk.privacy-center.org", "didomiConfig", "window.didomiOnReady"},
+        Banner: []string{"didomi-notice", "didomi-host"}, RejectAll: []string{"didomi-notice-disagree-button"}},
+    {Name: "Quantcast Choice", Markers: []string{"cmp.quantcast.com", "quantcast.mgr.consensu.org"},
+        Banner: []string{"qc-cmp2-container", "qc-cmp2-ui"}},
+    {Name: "TrustArc", Markers: []string{"consent.trustarc.com", "truste.com/notice", "consent-pref.trustarc.com"},
+        Banner: []string{"truste-consent-track"}, RejectAll: []string{"truste-consent-required"}},
+    {Name: "Usercentrics", Markers: []string{"app.usercentrics.eu", "web.cmp.usercentrics.eu", "usercentrics-cmp"},
+        Banner: []string{"usercentrics-root"}},
+    {Name: "Sourcepoint", Markers: []string{"cdn.privacy-mgmt.com", "_sp_.config", "sp_message_container"}},
+    {Name: "Osano", Markers: []string{"cmp.osano.com"}, Banner: []string{"osano-cm-dialog"}, RejectAll: []string{"osano-cm-denyAll"}},
+    {Name: "CookieYes", Markers: []string{"cdn-cookieyes.com"}, Banner: []string{"cky-consent-container"}, RejectAll: []string{"cky-btn-reject"}},
+    {Name: "Termly", Markers: []string{"app.termly.io"}},
+    {Name: "iubenda", Markers: []string{"cdn.iubenda.com", "_iub.csConfiguration"}, Banner: []string{"iubenda-cs-banner"}, RejectAll: []string{"iubenda-cs-reject-btn"}},
+    {Name: "Complianz", Markers: []string{"cmplz_banner", "complianz-gdpr"}, Banner: []string{"cmplz-cookiebanner"}, RejectAll: []string{"cmplz-deny"}},
+    {Name: "consentmanager", Markers: []string{"consentmanager.net", "cdn.consentmanager.mgr.consensu.org"}, Banner: []string{"cmpbox"}, RejectAll: []string{"cmpboxbtnno"}},
+    {Name: "Cookie Script", Markers: []string{"cdn.cookie-script.com"}, Banner: []string{"cookiescript_injected"}, RejectAll: []string{"cookiescript_reject"}},
+    {Name: "Axeptio", Markers: []string{"static.axept.io", "axeptioSettings"}},
+    {Name: "Borlabs Cookie", Markers: []string{"BorlabsCookie", "borlabs-cookie"}, Banner: []string{"BorlabsCookieBox"}},
+    {Name: "CookieFirst", Markers: []string{"consent.cookiefirst.com"}},
+    {Name: "Klaro", Markers: []string{"klaroConfig", "klaro.js"}, Banner: []string{"klaro"}, RejectAll: []string{"cm-btn-decline"}},
+    {Name: "Google Funding Choices", Markers: []string{"fundingchoicesmessages.google.com"}},
+}
+
+// rejectAllText matches first-layer reject labels in the languages discovery supports.
+var rejectAllText = regexp.MustCompile(`(?i)>\s*(reject all|decline all|refuse all|reject cookies|deny all|alle ablehnen|tout refuser|rechazar todo|rechazar todas|rifiuta tutto|alles weigeren|alle weigeren|rejeitar tudo|recusar tudo)\s*<`)
+
+var (
+    tcfStub = regexp.MustCompile(`__tcfapi`)
+    gppStub = regexp.MustCompile(`__gpp(?:Locator)?\b`)
+    // TC strings are base64url segments joined by '.'. The core segment's first
+    // 6 bits are the version (2, so 'C') and the next 6 the top of the 36-bit
+    // Created time in deciseconds, 'O' to 'U' for 2018–2040. 42 characters hold
+    // the core fields with two empty vendor sections.
+    tcStringPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_-])(C[O-U][A-Za-z0-9_-]{40,}(?:\.[A-Za-z0-9_-]+)*)`)
+)
+
+// Detect returns the CMPs fingerprinted in page markup.
+func Detect(page []byte) []CMP {
+    var out []CMP
+    for _, c := range CMPs {
+        for _, m := range c.Markers {
+            if bytes.Contains(page, []byte(m)) {
+                out = append(out, c)
+                break
+            }
+        }
+    }
+    return out
+}
+
+// FirstLayerReject reports whether a server-rendered banner offers "reject all".
+// ok is false when no banner markup is present (banners injected by script are invisible statically).
+func FirstLayerReject(page []byte, cmps []CMP) (reject bool, ok bool) {
+    for _, c := range cmps {
+        for _, b := range c.Banner {
+            if bytes.Contains(page, []byte(b)) { ok = true }
+        }
+        for _, r := range c.RejectAll {
+            if bytes.Contains(page, []byte(r)) { return true, true }
+        }
+    }
+    if !ok { return false, false }
+    return rejectAllText.Match(page), true
+}
@@ -0,0 +1,39 @@
+package consent
+
+import (
+    "bytes"
+    "encoding/json"
+)
+
+// VendorList is a Global Vendor List (or CMP vendor subset) embedded in the page.
+type VendorList struct {
+    VendorListVersion int                        `json:"vendorListVersion"`
+    Vendors           map[string]json.RawMessage `json:"vendors"`
+}
+
+var gvlKey = []byte(`"vendorListVersion"`)
+
+// FindVendorLists decodes JSON objects carrying vendorListVersion and a vendors map.
+// For each key occurrence it tries the nearest preceding '{' characters as object starts.
+func FindVendorLists(page []byte) []VendorList {
+    var out []VendorList
+    offset := 0
+    for {
+        i := bytes.Index(page[offset:], gvlKey)
+        if i < 0 { return out }
+        at := offset + i
+        offset = at + len(gvlKey)
+        tries := 0
+        for j := at; j >= 0 && tries < 16; j-- {
+            if page[j] != '{' { continue }
+            tries++
+            var vl VendorList
+            dec := json.NewDecoder(bytes.NewReader(page[j:]))
+            if err := dec.Decode(&vl); err == nil && len(vl.Vendors) > 0 {
+                out = append(out, vl)
+                offset = j + int(dec.InputOffset())
+                break
+            }
+        }
+    }
+}
@@ -0,0 +1,104 @@
+// Package consent detects consent management platforms, IAB TCF/GPP stubs and
+// consent strings or vendor lists exposed by the landing page.
+package consent
+
+import (
+    "context"
+    "net/http"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "consent"
+
+// Scanner emits consent.* signals from static landing page markup.
+type Scanner struct {
+    Fetcher ports.Fetcher
+}
+
+func New(fetcher ports.Fetcher) *Scanner { return &Scanner{Fetcher: fetcher} }
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: scanners.LandingURL(t)})
+    if err != nil { return res, err }
+    page := resp.Body
+
+    cmps := Detect(page)
+    names := make([]string, 0, len(cmps))
+    for _, c := range cmps { names = append(names, c.Name) }
+    hasTCF := tcfStub.Match(page)
+    hasGPP := gppStub.Match(page)
+    reject, rejectKnown := FirstLayerReject(page, cmps)
+
+    var tcs []*TCString
+    for _, m := range tcStringPattern.FindAllSubmatch(page, 8) {
+        if tc, err := DecodeTCString(string(m[1])); err == nil { tcs = append(tcs, tc) }
+    }
+    for _, hop := range resp.Hops {
+        for _, c := range (&http.Response{Header: hop.Header}).Cookies() {
+            if c.Name != "euconsent-v2" { continue }
+            if tc, err := DecodeTCString(c.Value); err == nil { tcs = append(tcs, tc) }
+        }
+    }
+    lists := FindVendorLists(page)
+
+    vendorCount := -1
+    for _, vl := range lists {
+        if len(vl.Vendors) > vendorCount { vendorCount = len(vl.Vendors) }
+    }
+    for _, tc := range tcs {
+        if n := len(union(tc.VendorConsents, tc.VendorLI)); n > vendorCount { vendorCount = n }
+    }
+
+    listSummary := make([]map[string]int, 0, len(lists))
+    for _, vl := range lists {
+        listSummary = append(listSummary, map[string]int{"vendor_list_version": vl.VendorListVersion, "vendors": len(vl.Vendors)})
+    }
+    ev := scanners.NewEvidence("consent", resp.URL, page, map[string]any{
+        "url":          resp.URL,
+        "cmps":         names,
+        "tcfapi_stub":  hasTCF,
+        "gpp_stub":     hasGPP,
+        "tc_strings":   tcs,
+        "vendor_lists": listSummary,
+        "banner_found": rejectKnown,
+        "reject_all":   reject,
+    })
+    res.Evidence = append(res.Evidence, ev)
+    add := func(code string, value any, severity string, confidence float64) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, confidence, source, ev.Hash))
+    }
+
+    add("consent.cmp.present", len(cmps) > 0, "info", 1)
+    if len(cmps) > 0 { add("consent.cmp.name", strings.Join(names, ","), "info", 0.9) }
+    add("consent.tcf.stub", hasTCF, "info", 1)
+    add("consent.gpp.stub", hasGPP, "info", 1)
+    add("consent.tcf.string.present", len(tcs) > 0, "info", 1)
+    if len(tcs) > 0 {
+        add("consent.tcf.cmp_id", tcs[0].CmpID, "info", 1)
+        add("consent.tcf.purpose_consents", len(tcs[0].PurposeConsents), "info", 1)
+    }
+    if vendorCount >= 0 {
+        add("consent.vendors.count", vendorCount, scanners.SeverityIf(vendorCount > 100, "medium"), 1)
+    }
+    switch {
+    case len(cmps) == 0:
+    case rejectKnown:
+        add("consent.reject_all.first_layer", reject, scanners.SeverityIf(!reject, "medium"), 0.8)
+    default:
+        add("consent.reject_all.first_layer", "unknown", "info", 0.3)
+    }
+    return res, nil
+}
+
+func union(a, b []int) map[int]bool {
+    out := map[int]bool{}
+    for _, v := range a { out[v] = true }
+    for _, v := range b { out[v] = true }
+    return out
+}
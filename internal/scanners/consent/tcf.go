@@ -0,0 +1,140 @@
+package consent
+
+import (
+    "encoding/base64"
+    "errors"
+    "strings"
+    "time"
+)
+
+// TCString is the decoded core segment of an IAB TCF v2 consent string.
+type TCString struct {
+    Version           int       `json:"version"`
+    Created           time.Time `json:"created"`
+    LastUpdated       time.Time `json:"last_updated"`
+    CmpID             int       `json:"cmp_id"`
+    CmpVersion        int       `json:"cmp_version"`
+    ConsentLanguage   string    `json:"consent_language"`
+    VendorListVersion int       `json:"vendor_list_version"`
+    PolicyVersion     int       `json:"policy_version"`
+    PurposeConsents   []int     `json:"purpose_consents"`
+    PurposeLI         []int     `json:"purpose_legitimate_interests"`
+    PublisherCC       string    `json:"publisher_cc"`
+    VendorConsents    []int     `json:"vendor_consents"`
+    VendorLI          []int     `json:"vendor_legitimate_interests"`
+}
+
+var errShort = errors.New("tcf: string too short")
+
+type bitReader struct {
+    b   []byte
+    pos int
+}
+
+func (r *bitReader) int(n int) (int, error) {
+    if r.pos+n > len(r.b)*8 { return 0, errShort }
+    v := 0
+    for i := 0; i < n; i++ {
+        bit := (r.b[(r.pos+i)/8] >> (7 - uint((r.pos+i)%8))) & 1
+        v = v<<1 | int(bit)
+    }
+    r.pos += n
+    return v, nil
+}
+
+func (r *bitReader) letters() (string, error) {
+    a, err := r.int(6)
+    if err != nil { return "", err }
+    b, err := r.int(6)
+    if err != nil { return "", err }
+    return string([]byte{byte('A' + a), byte('A' + b)}), nil
+}
+
+func (r *bitReader) bits(n int) ([]int, error) {
+    var set []int
+    for i := 1; i <= n; i++ {
+        v, err := r.int(1)
+        if err != nil { return nil, err }
+        if v == 1 { set = append(set, i) }
+    }
+    return set, nil
+}
+
+// vendors reads a MaxVendorId + bitfield/range encoded vendor section.
+func (r *bitReader) vendors() ([]int, error) {
+    max, err := r.int(16)
+    if err != nil { return nil, err }
+    ranged, err := r.int(1)
+    if err != nil { return nil, err }
+    if ranged == 0 { return r.bits(max) }
+    n, err := r.int(12)
+    if err != nil { return nil, err }
+    var out []int
+    for i := 0; i < n; i++ {
+        isRange, err := r.int(1)
+        if err != nil { return nil, err }
+        start, err := r.int(16)
+        if err != nil { return nil, err }
+        end := start
+        if isRange == 1 {
+            if end, err = r.int(16); err != nil { return nil, err }
+        }
+        for v := start; v <= end && v <= max; v++ { out = append(out, v) }
+    }
+    return out, nil
+}
+
+func deciseconds(v int) time.Time { return time.UnixMilli(int64(v) * 100).UTC() }
+
+// DecodeTCString decodes the core segment (before the first '.') of a TCF v2 string.
+func DecodeTCString(s string) (*TCString, error) {
+    core, _, _ := strings.Cut(strings.TrimSpace(s), ".")
+    raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(core, "="))
+    if err != nil { return nil, err }
+    r := &bitReader{b: raw}
+    tc := &TCString{}
+    read := func(n int) int {
+        if err != nil { return 0 }
+        var v int
+        v, err = r.int(n)
+        return v
+    }
+    tc.Version = read(6)
+    if err == nil && tc.Version != 2 { return nil, errors.New("tcf: unsupported version") }
+    tc.Created = deciseconds(read(36))
+    tc.LastUpdated = deciseconds(read(36))
+    tc.CmpID = read(12)
+    tc.CmpVersion = read(12)
+    read(6) // consent screen
+    if err != nil { return nil, err }
+    if tc.ConsentLanguage, err = r.letters(); err != nil { return nil, err }
+    tc.VendorListVersion = read(12)
+    tc.PolicyVersion = read(6)
+    read(1) // is service specific
+    read(1) // use non-standard texts
+    read(12) // special feature opt-ins
+    if err != nil { return nil, err }
+    if tc.PurposeConsents, err = r.bits(24); err != nil { return nil, err }
+    if tc.PurposeLI, err = r.bits(24); err != nil { return nil, err }
+    read(1) // purpose one treatment
+    if err != nil { return nil, err }
+    if tc.PublisherCC, err = r.letters(); err != nil { return nil, err }
+    if tc.VendorConsents, err = r.vendors(); err != nil { return nil, err }
+    if tc.VendorLI, err = r.vendors(); err != nil { return nil, err }
+    if !plausible(tc) { return nil, errImplausible }
+    return tc, nil
+}
+
+var errImplausible = errors.New("tcf: header fields out of range")
+
+// tcfEpoch predates the first TCF v2 strings.
+var tcfEpoch = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// plausible rejects tokens that decode as version 2 by chance: real strings are
+// created after TCF v2 existed, updated no earlier than created, and name a CMP,
+// a vendor list and two-letter codes.
+func plausible(tc *TCString) bool {
+    upper := func(s string) bool { return len(s) == 2 && s[0] >= 'A' && s[0] <= 'Z' && s[1] >= 'A' && s[1] <= 'Z' }
+    return !tc.Created.Before(tcfEpoch) && !tc.LastUpdated.Before(tc.Created) &&
+        tc.CmpID > 0 && tc.VendorListVersion > 0 && upper(tc.ConsentLanguage) && upper(tc.PublisherCC)
+}
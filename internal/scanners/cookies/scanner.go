@@ -0,0 +1,184 @@
+// Package cookies records the cookies a landing page sets across its redirect
+// chain and grades their hygiene and pre-consent tracking.
+package cookies
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/url"
+    "sort"
+    "strings"
+    "time"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "cookies"
+
+// LongLivedDays flags cookies outliving the common 13-month ePrivacy guidance.
+const LongLivedDays = 395
+
+// Cookie is one Set-Cookie observed during the landing page fetch. Values are
+// never stored; only their length is kept.
+type Cookie struct {
+    Name         string  `json:"name"`
+    Domain       string  `json:"domain"`
+    Path         string  `json:"path,omitempty"`
+    SetBy        string  `json:"set_by"`
+    ThirdParty   bool    `json:"third_party"`
+    Secure       bool    `json:"secure"`
+    HttpOnly     bool    `json:"http_only"`
+    SameSite     string  `json:"same_site"` // strict|lax|none|unset
+    Session      bool    `json:"session"`
+    LifetimeDays float64 `json:"lifetime_days"`
+    ValueLength  int     `json:"value_length"`
+    Vendor       string  `json:"vendor,omitempty"`
+    Purpose      string  `json:"purpose,omitempty"`
+    Identifier   bool    `json:"identifier"`
+}
+
+// Scanner emits cookies.* signals for the landing page. Only HTTP Set-Cookie
+// headers are visible server-side; cookies written by scripts are not.
+type Scanner struct {
+    Fetcher ports.Fetcher
+    Now     func() time.Time
+}
+
+func New(fetcher ports.Fetcher) *Scanner { return &Scanner{Fetcher: fetcher, Now: time.Now} }
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: scanners.LandingURL(t)})
+    if err != nil { return res, err }
+    jar := Collect(resp.Hops, t.Domain, s.Now())
+
+    raw, _ := json.Marshal(jar)
+    ev := scanners.NewEvidence("cookies", resp.URL, raw, map[string]any{"url": resp.URL, "cookies": jar})
+    res.Evidence = append(res.Evidence, ev)
+
+    var thirdParty, tracking, identifiers, insecure, noneInsecure, unset, sessionNoHTTPOnly, longLived int
+    var maxDays float64
+    vendors := map[string]bool{}
+    https := strings.HasPrefix(resp.URL, "https://")
+    for _, c := range jar {
+        if c.ThirdParty { thirdParty++ }
+        if c.Vendor != "" && (c.Purpose == PurposeAdvertising || c.Purpose == PurposeAnalytics) {
+            tracking++
+            vendors[c.Vendor] = true
+        }
+        if c.Identifier { identifiers++ }
+        if https && !c.Secure { insecure++ }
+        if c.SameSite == "none" && !c.Secure { noneInsecure++ }
+        if c.SameSite == "unset" { unset++ }
+        if looksLikeSession(c.Name) && !c.HttpOnly { sessionNoHTTPOnly++ }
+        if c.LifetimeDays > LongLivedDays { longLived++ }
+        if c.LifetimeDays > maxDays { maxDays = c.LifetimeDays }
+    }
+    names := make([]string, 0, len(vendors))
+    for v := range vendors { names = append(names, v) }
+    sort.Strings(names)
+
+    add := func(code string, value any, severity string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, 1, source, ev.Hash))
+    }
+    add("cookies.count", len(jar), "info")
+    add("cookies.third_party.count", thirdParty, scanners.SeverityIf(thirdParty > 0, "low"))
+    add("cookies.tracking.count", tracking, scanners.SeverityIf(tracking > 0, "medium"))
+    add("cookies.tracker_vendors", strings.Join(names, ","), "info")
+    add("cookies.preconsent.identifiers", identifiers, scanners.SeverityIf(identifiers > 0, "high"))
+    add("cookies.insecure.count", insecure, scanners.SeverityIf(insecure > 0, "medium"))
+    add("cookies.samesite.none_insecure", noneInsecure, scanners.SeverityIf(noneInsecure > 0, "low"))
+    add("cookies.samesite.unset", unset, "info")
+    add("cookies.session.httponly_missing", sessionNoHTTPOnly, scanners.SeverityIf(sessionNoHTTPOnly > 0, "medium"))
+    add("cookies.long_lived.count", longLived, scanners.SeverityIf(longLived > 0, "low"))
+    add("cookies.max_lifetime_days", int(maxDays+0.5), "info")
+    return res, nil
+}
+
+// Collect parses Set-Cookie headers from every hop. Everything observed here is
+// set before the visitor could interact with a consent banner.
+func Collect(hops []ports.FetchHop, site string, now time.Time) []Cookie {
+    var out []Cookie
+    for _, hop := range hops {
+        u, err := url.Parse(hop.URL)
+        if err != nil { continue }
+        for _, hc := range (&http.Response{Header: hop.Header}).Cookies() {
+            c := Cookie{
+                Name:        hc.Name,
+                Domain:      strings.TrimPrefix(strings.ToLower(hc.Domain), "."),
+                Path:        hc.Path,
+                SetBy:       u.Hostname(),
+                Secure:      hc.Secure,
+                HttpOnly:    hc.HttpOnly,
+                SameSite:    sameSite(hc.SameSite),
+                ValueLength: len(hc.Value),
+            }
+            if c.Domain == "" { c.Domain = u.Hostname() }
+            c.ThirdParty = scanners.Registrable(c.Domain) != site
+            switch {
+            case hc.MaxAge > 0:
+                c.LifetimeDays = float64(hc.MaxAge) / 86400
+            case hc.MaxAge < 0:
+                continue // deletion
+            case !hc.Expires.IsZero():
+                if hc.Expires.Before(now) { continue } // deletion
+                c.LifetimeDays = hc.Expires.Sub(now).Hours() / 24
+            default:
+                c.Session = true
+            }
+            if v, ok := LookupVendor(hc.Name); ok {
+                c.Vendor, c.Purpose = v.Name, v.Purpose
+            }
+            c.Identifier = identifier(c, hc.Value)
+            out = append(out, c)
+        }
+    }
+    return out
+}
+
+// identifier flags cookies able to recognize a returning visitor: known tracking
+// cookies, or unknown persistent cookies carrying a high-entropy value.
+func identifier(c Cookie, value string) bool {
+    if c.Vendor != "" { return c.Purpose == PurposeAdvertising || c.Purpose == PurposeAnalytics }
+    return !c.Session && c.LifetimeDays >= 1 && highEntropy(value)
+}
+
+func highEntropy(v string) bool {
+    if len(v) < 16 { return false }
+    var digits, letters int
+    seen := map[rune]bool{}
+    for _, r := range v {
+        switch {
+        case r >= '0' && r <= '9':
+            digits++
+        case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+            letters++
+        }
+        seen[r] = true
+    }
+    return digits > 0 && letters > 0 && len(seen) >= 10
+}
+
+func looksLikeSession(name string) bool {
+    n := strings.ToLower(name)
+    for _, k := range []string{"sess", "sid", "auth", "token", "login"} {
+        if strings.Contains(n, k) { return true }
+    }
+    return false
+}
+
+func sameSite(m http.SameSite) string {
+    switch m {
+    case http.SameSiteStrictMode:
+        return "strict"
+    case http.SameSiteLaxMode:
+        return "lax"
+    case http.SameSiteNoneMode:
+        return "none"
+    }
+    return "unset"
+}
@@ -0,0 +1,105 @@
+package cookies
+
+import "strings"
+
+// Cookie purposes used for classification.
+const (
+    PurposeAdvertising = "advertising"
+    PurposeAnalytics   = "analytics"
+    PurposeFunctional  = "functional"
+    PurposeSecurity    = "security"
+)
+
+// Vendor attributes a well-known cookie name to the company that sets it.
+type Vendor struct {
+    Name    string
+    Purpose string
+}
+
+// knownCookies maps exact cookie names to vendors; knownPrefixes handles
+// names carrying per-property suffixes (e.g. _ga_<id>).
+var knownCookies = map[string]Vendor{
+    "_ga":                   {"Google Analytics", PurposeAnalytics},
+    "_gid":                  {"Google Analytics", PurposeAnalytics},
+    "_gat":                  {"Google Analytics", PurposeAnalytics},
+    "__utma":                {"Google Analytics", PurposeAnalytics},
+    "__utmz":                {"Google Analytics", PurposeAnalytics},
+    "_gcl_au":               {"Google Ads", PurposeAdvertising},
+    "IDE":                   {"Google Ads", PurposeAdvertising},
+    "test_cookie":           {"Google Ads", PurposeAdvertising},
+    "NID":                   {"Google", PurposeAdvertising},
+    "_fbp":                  {"Meta", PurposeAdvertising},
+    "fr":                    {"Meta", PurposeAdvertising},
+    "_uetsid":               {"Microsoft Advertising", PurposeAdvertising},
+    "_uetvid":               {"Microsoft Advertising", PurposeAdvertising},
+    "MUID":                  {"Microsoft", PurposeAdvertising},
+    "_clck":                 {"Microsoft Clarity", PurposeAnalytics},
+    "_clsk":                 {"Microsoft Clarity", PurposeAnalytics},
+    "_hjid":                 {"Hotjar", PurposeAnalytics},
+    "hubspotutk":            {"HubSpot", PurposeAnalytics},
+    "__hstc":                {"HubSpot", PurposeAnalytics},
+    "__hssc":                {"HubSpot", PurposeAnalytics},
+    "__hssrc":               {"HubSpot", PurposeAnalytics},
+    "bcookie":               {"LinkedIn", PurposeAdvertising},
+    "lidc":                  {"LinkedIn", PurposeAdvertising},
+    "li_gc":                 {"LinkedIn", PurposeAdvertising},
+    "UserMatchHistory":      {"LinkedIn", PurposeAdvertising},
+    "AnalyticsSyncHistory":  {"LinkedIn", PurposeAdvertising},
+    "_ttp":                  {"TikTok", PurposeAdvertising},
+    "ajs_anonymous_id":      {"Segment", PurposeAnalytics},
+    "ajs_user_id":           {"Segment", PurposeAnalytics},
+    "cto_bundle":            {"Criteo", PurposeAdvertising},
+    "_pinterest_ct_ua":      {"Pinterest", PurposeAdvertising},
+    "_pin_unauth":           {"Pinterest", PurposeAdvertising},
+    "personalization_id":    {"X (Twitter)", PurposeAdvertising},
+    "muc_ads":               {"X (Twitter)", PurposeAdvertising},
+    "s_cc":                  {"Adobe Analytics", PurposeAnalytics},
+    "s_sq":                  {"Adobe Analytics", PurposeAnalytics},
+    "s_vi":                  {"Adobe Analytics", PurposeAnalytics},
+    "_ym_uid":               {"Yandex Metrica", PurposeAnalytics},
+    "_ym_d":                 {"Yandex Metrica", PurposeAnalytics},
+    "_scid":                 {"Snap", PurposeAdvertising},
+    "__qca":                 {"Quantcast", PurposeAdvertising},
+    "optimizelyEndUserId":   {"Optimizely", PurposeAnalytics},
+    "__cf_bm":               {"Cloudflare", PurposeSecurity},
+    "cf_clearance":          {"Cloudflare", PurposeSecurity},
+    "__cfruid":              {"Cloudflare", PurposeSecurity},
+    "AWSALB":                {"Amazon Web Services", PurposeFunctional},
+    "AWSALBCORS":            {"Amazon Web Services", PurposeFunctional},
+    "OptanonConsent":        {"OneTrust", PurposeFunctional},
+    "OptanonAlertBoxClosed": {"OneTrust", PurposeFunctional},
+    "CookieConsent":         {"Cookiebot", PurposeFunctional},
+    "didomi_token":          {"Didomi", PurposeFunctional},
+    "euconsent-v2":          {"IAB TCF", PurposeFunctional},
+}
+
+var knownPrefixes = []struct {
+    Prefix string
+    Vendor Vendor
+}{
+    {"_ga_", Vendor{"Google Analytics", PurposeAnalytics}},
+    {"_gac_", Vendor{"Google Ads", PurposeAdvertising}},
+    {"_hjSession", Vendor{"Hotjar", PurposeAnalytics}},
+    {"_hjSessionUser_", Vendor{"Hotjar", PurposeAnalytics}},
+    {"_pk_id", Vendor{"Matomo", PurposeAnalytics}},
+    {"_pk_ses", Vendor{"Matomo", PurposeAnalytics}},
+    {"AMCV_", Vendor{"Adobe Experience Cloud", PurposeAnalytics}},
+    {"amp_", Vendor{"Amplitude", PurposeAnalytics}},
+    {"intercom-id-", Vendor{"Intercom", PurposeFunctional}},
+    {"intercom-session-", Vendor{"Intercom", PurposeFunctional}},
+}
+
+// LookupVendor returns the vendor for a cookie name, if known.
+func LookupVendor(name string) (Vendor, bool) {
+    if v, ok := knownCookies[name]; ok { return v, true }
+    for _, p := range knownPrefixes {
+        if strings.HasPrefix(name, p.Prefix) { return p.Vendor, true }
+    }
+    if strings.HasPrefix(name, "mp_") && strings.HasSuffix(name, "_mixpanel") {
+        return Vendor{"Mixpanel", PurposeAnalytics}, true
+    }
+    return Vendor{}, false
+}
+
+// Tracking reports whether the vendor purpose is advertising or analytics.
+func (v Vendor) Tracking() bool { return v.Purpose == PurposeAdvertising || v.Purpose == PurposeAnalytics }
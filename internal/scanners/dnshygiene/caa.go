@@ -0,0 +1,61 @@
+package dnshygiene
+
+import (
+    "crypto/x509"
+    "strings"
+
+    "camille/internal/ports"
+)
+
+// caIdentifiers maps issuer organization fragments to the CAA issuer domains a CA accepts.
+var caIdentifiers = []struct {
+    Match []string
+    CAA   []string
+}{
+    {[]string{"let's encrypt"}, []string{"letsencrypt.org"}},
+    {[]string{"digicert", "thawte", "geotrust", "rapidssl", "symantec"}, []string{"digicert.com", "thawte.com", "geotrust.com", "rapidssl.com", "symantec.com"}},
+    {[]string{"sectigo", "comodo", "zerossl"}, []string{"sectigo.com", "comodoca.com", "comodo.com", "zerossl.com"}},
+    {[]string{"google trust services"}, []string{"pki.goog"}},
+    {[]string{"amazon"}, []string{"amazon.com", "amazontrust.com", "awstrust.com", "amazonaws.com"}},
+    {[]string{"globalsign"}, []string{"globalsign.com"}},
+    {[]string{"godaddy", "starfield"}, []string{"godaddy.com", "starfieldtech.com"}},
+    {[]string{"entrust"}, []string{"entrust.net"}},
+    {[]string{"microsoft"}, []string{"microsoft.com"}},
+    {[]string{"buypass"}, []string{"buypass.com"}},
+    {[]string{"ssl.com"}, []string{"ssl.com"}},
+    {[]string{"certum", "asseco"}, []string{"certum.pl"}},
+}
+
+// Issuers returns the CA domains authorized by "issue" tags; an empty value (";") authorizes none.
+func Issuers(recs []ports.CAARecord) []string {
+    var out []string
+    for _, r := range recs {
+        if r.Tag != "issue" { continue }
+        id, _, _ := strings.Cut(r.Value, ";")
+        if id = strings.ToLower(strings.TrimSpace(id)); id != "" { out = append(out, id) }
+    }
+    return out
+}
+
+// IssuerCAA returns the CAA identifiers for the certificate's issuing organization, or nil if unknown.
+func IssuerCAA(cert *x509.Certificate) []string {
+    names := append([]string{cert.Issuer.CommonName}, cert.Issuer.Organization...)
+    for _, n := range names {
+        n = strings.ToLower(n)
+        for _, ca := range caIdentifiers {
+            for _, m := range ca.Match {
+                if strings.Contains(n, m) { return ca.CAA }
+            }
+        }
+    }
+    return nil
+}
+
+func authorized(issuers, caa []string) bool {
+    for _, a := range caa {
+        for _, b := range issuers {
+            if a == b { return true }
+        }
+    }
+    return false
+}
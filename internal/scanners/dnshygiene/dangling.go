@@ -0,0 +1,94 @@
+package dnshygiene
+
+import (
+    "bytes"
+    "context"
+    "strings"
+
+    "camille/internal/ports"
+)
+
+// CommonSubdomains are probed for dangling CNAMEs.
+var CommonSubdomains = []string{
+    "www", "blog", "shop", "store", "help", "support", "docs", "status", "dev", "staging",
+    "cdn", "assets", "static", "media", "app", "api", "beta", "mail", "careers", "jobs",
+}
+
+// cloudService describes a hosting service whose unclaimed resources can be taken over.
+// Fingerprint is body text the service serves for an unclaimed name; empty means only
+// a non-resolving target counts as dangling.
+type cloudService struct {
+    Name        string
+    Suffixes    []string
+    Fingerprint string
+}
+
+var cloudServices = []cloudService{
+    {"aws-s3", []string{".s3.amazonaws.com", ".s3-website"}, "NoSuchBucket"},
+    {"aws-cloudfront", []string{".cloudfront.net"}, "The request could not be satisfied"},
+    {"aws-elasticbeanstalk", []string{".elasticbeanstalk.com"}, ""},
+    {"azure", []string{".azurewebsites.net", ".cloudapp.net", ".cloudapp.azure.com", ".trafficmanager.net", ".blob.core.windows.net", ".azureedge.net", ".azure-api.net"}, ""},
+    {"github-pages", []string{".github.io"}, "There isn't a GitHub Pages site here"},
+    {"heroku", []string{".herokuapp.com", ".herokudns.com"}, "No such app"},
+    {"netlify", []string{".netlify.app", ".netlify.com"}, "Not Found - Request ID"},
+    {"shopify", []string{".myshopify.com"}, "Sorry, this shop is currently unavailable"},
+    {"fastly", []string{".fastly.net"}, "Fastly error: unknown domain"},
+    {"pantheon", []string{".pantheonsite.io"}, "The gods are wise"},
+    {"ghost", []string{".ghost.io"}, "Domain error"},
+    {"zendesk", []string{".zendesk.com"}, "Help Center Closed"},
+    {"readme", []string{".readme.io"}, "Project doesnt exist"},
+    {"surge", []string{".surge.sh"}, "project not found"},
+    {"bitbucket", []string{".bitbucket.io"}, "Repository not found"},
+    {"wordpress", []string{".wordpress.com"}, "Do you want to register"},
+}
+
+// Dangling is a subdomain aliasing an unclaimed cloud resource.
+type Dangling struct {
+    Host    string `json:"host"`
+    Target  string `json:"target"`
+    Service string `json:"service"`
+    Reason  string `json:"reason"` // nxdomain|fingerprint
+}
+
+func serviceFor(target string) (cloudService, bool) {
+    for _, svc := range cloudServices {
+        for _, suf := range svc.Suffixes {
+            if strings.HasSuffix(target, suf) || strings.Contains(target, suf+".") { return svc, true }
+        }
+    }
+    return cloudService{}, false
+}
+
+// danglingCNAMEs checks each common subdomain for a CNAME into a cloud service whose
+// target no longer resolves or serves the service's unclaimed-resource page.
+func danglingCNAMEs(ctx context.Context, r ports.Resolver, f ports.Fetcher, name string) ([]Dangling, map[string]string, error) {
+    var out []Dangling
+    aliases := map[string]string{}
+    for _, sub := range CommonSubdomains {
+        host := sub + "." + name
+        target, err := r.LookupCNAME(ctx, host)
+        if err != nil {
+            if ctx.Err() != nil { return out, aliases, ctx.Err() }
+            continue
+        }
+        if target == "" { continue }
+        aliases[host] = target
+        svc, ok := serviceFor(target)
+        if !ok { continue }
+        ips, err := r.LookupIP(ctx, target)
+        if err != nil { continue }
+        if len(ips) == 0 {
+            out = append(out, Dangling{Host: host, Target: target, Service: svc.Name, Reason: "nxdomain"})
+            continue
+        }
+        if svc.Fingerprint == "" || f == nil { continue }
+        resp, err := f.Fetch(ctx, ports.FetchRequest{URL: "https://" + host + "/"})
+        if err != nil {
+            resp, err = f.Fetch(ctx, ports.FetchRequest{URL: "http://" + host + "/"})
+        }
+        if err == nil && resp.StatusCode >= 400 && bytes.Contains(resp.Body, []byte(svc.Fingerprint)) {
+            out = append(out, Dangling{Host: host, Target: target, Service: svc.Name, Reason: "fingerprint"})
+        }
+    }
+    return out, aliases, nil
+}
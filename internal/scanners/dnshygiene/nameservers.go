@@ -0,0 +1,71 @@
+package dnshygiene
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "strings"
+
+    "golang.org/x/net/publicsuffix"
+
+    "camille/internal/ports"
+)
+
+// NSReport summarizes nameserver diversity.
+type NSReport struct {
+    Hosts     []string          `json:"hosts"`
+    Providers []string          `json:"providers"`
+    ASNs      []string          `json:"asns"`
+    HostASNs  map[string]string `json:"host_asns"`
+}
+
+func (r NSReport) Diverse() bool { return len(r.Providers) > 1 || len(r.ASNs) > 1 }
+
+// nameservers groups NS hosts by provider (registrable domain) and origin ASN,
+// looked up through Team Cymru's origin.asn.cymru.com TXT service.
+func nameservers(ctx context.Context, r ports.Resolver, name string) (NSReport, error) {
+    rep := NSReport{HostASNs: map[string]string{}}
+    hosts, err := r.LookupNS(ctx, name)
+    if err != nil { return rep, err }
+    rep.Hosts = hosts
+    providers := map[string]bool{}
+    asns := map[string]bool{}
+    for _, h := range hosts {
+        p, err := publicsuffix.EffectiveTLDPlusOne(h)
+        if err != nil { p = h }
+        if !providers[p] { rep.Providers = append(rep.Providers, p) }
+        providers[p] = true
+
+        ips, err := r.LookupIP(ctx, h)
+        if err != nil || len(ips) == 0 { continue }
+        asn, err := originASN(ctx, r, ips[0])
+        if err != nil || asn == "" { continue }
+        rep.HostASNs[h] = asn
+        if !asns[asn] { rep.ASNs = append(rep.ASNs, asn) }
+        asns[asn] = true
+    }
+    return rep, nil
+}
+
+func originASN(ctx context.Context, r ports.Resolver, ip net.IP) (string, error) {
+    var qname string
+    if v4 := ip.To4(); v4 != nil {
+        qname = fmt.Sprintf("%d.%d.%d.%d.origin.asn.cymru.com", v4[3], v4[2], v4[1], v4[0])
+    } else {
+        const hex = "0123456789abcdef"
+        var b strings.Builder
+        v6 := ip.To16()
+        for i := len(v6) - 1; i >= 0; i-- {
+            b.WriteByte(hex[v6[i]&0xf]); b.WriteByte('.')
+            b.WriteByte(hex[v6[i]>>4]); b.WriteByte('.')
+        }
+        qname = b.String() + "origin6.asn.cymru.com"
+    }
+    txts, err := r.LookupTXT(ctx, qname)
+    if err != nil || len(txts) == 0 { return "", err }
+    // "15169 | 8.8.8.0/24 | US | arin | 1992-12-01"; multi-origin prefixes list several ASNs.
+    first, _, _ := strings.Cut(txts[0], "|")
+    fields := strings.Fields(first)
+    if len(fields) == 0 { return "", nil }
+    return "AS" + fields[0], nil
+}
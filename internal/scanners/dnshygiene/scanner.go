@@ -0,0 +1,116 @@
+// Package dnshygiene checks zone-level DNS posture: DNSSEC, CAA authorization
+// against the live certificate, nameserver diversity and dangling CNAMEs.
+package dnshygiene
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "dnshygiene"
+
+// Scanner emits dns.* signals. TLS and Fetcher are optional; without them the
+// certificate issuer check reports unknown and dangling detection relies on NXDOMAIN only.
+type Scanner struct {
+    Resolver ports.Resolver
+    TLS      ports.TLSProber
+    Fetcher  ports.Fetcher
+}
+
+func New(resolver ports.Resolver, tls ports.TLSProber, fetcher ports.Fetcher) *Scanner {
+    return &Scanner{Resolver: resolver, TLS: tls, Fetcher: fetcher}
+}
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    add := func(code string, value any, severity string, refs ...string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, 1, source, refs...))
+    }
+
+    // DNSSEC
+    st, err := s.Resolver.LookupDNSSEC(ctx, t.Domain)
+    if err != nil { return res, err }
+    raw, _ := json.Marshal(st)
+    dev := scanners.NewEvidence("dns", "dns:"+t.Domain+"?type=DNSKEY", raw, map[string]any{
+        "name": t.Domain, "dnskey": st.DNSKEY, "ds": st.DS, "ad": st.Validated,
+    })
+    res.Evidence = append(res.Evidence, dev)
+    signed := st.DNSKEY && st.DS
+    add("dns.dnssec.signed", signed, scanners.SeverityIf(!signed, "low"), dev.Hash)
+    add("dns.dnssec.validated", st.Validated, scanners.SeverityIf(signed && !st.Validated, "high"), dev.Hash)
+
+    // CAA
+    caa, err := s.Resolver.LookupCAA(ctx, t.Domain)
+    if err != nil { return res, err }
+    var caaText []string
+    hasIodef := false
+    for _, r := range caa {
+        caaText = append(caaText, fmt.Sprintf("%d %s %q", r.Flag, r.Tag, r.Value))
+        if r.Tag == "iodef" { hasIodef = true }
+    }
+    cev := scanners.DNSEvidence(t.Domain, "CAA", caaText)
+    res.Evidence = append(res.Evidence, cev)
+    add("dns.caa.present", len(caa) > 0, scanners.SeverityIf(len(caa) == 0, "low"), cev.Hash)
+    if len(caa) > 0 {
+        issuers := Issuers(caa)
+        add("dns.caa.issuers", strings.Join(issuers, ","), "info", cev.Hash)
+        add("dns.caa.iodef.present", hasIodef, "info", cev.Hash)
+        s.issuerAuthorized(ctx, t, issuers, cev.Hash, &res)
+    }
+
+    // Nameserver diversity
+    ns, err := nameservers(ctx, s.Resolver, t.Domain)
+    if err != nil { return res, err }
+    nraw, _ := json.Marshal(ns)
+    nev := scanners.NewEvidence("dns", "dns:"+t.Domain+"?type=NS", nraw, ns)
+    res.Evidence = append(res.Evidence, nev)
+    add("dns.ns.count", len(ns.Hosts), scanners.SeverityIf(len(ns.Hosts) < 2, "medium"), nev.Hash)
+    add("dns.ns.providers", len(ns.Providers), "info", nev.Hash)
+    if len(ns.ASNs) > 0 { add("dns.ns.asns", len(ns.ASNs), "info", nev.Hash) }
+    add("dns.ns.diverse", ns.Diverse(), scanners.SeverityIf(!ns.Diverse(), "low"), nev.Hash)
+
+    // Dangling CNAMEs
+    dangling, aliases, err := danglingCNAMEs(ctx, s.Resolver, s.Fetcher, t.Domain)
+    if err != nil { return res, err }
+    if dangling == nil { dangling = []Dangling{} }
+    graw, _ := json.Marshal(map[string]any{"aliases": aliases, "dangling": dangling})
+    gev := scanners.NewEvidence("dns", "dns:"+t.Domain+"?type=CNAME", graw, map[string]any{
+        "probed": CommonSubdomains, "aliases": aliases, "dangling": dangling,
+    })
+    res.Evidence = append(res.Evidence, gev)
+    add("dns.cname.dangling", len(dangling), scanners.SeverityIf(len(dangling) > 0, "high"), gev.Hash)
+    return res, nil
+}
+
+// issuerAuthorized compares the live certificate's issuer with the CAA issue set.
+func (s *Scanner) issuerAuthorized(ctx context.Context, t ports.ScanTarget, issuers []string, caaRef string, res *ports.ScanResult) {
+    unknown := func() {
+        res.Signals = append(res.Signals, scanners.NewSignal("dns.caa.issuer_authorized", "unknown", "info", 0.5, source, caaRef))
+    }
+    if s.TLS == nil { unknown(); return }
+    host := t.Host()
+    certs, err := s.TLS.PeerCertificates(ctx, host)
+    if err != nil || len(certs) == 0 { unknown(); return }
+    leaf := certs[0]
+    caa := IssuerCAA(leaf)
+    lev := scanners.NewEvidence("tls.certificate", "https://"+host, leaf.Raw, map[string]any{
+        "subject":    leaf.Subject.String(),
+        "issuer":     leaf.Issuer.String(),
+        "not_after":  leaf.NotAfter,
+        "caa_domain": caa,
+    })
+    res.Evidence = append(res.Evidence, lev)
+    if caa == nil {
+        res.Signals = append(res.Signals, scanners.NewSignal("dns.caa.issuer_authorized", "unknown", "info", 0.5, source, caaRef, lev.Hash))
+        return
+    }
+    ok := authorized(issuers, caa)
+    res.Signals = append(res.Signals, scanners.NewSignal("dns.caa.issuer_authorized", ok, scanners.SeverityIf(!ok, "medium"), 1, source, caaRef, lev.Hash))
+}
@@ -0,0 +1,133 @@
+package dnshygiene
+
+import (
+    "context"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "fmt"
+    "testing"
+
+    "camille/internal/adapters/dns/dnstest"
+    "camille/internal/ports"
+    "camille/internal/scanners/scannertest"
+)
+
+type fakeTLS struct{ org string }
+
+func (f fakeTLS) PeerCertificates(ctx context.Context, host string) ([]*x509.Certificate, error) {
+    return []*x509.Certificate{{Issuer: pkix.Name{Organization: []string{f.org}}}}, nil
+}
+
+// fakeFetcher serves fixed responses by URL; anything else fails.
+type fakeFetcher map[string]ports.FetchResponse
+
+func (f fakeFetcher) Fetch(ctx context.Context, req ports.FetchRequest) (ports.FetchResponse, error) {
+    if resp, ok := f[req.URL]; ok {
+        resp.URL = req.URL
+        return resp, nil
+    }
+    return ports.FetchResponse{}, fmt.Errorf("no route to %s", req.URL)
+}
+
+// zone is the baseline every test starts from: an unsigned zone on two hosts of one provider.
+var zone = []string{
+    `example.com. 300 IN A 192.0.2.1`,
+    `example.com. 300 IN NS ns1.dnshost.net.`,
+    `example.com. 300 IN NS ns2.dnshost.net.`,
+    `ns1.dnshost.net. 300 IN A 198.51.100.1`,
+    `ns2.dnshost.net. 300 IN A 198.51.100.2`,
+    `1.100.51.198.origin.asn.cymru.com. 300 IN TXT "64500 | 198.51.100.0/24 | US | arin | 2001-01-01"`,
+    `2.100.51.198.origin.asn.cymru.com. 300 IN TXT "64500 | 198.51.100.0/24 | US | arin | 2001-01-01"`,
+}
+
+func TestDNSSEC(t *testing.T) {
+    srv := dnstest.Start(t, zone...)
+    sigs := scannertest.Scan(t, New(srv.Resolver(), nil, nil))
+    scannertest.Want(t, sigs, "dns.dnssec.signed", false)
+    scannertest.Want(t, sigs, "dns.dnssec.validated", false)
+
+    srv.Add(t, `example.com. 300 IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==`)
+    srv.Add(t, `example.com. 300 IN DS 2371 13 2 1F987CC6583E92DF0890718C42D4B5D6D3A5A1C3B4A17D7E1B2A5F4F9C6C1E0A`)
+    sigs = scannertest.Scan(t, New(srv.Resolver(), nil, nil))
+    scannertest.Want(t, sigs, "dns.dnssec.signed", true)
+    scannertest.Want(t, sigs, "dns.dnssec.validated", false)
+    if s := sigs["dns.dnssec.validated"]; s.Severity != "high" { t.Errorf("signed but not validating: severity %s, want high", s.Severity) }
+
+    srv.Validated = true
+    sigs = scannertest.Scan(t, New(srv.Resolver(), nil, nil))
+    scannertest.Want(t, sigs, "dns.dnssec.validated", true)
+}
+
+func TestCAA(t *testing.T) {
+    srv := dnstest.Start(t, zone...)
+    sigs := scannertest.Scan(t, New(srv.Resolver(), fakeTLS{"Let's Encrypt"}, nil))
+    scannertest.Want(t, sigs, "dns.caa.present", false)
+    if _, ok := sigs["dns.caa.issuer_authorized"]; ok { t.Error("issuer_authorized emitted without CAA records") }
+
+    srv.Add(t, `example.com. 300 IN CAA 0 issue "letsencrypt.org"`)
+    srv.Add(t, `example.com. 300 IN CAA 0 issue "pki.goog; cansignhttpexchanges=yes"`)
+    srv.Add(t, `example.com. 300 IN CAA 0 iodef "mailto:security@example.com"`)
+    sigs = scannertest.Scan(t, New(srv.Resolver(), fakeTLS{"Let's Encrypt"}, nil))
+    scannertest.Want(t, sigs, "dns.caa.present", true)
+    scannertest.Want(t, sigs, "dns.caa.issuers", "letsencrypt.org,pki.goog")
+    scannertest.Want(t, sigs, "dns.caa.iodef.present", true)
+    scannertest.Want(t, sigs, "dns.caa.issuer_authorized", true)
+
+    sigs = scannertest.Scan(t, New(srv.Resolver(), fakeTLS{"DigiCert Inc"}, nil))
+    scannertest.Want(t, sigs, "dns.caa.issuer_authorized", false)
+
+    sigs = scannertest.Scan(t, New(srv.Resolver(), fakeTLS{"Unheard Of CA"}, nil))
+    scannertest.Want(t, sigs, "dns.caa.issuer_authorized", "unknown")
+
+    sigs = scannertest.Scan(t, New(srv.Resolver(), nil, nil))
+    scannertest.Want(t, sigs, "dns.caa.issuer_authorized", "unknown")
+}
+
+func TestNameserverDiversity(t *testing.T) {
+    srv := dnstest.Start(t, zone...)
+    sigs := scannertest.Scan(t, New(srv.Resolver(), nil, nil))
+    scannertest.Want(t, sigs, "dns.ns.count", 2)
+    scannertest.Want(t, sigs, "dns.ns.providers", 1)
+    scannertest.Want(t, sigs, "dns.ns.asns", 1)
+    scannertest.Want(t, sigs, "dns.ns.diverse", false)
+
+    srv.Add(t, `example.com. 300 IN NS ns.otherdns.org.`)
+    srv.Add(t, `ns.otherdns.org. 300 IN A 203.0.113.9`)
+    srv.Add(t, `9.113.0.203.origin.asn.cymru.com. 300 IN TXT "64511 | 203.0.113.0/24 | DE | ripencc | 2005-01-01"`)
+    sigs = scannertest.Scan(t, New(srv.Resolver(), nil, nil))
+    scannertest.Want(t, sigs, "dns.ns.count", 3)
+    scannertest.Want(t, sigs, "dns.ns.providers", 2)
+    scannertest.Want(t, sigs, "dns.ns.asns", 2)
+    scannertest.Want(t, sigs, "dns.ns.diverse", true)
+}
+
+func TestDanglingCNAMEs(t *testing.T) {
+    srv := dnstest.Start(t, append(zone,
+        // Unclaimed: the Heroku app no longer exists.
+        `blog.example.com. 300 IN CNAME old-blog.herokuapp.com.`,
+        // Resolves, but the shop serves Shopify's unclaimed page.
+        `shop.example.com. 300 IN CNAME example.myshopify.com.`,
+        `example.myshopify.com. 300 IN A 192.0.2.20`,
+        // Claimed and serving content.
+        `docs.example.com. 300 IN CNAME example.github.io.`,
+        `example.github.io. 300 IN A 192.0.2.30`,
+        // Not a cloud service.
+        `www.example.com. 300 IN CNAME example.com.`,
+    )...)
+    f := fakeFetcher{
+        "https://shop.example.com/": {StatusCode: 404, Body: []byte("<h1>Sorry, this shop is currently unavailable.</h1>")},
+        "https://docs.example.com/": {StatusCode: 200, Body: []byte("<h1>Docs</h1>")},
+    }
+    scannertest.Want(t, scannertest.Scan(t, New(srv.Resolver(), nil, f)), "dns.cname.dangling", 2)
+
+    got, aliases, err := danglingCNAMEs(context.Background(), srv.Resolver(), f, "example.com")
+    if err != nil { t.Fatalf("danglingCNAMEs: %v", err) }
+    if len(aliases) != 4 { t.Errorf("aliases = %v, want 4", aliases) }
+    reasons := map[string]string{}
+    for _, d := range got { reasons[d.Host] = d.Service + "/" + d.Reason }
+    wantReasons := map[string]string{
+        "blog.example.com": "heroku/nxdomain",
+        "shop.example.com": "shopify/fingerprint",
+    }
+    if fmt.Sprint(reasons) != fmt.Sprint(wantReasons) { t.Errorf("dangling = %v, want %v", reasons, wantReasons) }
+}
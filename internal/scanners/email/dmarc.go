@@ -0,0 +1,73 @@
+package email
+
+import "strings"
+
+// DMARC is a parsed v=DMARC1 record (RFC 7489).
+type DMARC struct {
+    Record          string
+    Policy          string // none|quarantine|reject, or "invalid"
+    SubdomainPolicy string // sp, defaulting to Policy
+    Pct             int
+    RUA             []string
+    RUF             []string
+    Errors          []string
+}
+
+// FindDMARC returns the v=DMARC1 records among TXT strings.
+func FindDMARC(txts []string) []string {
+    var out []string
+    for _, t := range txts {
+        if strings.HasPrefix(strings.ToLower(strings.TrimSpace(t)), "v=dmarc1") { out = append(out, t) }
+    }
+    return out
+}
+
+// ParseDMARC parses tag=value pairs and applies RFC defaults.
+func ParseDMARC(record string) *DMARC {
+    d := &DMARC{Record: record, Pct: 100}
+    tags := map[string]string{}
+    for _, part := range strings.Split(record, ";") {
+        k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+        if !ok { continue }
+        tags[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+    }
+    d.Policy = policy(tags["p"])
+    if d.Policy == "invalid" { d.Errors = append(d.Errors, "p tag missing or invalid") }
+    d.SubdomainPolicy = d.Policy
+    if sp, ok := tags["sp"]; ok {
+        d.SubdomainPolicy = policy(sp)
+        if d.SubdomainPolicy == "invalid" { d.Errors = append(d.Errors, "sp tag invalid") }
+    }
+    if pct, ok := tags["pct"]; ok {
+        n := -1
+        for _, c := range pct {
+            if c < '0' || c > '9' { n = -1; break }
+            if n < 0 { n = 0 }
+            n = n*10 + int(c-'0')
+        }
+        if n < 0 || n > 100 {
+            d.Errors = append(d.Errors, "pct tag invalid")
+        } else {
+            d.Pct = n
+        }
+    }
+    d.RUA = uris(tags["rua"])
+    d.RUF = uris(tags["ruf"])
+    return d
+}
+
+func policy(v string) string {
+    switch strings.ToLower(v) {
+    case "none", "quarantine", "reject":
+        return strings.ToLower(v)
+    }
+    return "invalid"
+}
+
+func uris(v string) []string {
+    var out []string
+    for _, u := range strings.Split(v, ",") {
+        if u = strings.TrimSpace(u); u != "" { out = append(out, u) }
+    }
+    return out
+}
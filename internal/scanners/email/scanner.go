@@ -0,0 +1,154 @@
+// Package email checks SPF, DMARC and DKIM publication for a domain.
+package email
+
+import (
+    "context"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "email"
+
+// DefaultDKIMSelectors are probed when no selector list is configured. DKIM
+// selectors cannot be enumerated, so a miss is reported as unknown, not absent.
+var DefaultDKIMSelectors = []string{
+    "default", "google", "selector1", "selector2", "k1", "k2", "k3", "s1", "s2",
+    "dkim", "mail", "smtp", "mxvault", "zoho", "mandrill", "everlytickey1", "amazonses",
+}
+
+// Scanner emits email.* signals from DNS.
+type Scanner struct {
+    Resolver  ports.Resolver
+    Selectors []string
+}
+
+func New(resolver ports.Resolver, selectors []string) *Scanner {
+    if len(selectors) == 0 { selectors = DefaultDKIMSelectors }
+    return &Scanner{Resolver: resolver, Selectors: selectors}
+}
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    if err := s.spf(ctx, t.Domain, &res); err != nil { return res, err }
+    if err := s.dmarc(ctx, t.Domain, &res); err != nil { return res, err }
+    if err := s.dkim(ctx, t.Domain, &res); err != nil { return res, err }
+    return res, nil
+}
+
+func (s *Scanner) spf(ctx context.Context, name string, res *ports.ScanResult) error {
+    txts, err := s.Resolver.LookupTXT(ctx, name)
+    if err != nil { return err }
+    recs := FindSPF(txts)
+    ev := scanners.DNSEvidence(name, "TXT", txts)
+    res.Evidence = append(res.Evidence, ev)
+    add := signalAdder(res, ev.Hash)
+
+    add("email.spf.present", len(recs) > 0, scanners.SeverityIf(len(recs) == 0, "medium"))
+    if len(recs) == 0 { return nil }
+
+    spf := ParseSPF(recs[0])
+    if len(recs) > 1 {
+        spf.Errors = append(spf.Errors, "multiple SPF records published")
+    }
+    included := map[string][]string{}
+    if err := spf.CountLookups(ctx, s.Resolver, included); err != nil { return err }
+    for inc, recs := range included {
+        iev := scanners.DNSEvidence(inc, "TXT", recs)
+        res.Evidence = append(res.Evidence, iev)
+    }
+
+    exceeded := spf.Lookups > MaxSPFLookups
+    add("email.spf.valid", len(spf.Errors) == 0, scanners.SeverityIf(len(spf.Errors) > 0, "low"))
+    add("email.spf.lookups", spf.Lookups, "info")
+    add("email.spf.lookups.exceeded", exceeded, scanners.SeverityIf(exceeded, "medium"))
+    allSeverity := "info"
+    switch spf.All {
+    case "pass":
+        allSeverity = "high"
+    case "neutral", "none":
+        allSeverity = "low"
+    }
+    add("email.spf.all", spf.All, allSeverity)
+    return nil
+}
+
+func (s *Scanner) dmarc(ctx context.Context, name string, res *ports.ScanResult) error {
+    qname := "_dmarc." + name
+    txts, err := s.Resolver.LookupTXT(ctx, qname)
+    if err != nil { return err }
+    recs := FindDMARC(txts)
+    ev := scanners.DNSEvidence(qname, "TXT", txts)
+    res.Evidence = append(res.Evidence, ev)
+    add := signalAdder(res, ev.Hash)
+
+    add("email.dmarc.present", len(recs) > 0, scanners.SeverityIf(len(recs) == 0, "medium"))
+    if len(recs) == 0 { return nil }
+    d := ParseDMARC(recs[0])
+    if len(recs) > 1 { d.Errors = append(d.Errors, "multiple DMARC records published") }
+
+    add("email.dmarc.valid", len(d.Errors) == 0, scanners.SeverityIf(len(d.Errors) > 0, "low"))
+    add("email.dmarc.policy", d.Policy, policySeverity(d.Policy))
+    add("email.dmarc.subdomain_policy", d.SubdomainPolicy, policySeverity(d.SubdomainPolicy))
+    add("email.dmarc.pct", d.Pct, scanners.SeverityIf(d.Pct < 100, "low"))
+    add("email.dmarc.rua.present", len(d.RUA) > 0, "info")
+    add("email.dmarc.ruf.present", len(d.RUF) > 0, "info")
+    return nil
+}
+
+func (s *Scanner) dkim(ctx context.Context, name string, res *ports.ScanResult) error {
+    var found []string
+    var refs []string
+    for _, sel := range s.Selectors {
+        qname := sel + "._domainkey." + name
+        txts, err := s.Resolver.LookupTXT(ctx, qname)
+        if err != nil {
+            if ctx.Err() != nil { return ctx.Err() }
+            continue
+        }
+        if !hasDKIMKey(txts) { continue }
+        ev := scanners.DNSEvidence(qname, "TXT", txts)
+        res.Evidence = append(res.Evidence, ev)
+        refs = append(refs, ev.Hash)
+        found = append(found, sel)
+    }
+    if len(found) == 0 {
+        res.Signals = append(res.Signals, scanners.NewSignal("email.dkim.present", "unknown", "info", 0.5, source))
+        return nil
+    }
+    res.Signals = append(res.Signals,
+        scanners.NewSignal("email.dkim.present", true, "info", 1, source, refs...),
+        scanners.NewSignal("email.dkim.selectors", strings.Join(found, ","), "info", 1, source, refs...),
+    )
+    return nil
+}
+
+// hasDKIMKey reports whether any TXT string is a DKIM key record with a non-empty p= tag.
+func hasDKIMKey(txts []string) bool {
+    for _, t := range txts {
+        for _, part := range strings.Split(t, ";") {
+            k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+            if ok && strings.TrimSpace(k) == "p" && strings.TrimSpace(v) != "" { return true }
+        }
+    }
+    return false
+}
+
+func signalAdder(res *ports.ScanResult, evidence string) func(code string, value any, severity string) {
+    return func(code string, value any, severity string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, 1, source, evidence))
+    }
+}
+
+func policySeverity(p string) string {
+    switch p {
+    case "reject", "quarantine":
+        return "info"
+    case "none":
+        return "low"
+    }
+    return "medium"
+}
@@ -0,0 +1,100 @@
+package email
+
+import (
+    "context"
+    "fmt"
+    "testing"
+
+    "camille/internal/adapters/dns/dnstest"
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners/scannertest"
+)
+
+func scan(t *testing.T, srv *dnstest.Server, selectors ...string) map[string]domain.Signal {
+    t.Helper()
+    return scannertest.Scan(t, New(srv.Resolver(), selectors))
+}
+
+func TestScanStrictDomain(t *testing.T) {
+    srv := dnstest.Start(t,
+        `example.com. 300 IN TXT "v=spf1 include:_spf.mail.example.net ip4:192.0.2.0/24 -all"`,
+        `_spf.mail.example.net. 300 IN TXT "v=spf1 a mx -all"`,
+        `_dmarc.example.com. 300 IN TXT "v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:d@example.com"`,
+        `selector1._domainkey.example.com. 300 IN TXT "v=DKIM1; k=rsa; p=MIIBIjANBgkq"`,
+    )
+    sigs := scan(t, srv, "default", "selector1")
+
+    scannertest.Want(t, sigs, "email.spf.present", true)
+    scannertest.Want(t, sigs, "email.spf.valid", true)
+    scannertest.Want(t, sigs, "email.spf.lookups", 3)
+    scannertest.Want(t, sigs, "email.spf.lookups.exceeded", false)
+    scannertest.Want(t, sigs, "email.spf.all", "fail")
+    scannertest.Want(t, sigs, "email.dmarc.present", true)
+    scannertest.Want(t, sigs, "email.dmarc.policy", "reject")
+    scannertest.Want(t, sigs, "email.dmarc.subdomain_policy", "quarantine")
+    scannertest.Want(t, sigs, "email.dmarc.pct", 50)
+    scannertest.Want(t, sigs, "email.dmarc.rua.present", true)
+    scannertest.Want(t, sigs, "email.dmarc.ruf.present", false)
+    scannertest.Want(t, sigs, "email.dkim.present", true)
+    scannertest.Want(t, sigs, "email.dkim.selectors", "selector1")
+}
+
+func TestScanMissingRecords(t *testing.T) {
+    srv := dnstest.Start(t, `example.com. 300 IN A 192.0.2.1`)
+    sigs := scan(t, srv, "default")
+
+    scannertest.Want(t, sigs, "email.spf.present", false)
+    scannertest.Want(t, sigs, "email.dmarc.present", false)
+    // Selectors cannot be enumerated, so no key found is not proof of absence.
+    scannertest.Want(t, sigs, "email.dkim.present", "unknown")
+    if _, ok := sigs["email.spf.all"]; ok { t.Error("email.spf.all emitted without an SPF record") }
+}
+
+func TestScanSPFLookupLimit(t *testing.T) {
+    records := []string{`example.com. 300 IN TXT "v=spf1 include:a.example.net include:b.example.net ~all"`}
+    for _, n := range []string{"a", "b"} {
+        records = append(records, fmt.Sprintf(`%s.example.net. 300 IN TXT "v=spf1 a mx ptr exists:x.example.net include:%s2.example.net -all"`, n, n))
+        records = append(records, fmt.Sprintf(`%s2.example.net. 300 IN TXT "v=spf1 a mx -all"`, n))
+    }
+    sigs := scan(t, dnstest.Start(t, records...), "default")
+
+    scannertest.Want(t, sigs, "email.spf.lookups.exceeded", true)
+    scannertest.Want(t, sigs, "email.spf.all", "softfail")
+}
+
+func TestScanInvalidRecords(t *testing.T) {
+    srv := dnstest.Start(t,
+        `example.com. 300 IN TXT "v=spf1 ip4:300.1.1.1 +all"`,
+        `example.com. 300 IN TXT "v=spf1 -all"`,
+        `_dmarc.example.com. 300 IN TXT "v=DMARC1; p=monitor; pct=150"`,
+    )
+    sigs := scan(t, srv, "default")
+
+    scannertest.Want(t, sigs, "email.spf.valid", false)
+    scannertest.Want(t, sigs, "email.spf.all", "pass")
+    if s := sigs["email.spf.all"]; s.Severity != "high" { t.Errorf("+all severity = %s, want high", s.Severity) }
+    scannertest.Want(t, sigs, "email.dmarc.valid", false)
+    scannertest.Want(t, sigs, "email.dmarc.policy", "invalid")
+}
+
+func TestScanStoresRawTXT(t *testing.T) {
+    srv := dnstest.Start(t, `example.com. 300 IN TXT "v=spf1 -all"`, `example.com. 300 IN TXT "google-site-verification=abc"`)
+    res, err := New(srv.Resolver(), []string{"default"}).Scan(context.Background(), ports.ScanTarget{Domain: "example.com"})
+    if err != nil { t.Fatalf("Scan: %v", err) }
+    for _, ev := range res.Evidence {
+        p := ev.Payload.(map[string]any)
+        if p["name"] != "example.com" { continue }
+        if recs := p["records"].([]string); len(recs) != 2 { t.Errorf("records = %v, want both TXT strings", recs) }
+        return
+    }
+    t.Error("no evidence for example.com TXT")
+}
+
+func TestScanResolverFailure(t *testing.T) {
+    srv := dnstest.Start(t, `example.com. 300 IN A 192.0.2.1`)
+    srv.Fail("example.com")
+    if _, err := New(srv.Resolver(), nil).Scan(context.Background(), ports.ScanTarget{Domain: "example.com"}); err == nil {
+        t.Error("SERVFAIL should fail the scan rather than report records absent")
+    }
+}
@@ -0,0 +1,146 @@
+package email
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "strings"
+
+    "camille/internal/ports"
+)
+
+// MaxSPFLookups is the RFC 7208 §4.6.4 limit on DNS-querying terms.
+const MaxSPFLookups = 10
+
+// SPF is a parsed v=spf1 record.
+type SPF struct {
+    Record   string
+    All      string // pass|fail|softfail|neutral|none (qualifier on "all", or none)
+    Includes []string
+    Redirect string
+    Lookups  int // DNS-querying terms, following include/redirect recursively
+    Errors   []string
+}
+
+var qualifiers = map[byte]string{'+': "pass", '-': "fail", '~': "softfail", '?': "neutral"}
+
+// FindSPF returns the v=spf1 records among TXT strings.
+func FindSPF(txts []string) []string {
+    var out []string
+    for _, t := range txts {
+        lt := strings.ToLower(strings.TrimSpace(t))
+        if lt == "v=spf1" || strings.HasPrefix(lt, "v=spf1 ") { out = append(out, t) }
+    }
+    return out
+}
+
+// ParseSPF checks the syntax of a single record without resolving it.
+func ParseSPF(record string) *SPF {
+    s := &SPF{Record: record, All: "none"}
+    terms := strings.Fields(record)
+    if len(terms) == 0 || !strings.EqualFold(terms[0], "v=spf1") {
+        s.Errors = append(s.Errors, "record does not start with v=spf1")
+        return s
+    }
+    for _, term := range terms[1:] {
+        if name, value, ok := strings.Cut(term, "="); ok && !strings.ContainsAny(name, ":/") {
+            switch strings.ToLower(name) {
+            case "redirect":
+                if s.Redirect != "" { s.Errors = append(s.Errors, "redirect modifier repeated") }
+                if value == "" { s.Errors = append(s.Errors, "redirect modifier has no domain") }
+                s.Redirect = value
+                s.Lookups++
+            case "exp":
+            default:
+                if !validModifierName(name) { s.Errors = append(s.Errors, fmt.Sprintf("invalid modifier %q", term)) }
+            }
+            continue
+        }
+
+        mech := term
+        qual := "pass"
+        if q, ok := qualifiers[mech[0]]; ok {
+            qual = q
+            mech = mech[1:]
+        }
+        name, arg, hasArg := strings.Cut(mech, ":")
+        if !hasArg {
+            name, arg, _ = strings.Cut(mech, "/")
+            if arg != "" { arg = "/" + arg }
+        }
+        switch strings.ToLower(name) {
+        case "all":
+            if hasArg || arg != "" { s.Errors = append(s.Errors, "all takes no argument") }
+            s.All = qual
+        case "include":
+            if arg == "" { s.Errors = append(s.Errors, "include requires a domain") }
+            s.Includes = append(s.Includes, arg)
+            s.Lookups++
+        case "a", "mx":
+            s.Lookups++
+        case "ptr":
+            s.Lookups++
+        case "exists":
+            if arg == "" { s.Errors = append(s.Errors, "exists requires a domain") }
+            s.Lookups++
+        case "ip4":
+            if !validCIDR(arg, false) { s.Errors = append(s.Errors, fmt.Sprintf("invalid ip4 %q", arg)) }
+        case "ip6":
+            if !validCIDR(arg, true) { s.Errors = append(s.Errors, fmt.Sprintf("invalid ip6 %q", arg)) }
+        default:
+            s.Errors = append(s.Errors, fmt.Sprintf("unknown mechanism %q", term))
+        }
+    }
+    return s
+}
+
+// CountLookups resolves include/redirect targets and adds their DNS-querying terms
+// to Lookups, stopping once the limit is exceeded. Records fetched are appended to seen.
+func (s *SPF) CountLookups(ctx context.Context, r ports.Resolver, seen map[string][]string) error {
+    total := s.Lookups
+    queue := append([]string{}, s.Includes...)
+    if s.Redirect != "" { queue = append(queue, s.Redirect) }
+    visited := map[string]bool{}
+    for len(queue) > 0 && total <= MaxSPFLookups {
+        name := strings.ToLower(strings.TrimSuffix(queue[0], "."))
+        queue = queue[1:]
+        if visited[name] || strings.Contains(name, "%") { continue }
+        visited[name] = true
+        txts, err := r.LookupTXT(ctx, name)
+        if err != nil { return err }
+        recs := FindSPF(txts)
+        seen[name] = recs
+        if len(recs) != 1 {
+            s.Errors = append(s.Errors, fmt.Sprintf("%s: expected one SPF record, found %d", name, len(recs)))
+            continue
+        }
+        sub := ParseSPF(recs[0])
+        total += sub.Lookups
+        queue = append(queue, sub.Includes...)
+        if sub.Redirect != "" { queue = append(queue, sub.Redirect) }
+    }
+    s.Lookups = total
+    return nil
+}
+
+func validCIDR(arg string, v6 bool) bool {
+    addr, bits, hasBits := strings.Cut(arg, "/")
+    ip := net.ParseIP(addr)
+    if ip == nil || (ip.To4() != nil) == v6 { return false }
+    if !hasBits { return true }
+    max := 32
+    if v6 { max = 128 }
+    var n int
+    if _, err := fmt.Sscanf(bits, "%d", &n); err != nil || n < 0 || n > max { return false }
+    return true
+}
+
+func validModifierName(name string) bool {
+    if name == "" { return false }
+    for i, c := range name {
+        alpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+        if i == 0 && !alpha { return false }
+        if !alpha && !(c >= '0' && c <= '9') && c != '-' && c != '_' && c != '.' { return false }
+    }
+    return true
+}
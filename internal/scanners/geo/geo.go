@@ -0,0 +1,107 @@
+// Package geo reads countries and regions from free text and domain names, for
+// recipient locations, establishment addresses and regulatory applicability.
+package geo
+
+import (
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// countryCodes maps the country and region names policies use to ISO 3166-1 alpha-2
+// codes; EU stands for the European Union and EEA.
+var countryCodes = map[string]string{
+    "United States": "US", "United States of America": "US", "USA": "US", "U.S.": "US", "U.S.A.": "US", "US": "US",
+    "United Kingdom": "GB", "UK": "GB", "U.K.": "GB", "Great Britain": "GB", "England": "GB",
+    "European Union": "EU", "EU": "EU", "EEA": "EU", "European Economic Area": "EU",
+    "Ireland": "IE", "Germany": "DE", "France": "FR", "Netherlands": "NL", "The Netherlands": "NL", "Belgium": "BE",
+    "Luxembourg": "LU", "Sweden": "SE", "Finland": "FI", "Denmark": "DK", "Norway": "NO", "Iceland": "IS",
+    "Spain": "ES", "Italy": "IT", "Portugal": "PT", "Poland": "PL", "Austria": "AT", "Czech Republic": "CZ",
+    "Czechia": "CZ", "Romania": "RO", "Estonia": "EE", "Lithuania": "LT", "Latvia": "LV", "Greece": "GR",
+    "Bulgaria": "BG", "Hungary": "HU", "Croatia": "HR", "Slovakia": "SK", "Slovenia": "SI", "Switzerland": "CH",
+    "Canada": "CA", "Mexico": "MX", "Brazil": "BR", "Argentina": "AR", "Colombia": "CO", "Chile": "CL",
+    "Australia": "AU", "New Zealand": "NZ", "Japan": "JP", "South Korea": "KR", "Korea": "KR", "China": "CN",
+    "Hong Kong": "HK", "Taiwan": "TW", "Singapore": "SG", "India": "IN", "Philippines": "PH", "Malaysia": "MY",
+    "Indonesia": "ID", "Vietnam": "VN", "Israel": "IL", "United Arab Emirates": "AE", "UAE": "AE",
+    "South Africa": "ZA", "Ukraine": "UA", "Serbia": "RS", "Turkey": "TR", "Scotland": "GB", "Wales": "GB",
+    "Northern Ireland": "GB", "Cyprus": "CY", "Malta": "MT", "Liechtenstein": "LI",
+    "California": "US", "Delaware": "US", "New York": "US", "Washington": "US", "Texas": "US",
+}
+
+// regionCodes maps US states whose privacy laws the scanner tracks to ISO 3166-2 codes.
+var regionCodes = map[string]string{"California": "US-CA"}
+
+// californiaZip matches a California postal address line ("San Francisco, CA 94105").
+var californiaZip = regexp.MustCompile(`,\s*CA\s+9\d{4}\b`)
+
+// eu lists the EU and EEA member states.
+var eu = map[string]bool{
+    "EU": true, "AT": true, "BE": true, "BG": true, "HR": true, "CY": true, "CZ": true, "DK": true, "EE": true,
+    "FI": true, "FR": true, "DE": true, "GR": true, "HU": true, "IE": true, "IT": true, "LV": true, "LT": true,
+    "LU": true, "MT": true, "NL": true, "PL": true, "PT": true, "RO": true, "SK": true, "SI": true, "ES": true,
+    "SE": true, "IS": true, "LI": true, "NO": true,
+}
+
+var countryPattern *regexp.Regexp
+
+func init() {
+    names := make([]string, 0, len(countryCodes))
+    for n := range countryCodes { names = append(names, regexp.QuoteMeta(n)) }
+    sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+    countryPattern = regexp.MustCompile(`(?:^|[^\w.])(` + strings.Join(names, "|") + `)(?:[^\w]|$)`)
+}
+
+// Names lists the country names in s, in order and without repeats.
+func Names(s string) []string {
+    var out []string
+    for _, m := range countryPattern.FindAllStringSubmatch(s, -1) {
+        if !contains(out, m[1]) { out = append(out, m[1]) }
+    }
+    return out
+}
+
+// Countries lists the distinct codes of the countries named in s, sorted.
+func Countries(s string) []string {
+    var out []string
+    for _, n := range Names(s) {
+        if c := countryCodes[n]; !contains(out, c) { out = append(out, c) }
+    }
+    sort.Strings(out)
+    return out
+}
+
+// Regions lists the tracked regions named in s, such as US-CA for California.
+func Regions(s string) []string {
+    var out []string
+    for _, n := range Names(s) {
+        if c, ok := regionCodes[n]; ok && !contains(out, c) { out = append(out, c) }
+    }
+    if californiaZip.MatchString(s) && !contains(out, "US-CA") { out = append(out, "US-CA") }
+    return out
+}
+
+// InEU reports an EU or EEA member state, or EU itself.
+func InEU(code string) bool { return eu[code] }
+
+// vanityTLDs are country codes marketed and mostly used as generic domains.
+var vanityTLDs = map[string]bool{
+    "io": true, "ai": true, "co": true, "me": true, "tv": true, "fm": true, "ly": true, "to": true,
+    "cc": true, "ws": true, "sh": true, "so": true, "gl": true, "vc": true, "la": true, "gg": true,
+}
+
+// TLDCountry is the country of a domain's country-code top-level domain ("co.uk"
+// counts as uk); generic and vanity TLDs have none.
+func TLDCountry(domain string) (string, bool) {
+    i := strings.LastIndexByte(domain, '.')
+    tld := strings.ToLower(domain[i+1:])
+    if len(tld) != 2 || vanityTLDs[tld] { return "", false }
+    if tld == "uk" { return "GB", true }
+    return strings.ToUpper(tld), true
+}
+
+func contains(xs []string, s string) bool {
+    for _, x := range xs {
+        if x == s { return true }
+    }
+    return false
+}
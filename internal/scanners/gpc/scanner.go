@@ -0,0 +1,159 @@
+// Package gpc probes whether a site honors Global Privacy Control by comparing
+// responses with and without the Sec-GPC request header.
+package gpc
+
+import (
+    "context"
+    "encoding/json"
+    "net/url"
+    "sort"
+    "time"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/cookies"
+    "camille/internal/scanners/trackers"
+)
+
+const source = "gpc"
+
+// Status values for privacy.gpc.status.
+const (
+    StatusHonored = "honored"
+    StatusIgnored = "ignored"
+    StatusUnknown = "unknown" // no known tracker to suppress, or a request failed
+)
+
+// Snapshot summarizes one landing page response. Only known trackers count:
+// CDNs, font hosts and session cookies stay whatever GPC says, so they would
+// make every site look like it ignores the signal.
+type Snapshot struct {
+    GPC             bool     `json:"gpc"`
+    URL             string   `json:"url"`
+    StatusCode      int      `json:"status_code"`
+    Cookies         []string `json:"cookies"`
+    TrackingCookies []string `json:"tracking_cookies"` // known advertising or analytics cookies, or set by a tracker host
+    TrackerHosts    []string `json:"tracker_hosts"`    // hosts the tracker directory lists
+}
+
+// tracking is the volume of tracking a snapshot exposes.
+func (s Snapshot) tracking() int { return len(s.TrackingCookies) + len(s.TrackerHosts) }
+
+// Scanner emits privacy.gpc.* signals. Without a directory only cookies known by
+// name are counted.
+type Scanner struct {
+    Fetcher   ports.Fetcher
+    Directory ports.TrackerDirectory
+    Now       func() time.Time
+}
+
+func New(fetcher ports.Fetcher, dir ports.TrackerDirectory) *Scanner {
+    return &Scanner{Fetcher: fetcher, Directory: dir, Now: time.Now}
+}
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    add := func(code string, value any, severity string, confidence float64, refs ...string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, confidence, source, refs...))
+    }
+
+    declared, dev := s.declaration(ctx, t)
+    res.Evidence = append(res.Evidence, dev)
+    add("privacy.gpc.declared", declared, "info", 1, dev.Hash)
+
+    baseline, berr := s.snapshot(ctx, t, false)
+    withGPC, gerr := s.snapshot(ctx, t, true)
+    if berr != nil || gerr != nil {
+        if ctx.Err() != nil { return res, ctx.Err() }
+        add("privacy.gpc.status", StatusUnknown, "info", 0.3, dev.Hash)
+        return res, nil
+    }
+
+    raw, _ := json.Marshal([]Snapshot{baseline, withGPC})
+    sev := scanners.NewEvidence("gpc.probe", baseline.URL, raw, map[string]any{
+        "without_gpc": baseline,
+        "with_gpc":    withGPC,
+    })
+    res.Evidence = append(res.Evidence, sev)
+
+    cookieDelta := len(baseline.TrackingCookies) - len(withGPC.TrackingCookies)
+    hostDelta := len(baseline.TrackerHosts) - len(withGPC.TrackerHosts)
+    status, confidence := Classify(baseline, withGPC)
+    severity := "info"
+    if status == StatusIgnored { severity = "medium" }
+    add("privacy.gpc.status", status, severity, confidence, sev.Hash, dev.Hash)
+    add("privacy.gpc.cookies.delta", cookieDelta, "info", 1, sev.Hash)
+    add("privacy.gpc.trackers.delta", hostDelta, "info", 1, sev.Hash)
+    return res, nil
+}
+
+// Classify compares known tracking exposed without and with Sec-GPC. A baseline
+// without any is unknown, not a failure. Static responses only reveal server-side
+// behavior, so an unchanged page is reported with reduced confidence.
+func Classify(baseline, withGPC Snapshot) (string, float64) {
+    if baseline.tracking() == 0 { return StatusUnknown, 0.5 }
+    if withGPC.tracking() < baseline.tracking() { return StatusHonored, 0.8 }
+    return StatusIgnored, 0.6
+}
+
+func (s *Scanner) snapshot(ctx context.Context, t ports.ScanTarget, gpc bool) (Snapshot, error) {
+    req := ports.FetchRequest{URL: scanners.LandingURL(t)}
+    if gpc { req.Header = map[string][]string{"Sec-GPC": {"1"}} }
+    resp, err := s.Fetcher.Fetch(ctx, req)
+    if err != nil { return Snapshot{}, err }
+    snap := Snapshot{GPC: gpc, URL: resp.URL, StatusCode: resp.StatusCode, Cookies: []string{}, TrackingCookies: []string{}, TrackerHosts: []string{}}
+    for _, c := range cookies.Collect(resp.Hops, t.Domain, s.Now()) {
+        snap.Cookies = append(snap.Cookies, c.Name)
+        v, known := cookies.LookupVendor(c.Name)
+        if (known && v.Tracking()) || (c.ThirdParty && s.tracker(c.Domain)) {
+            snap.TrackingCookies = append(snap.TrackingCookies, c.Name)
+        }
+    }
+    base, err := url.Parse(resp.URL)
+    if err != nil { return snap, err }
+    hosts := map[string]bool{}
+    for _, r := range trackers.Census(base, resp.Body) {
+        if scanners.Registrable(r.Host) != t.Domain && s.tracker(r.Host) { hosts[r.Host] = true }
+    }
+    for h := range hosts { snap.TrackerHosts = append(snap.TrackerHosts, h) }
+    sort.Strings(snap.Cookies)
+    sort.Strings(snap.TrackingCookies)
+    sort.Strings(snap.TrackerHosts)
+    return snap, nil
+}
+
+// tracker reports whether the directory lists host.
+func (s *Scanner) tracker(host string) bool {
+    if s.Directory == nil { return false }
+    _, ok := s.Directory.Lookup(host)
+    return ok
+}
+
+// declaration fetches /.well-known/gpc.json; a {"gpc": true} body declares support.
+func (s *Scanner) declaration(ctx context.Context, t ports.ScanTarget) (bool, domain.Evidence) {
+    u := t.Origin() + "/.well-known/gpc.json"
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: u})
+    payload := map[string]any{"url": u}
+    var body []byte
+    declared := false
+    if err != nil {
+        payload["error"] = err.Error()
+    } else {
+        body = resp.Body
+        payload["status_code"] = resp.StatusCode
+        var doc struct {
+            GPC        bool   `json:"gpc"`
+            LastUpdate string `json:"lastUpdate"`
+        }
+        if resp.StatusCode == 200 && json.Unmarshal(resp.Body, &doc) == nil {
+            declared = doc.GPC
+            payload["gpc"] = doc.GPC
+            if doc.LastUpdate != "" { payload["last_update"] = doc.LastUpdate }
+        }
+        payload["snippet"] = scanners.Snippet(resp.Body, 512)
+    }
+    return declared, scanners.NewEvidence("gpc.json", u, append([]byte(u+"\n"), body...), payload)
+}
@@ -0,0 +1,94 @@
+// Package jurisdiction reads the locale hints a landing page gives away — the
+// country-code TLD, the document language, the currencies prices are shown in —
+// and the US state opt-out links, for inferring which privacy laws apply.
+package jurisdiction
+
+import (
+    "context"
+    "net/url"
+    "regexp"
+    "sort"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/geo"
+    "camille/internal/scanners/policy/discovery"
+)
+
+const source = "jurisdiction"
+
+// Scanner emits site.* locale signals from the landing page.
+type Scanner struct {
+    Fetcher ports.Fetcher
+}
+
+func New(fetcher ports.Fetcher) *Scanner { return &Scanner{Fetcher: fetcher} }
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: scanners.LandingURL(t)})
+    if err != nil { return res, err }
+    page := resp.Body
+
+    lang := discovery.Language(page)
+    currencies := Currencies(page)
+    tld, hasTLD := geo.TLDCountry(t.Domain)
+    var doNotSell, limitSensitive []string
+    if base, err := url.Parse(resp.URL); err == nil {
+        for _, l := range discovery.Links(base, page) {
+            if doNotSellText.MatchString(l.Text) { doNotSell = append(doNotSell, l.URL) }
+            if limitSensitiveText.MatchString(l.Text) { limitSensitive = append(limitSensitive, l.URL) }
+        }
+    }
+
+    ev := scanners.NewEvidence("site.locale", resp.URL, page, map[string]any{
+        "url":             resp.URL,
+        "language":        lang,
+        "tld_country":     tld,
+        "currencies":      currencies,
+        "do_not_sell":     doNotSell,
+        "limit_sensitive": limitSensitive,
+    })
+    res.Evidence = append(res.Evidence, ev)
+    add := func(code string, value any, confidence float64) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, "info", confidence, source, ev.Hash))
+    }
+
+    if hasTLD { add("site.tld", tld, 0.6) }
+    if lang != "" { add("site.language", lang, 0.8) }
+    add("site.currencies", currencies, 0.5)
+    add("site.link.do_not_sell", len(doNotSell) > 0, 0.8)
+    add("site.link.limit_sensitive", len(limitSensitive) > 0, 0.8)
+    return res, nil
+}
+
+// currencyMarks pairs price notations with ISO 4217 codes. Symbols only count
+// next to a digit so a stray "£" in prose is not a price.
+var currencyMarks = []struct {
+    code string
+    re   *regexp.Regexp
+}{
+    {"EUR", regexp.MustCompile(`€\s?\d|\d\s?€|\bEUR\s?\d|\d\s?EUR\b`)},
+    {"GBP", regexp.MustCompile(`£\s?\d|\bGBP\s?\d|\d\s?GBP\b`)},
+    {"BRL", regexp.MustCompile(`R\$\s?\d|\bBRL\s?\d|\d\s?BRL\b`)},
+    {"CAD", regexp.MustCompile(`\bCA\$\s?\d|\bC\$\s?\d|\bCAD\s?\d|\d\s?CAD\b`)},
+    {"AUD", regexp.MustCompile(`\bA\$\s?\d|\bAU\$\s?\d|\bAUD\s?\d|\d\s?AUD\b`)},
+    {"USD", regexp.MustCompile(`(?:^|[^A-Za-z$])\$\s?\d|\bUS\$\s?\d|\bUSD\s?\d|\d\s?USD\b`)},
+}
+
+// Currencies returns the sorted ISO codes of the currencies prices on page use.
+func Currencies(page []byte) []string {
+    out := []string{}
+    for _, c := range currencyMarks {
+        if c.re.Match(page) { out = append(out, c.code) }
+    }
+    sort.Strings(out)
+    return out
+}
+
+// doNotSellText matches the opt-out links CCPA/CPRA and the other US state laws require.
+var doNotSellText = regexp.MustCompile(`do not (?:sell|share)(?: or (?:sell|share))? my (?:personal )?(?:info|information|data)|your (?:california )?privacy choices|opt[- ]out of (?:the )?(?:sale|sharing)`)
+
+var limitSensitiveText = regexp.MustCompile(`limit (?:the )?use of my sensitive personal information`)
@@ -0,0 +1,87 @@
+package mailtransport
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// MaxPolicyAge is the RFC 8461 upper bound for max_age (one year).
+const MaxPolicyAge = 31557600
+
+// Policy is a parsed MTA-STS policy file (RFC 8461 §3.2).
+type Policy struct {
+    Version string
+    Mode    string // enforce|testing|none, or "invalid"
+    MX      []string
+    MaxAge  int
+    Errors  []string
+}
+
+// ParsePolicy reads key: value lines from the policy body.
+func ParsePolicy(body string) *Policy {
+    p := &Policy{Mode: "invalid"}
+    ageSeen := false
+    for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+        k, v, ok := strings.Cut(line, ":")
+        if !ok { continue }
+        v = strings.TrimSpace(v)
+        switch strings.TrimSpace(k) {
+        case "version":
+            p.Version = v
+        case "mode":
+            switch v {
+            case "enforce", "testing", "none":
+                p.Mode = v
+            }
+        case "mx":
+            p.MX = append(p.MX, strings.ToLower(strings.TrimSuffix(v, ".")))
+        case "max_age":
+            ageSeen = true
+            n, err := strconv.Atoi(v)
+            if err != nil || n < 0 || n > MaxPolicyAge {
+                p.Errors = append(p.Errors, fmt.Sprintf("max_age %q out of range", v))
+            } else {
+                p.MaxAge = n
+            }
+        }
+    }
+    if p.Version != "STSv1" { p.Errors = append(p.Errors, "version must be STSv1") }
+    if p.Mode == "invalid" { p.Errors = append(p.Errors, "mode missing or invalid") }
+    if p.Mode != "none" && len(p.MX) == 0 { p.Errors = append(p.Errors, "no mx patterns") }
+    if !ageSeen { p.Errors = append(p.Errors, "max_age missing") }
+    return p
+}
+
+// Matches reports whether host is covered by an mx pattern; "*." matches exactly one leftmost label.
+func (p *Policy) Matches(host string) bool {
+    host = strings.ToLower(strings.TrimSuffix(host, "."))
+    for _, pat := range p.MX {
+        if pat == host { return true }
+        if rest, ok := strings.CutPrefix(pat, "*."); ok {
+            label, parent, found := strings.Cut(host, ".")
+            if found && label != "" && parent == rest { return true }
+        }
+    }
+    return false
+}
+
+// Tags parses a "k=v; k=v" DNS record into lower-cased keys.
+func Tags(record string) map[string]string {
+    out := map[string]string{}
+    for _, part := range strings.Split(record, ";") {
+        k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+        if !ok { continue }
+        out[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+    }
+    return out
+}
+
+// findRecord returns TXT strings whose v= tag equals version.
+func findRecord(txts []string, version string) []string {
+    var out []string
+    for _, t := range txts {
+        if strings.EqualFold(Tags(t)["v"], version) { out = append(out, t) }
+    }
+    return out
+}
@@ -0,0 +1,39 @@
+package mailtransport
+
+import (
+    "fmt"
+    "testing"
+)
+
+func TestParsePolicyMaxAge(t *testing.T) {
+    const head = "version: STSv1\r\nmode: enforce\r\nmx: mail.example.com\r\n"
+    for _, tc := range []struct {
+        name   string
+        line   string
+        age    int
+        errors []string
+    }{
+        {"valid", "max_age: 604800", 604800, nil},
+        // RFC 8461 allows 0: the sender stops applying the policy at once.
+        {"zero", "max_age: 0", 0, nil},
+        {"missing", "", 0, []string{"max_age missing"}},
+        {"too large", fmt.Sprintf("max_age: %d", MaxPolicyAge+1), 0, []string{fmt.Sprintf("max_age %q out of range", fmt.Sprint(MaxPolicyAge+1))}},
+        {"negative", "max_age: -1", 0, []string{`max_age "-1" out of range`}},
+        {"not a number", "max_age: week", 0, []string{`max_age "week" out of range`}},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            p := ParsePolicy(head + tc.line)
+            if p.MaxAge != tc.age { t.Errorf("MaxAge = %d, want %d", p.MaxAge, tc.age) }
+            if fmt.Sprint(p.Errors) != fmt.Sprint(tc.errors) { t.Errorf("Errors = %q, want %q", p.Errors, tc.errors) }
+        })
+    }
+}
+
+func TestParsePolicyFields(t *testing.T) {
+    p := ParsePolicy("version: STSv1\nmode: testing\nmx: MX1.Example.com.\nmx: *.example.net\nmax_age: 86400\n")
+    if p.Mode != "testing" || len(p.Errors) != 0 { t.Fatalf("mode %s, errors %v", p.Mode, p.Errors) }
+    for host, want := range map[string]bool{"mx1.example.com": true, "a.example.net": true, "a.b.example.net": false, "example.net": false} {
+        if got := p.Matches(host); got != want { t.Errorf("Matches(%s) = %v, want %v", host, got, want) }
+    }
+    if p := ParsePolicy("version: STSv1\nmode: strict\nmax_age: 86400\n"); fmt.Sprint(p.Errors) != "[mode missing or invalid no mx patterns]" { t.Errorf("Errors = %q", p.Errors) }
+}
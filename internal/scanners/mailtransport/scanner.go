@@ -0,0 +1,151 @@
+// Package mailtransport checks inbound mail transport security: MTA-STS (RFC 8461),
+// SMTP TLS reporting (RFC 8460) and BIMI.
+package mailtransport
+
+import (
+    "bytes"
+    "context"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "mailtransport"
+
+// Scanner emits email.mta_sts.*, email.tls_rpt.* and email.bimi.* signals.
+type Scanner struct {
+    Resolver ports.Resolver
+    Fetcher  ports.Fetcher
+}
+
+func New(resolver ports.Resolver, fetcher ports.Fetcher) *Scanner {
+    return &Scanner{Resolver: resolver, Fetcher: fetcher}
+}
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    if err := s.mtaSTS(ctx, t.Domain, &res); err != nil { return res, err }
+    if err := s.tlsRPT(ctx, t.Domain, &res); err != nil { return res, err }
+    if err := s.bimi(ctx, t.Domain, &res); err != nil { return res, err }
+    return res, nil
+}
+
+func (s *Scanner) mtaSTS(ctx context.Context, name string, res *ports.ScanResult) error {
+    qname := "_mta-sts." + name
+    txts, err := s.Resolver.LookupTXT(ctx, qname)
+    if err != nil { return err }
+    recs := findRecord(txts, "STSv1")
+    ev := scanners.DNSEvidence(qname, "TXT", txts)
+    res.Evidence = append(res.Evidence, ev)
+    res.Signals = append(res.Signals, scanners.NewSignal("email.mta_sts.present", len(recs) > 0, scanners.SeverityIf(len(recs) == 0, "low"), 1, source, ev.Hash))
+    if len(recs) == 0 { return nil }
+
+    policyURL := "https://mta-sts." + name + "/.well-known/mta-sts.txt"
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: policyURL})
+    if err != nil && ctx.Err() != nil { return ctx.Err() }
+    // RFC 8461 §3.3: the policy must be served with 200 and no redirects.
+    fetched := err == nil && resp.StatusCode == 200 && len(resp.Hops) == 1
+    if !fetched {
+        res.Signals = append(res.Signals, scanners.NewSignal("email.mta_sts.policy.fetched", false, "medium", 1, source, ev.Hash))
+        return nil
+    }
+
+    p := ParsePolicy(string(resp.Body))
+    mxs, err := s.Resolver.LookupMX(ctx, name)
+    if err != nil { return err }
+    var mxHosts, unmatched []string
+    for _, mx := range mxs {
+        mxHosts = append(mxHosts, mx.Host)
+        if !p.Matches(mx.Host) { unmatched = append(unmatched, mx.Host) }
+    }
+    mxev := scanners.DNSEvidence(name, "MX", mxHosts)
+    pev := scanners.NewEvidence("mta-sts.policy", resp.URL, resp.Body, map[string]any{
+        "url":       resp.URL,
+        "snippet":   scanners.Snippet(resp.Body, 1024),
+        "mode":      p.Mode,
+        "mx":        p.MX,
+        "max_age":   p.MaxAge,
+        "errors":    p.Errors,
+        "unmatched": unmatched,
+    })
+    res.Evidence = append(res.Evidence, mxev, pev)
+
+    add := func(code string, value any, severity string, refs ...string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, 1, source, refs...))
+    }
+    add("email.mta_sts.policy.fetched", true, "info", pev.Hash)
+    add("email.mta_sts.valid", len(p.Errors) == 0, scanners.SeverityIf(len(p.Errors) > 0, "low"), ev.Hash, pev.Hash)
+    modeSeverity := "info"
+    if p.Mode != "enforce" { modeSeverity = "low" }
+    add("email.mta_sts.mode", p.Mode, modeSeverity, pev.Hash)
+    add("email.mta_sts.max_age", p.MaxAge, "info", pev.Hash)
+    if len(mxs) > 0 {
+        match := len(unmatched) == 0
+        add("email.mta_sts.mx.match", match, scanners.SeverityIf(!match, "medium"), pev.Hash, mxev.Hash)
+    }
+    return nil
+}
+
+func (s *Scanner) tlsRPT(ctx context.Context, name string, res *ports.ScanResult) error {
+    qname := "_smtp._tls." + name
+    txts, err := s.Resolver.LookupTXT(ctx, qname)
+    if err != nil { return err }
+    recs := findRecord(txts, "TLSRPTv1")
+    ev := scanners.DNSEvidence(qname, "TXT", txts)
+    res.Evidence = append(res.Evidence, ev)
+    res.Signals = append(res.Signals, scanners.NewSignal("email.tls_rpt.present", len(recs) > 0, scanners.SeverityIf(len(recs) == 0, "low"), 1, source, ev.Hash))
+    if len(recs) == 0 { return nil }
+
+    valid := len(recs) == 1
+    rua := Tags(recs[0])["rua"]
+    if rua == "" { valid = false }
+    for _, u := range strings.Split(rua, ",") {
+        u = strings.ToLower(strings.TrimSpace(u))
+        if !strings.HasPrefix(u, "mailto:") && !strings.HasPrefix(u, "https://") { valid = false }
+    }
+    res.Signals = append(res.Signals, scanners.NewSignal("email.tls_rpt.valid", valid, scanners.SeverityIf(!valid, "low"), 1, source, ev.Hash))
+    return nil
+}
+
+func (s *Scanner) bimi(ctx context.Context, name string, res *ports.ScanResult) error {
+    qname := "default._bimi." + name
+    txts, err := s.Resolver.LookupTXT(ctx, qname)
+    if err != nil { return err }
+    recs := findRecord(txts, "BIMI1")
+    ev := scanners.DNSEvidence(qname, "TXT", txts)
+    res.Evidence = append(res.Evidence, ev)
+    res.Signals = append(res.Signals, scanners.NewSignal("email.bimi.present", len(recs) > 0, "info", 1, source, ev.Hash))
+    if len(recs) == 0 { return nil }
+
+    tags := Tags(recs[0])
+    res.Signals = append(res.Signals, scanners.NewSignal("email.bimi.vmc.present", tags["a"] != "", "info", 1, source, ev.Hash))
+    logo := tags["l"]
+    if logo == "" { return nil }
+    if !strings.HasPrefix(strings.ToLower(logo), "https://") {
+        res.Signals = append(res.Signals, scanners.NewSignal("email.bimi.logo.reachable", false, "low", 1, source, ev.Hash))
+        return nil
+    }
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: logo})
+    if err != nil && ctx.Err() != nil { return ctx.Err() }
+    svg := err == nil && resp.StatusCode == 200 && bytes.Contains(bytes.ToLower(resp.Body), []byte("<svg"))
+    if !svg {
+        res.Signals = append(res.Signals, scanners.NewSignal("email.bimi.logo.reachable", false, "low", 1, source, ev.Hash))
+        return nil
+    }
+    tinyPS := bytes.Contains(resp.Body, []byte(`baseProfile="tiny-ps"`)) || bytes.Contains(resp.Body, []byte(`baseProfile='tiny-ps'`))
+    lev := scanners.NewEvidence("bimi.logo", resp.URL, resp.Body, map[string]any{
+        "url":     resp.URL,
+        "bytes":   len(resp.Body),
+        "tiny_ps": tinyPS,
+        "snippet": scanners.Snippet(resp.Body, 512),
+    })
+    res.Evidence = append(res.Evidence, lev)
+    res.Signals = append(res.Signals,
+        scanners.NewSignal("email.bimi.logo.reachable", true, "info", 1, source, ev.Hash, lev.Hash),
+        scanners.NewSignal("email.bimi.logo.tiny_ps", tinyPS, scanners.SeverityIf(!tinyPS, "low"), 1, source, lev.Hash),
+    )
+    return nil
+}
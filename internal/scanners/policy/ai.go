@@ -0,0 +1,258 @@
+package policy
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+    "sort"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/policy/chunks"
+    "camille/internal/scanners/policy/quotes"
+    "camille/internal/scanners/policy/rules"
+)
+
+// maxAIChunks caps extractor calls per document; perCategory is how many of the
+// best-ranked chunks each category is routed to.
+const (
+    maxAIChunks = 8
+    perCategory = 2
+)
+
+// aiCodes maps extractor categories to the signal codes they support.
+var aiCodes = map[string]string{
+    ports.AIDataSale:             "policy.data.sale.present",
+    ports.AIDataSharing:          "policy.data.sharing.third_parties",
+    ports.AITraining:             "policy.ai.training.userdata",
+    ports.AIRetentionSpecified:   "policy.data.storage.retention.specified",
+    ports.AIRetentionIndefinite:  "policy.data.storage.retention.indefinite",
+    ports.AIDeletionEmail:        "policy.user.rights.deletion.channel.email_present",
+    ports.AIChildrenRestrictions: "policy.children.restrictions.stated",
+}
+
+// aiQueries are the BM25 query sets that route chunks to extractor categories.
+var aiQueries = map[string]string{
+    ports.AIDataSale:             "sell sale sold personal information data brokers do not sell or share opt out valuable consideration",
+    ports.AIDataSharing:          "share disclose third parties partners service providers affiliates advertisers vendors recipients",
+    ports.AITraining:             "train training machine learning artificial intelligence AI models improve develop algorithms large language",
+    ports.AIRetentionSpecified:   "retain retention period days months years keep store delete after account closure",
+    ports.AIRetentionIndefinite:  "retain retention indefinitely permanently no fixed period as long as necessary keep",
+    ports.AIDeletionEmail:        "delete deletion erasure request rights contact email exercise data subject access",
+    ports.AIChildrenRestrictions: "children child minors under age 13 16 parental consent kids years old",
+}
+
+// aiLocalQueries extend aiQueries for documents in other languages; English terms
+// stay in the query because translated policies often keep some.
+var aiLocalQueries = map[string]map[string]string{
+    "de": {
+        ports.AIDataSale:             "verkaufen verkauf verkauft personenbezogene daten datenhändler gegenleistung",
+        ports.AIDataSharing:          "weitergabe weitergeben übermitteln offenlegen dritte empfänger partner dienstleister auftragsverarbeiter",
+        ports.AITraining:             "trainieren training maschinelles lernen künstliche intelligenz ki modelle",
+        ports.AIRetentionSpecified:   "speicherdauer aufbewahrung speichern gespeichert tage monate jahre löschen gelöscht",
+        ports.AIRetentionIndefinite:  "unbegrenzt unbefristet dauerhaft solange erforderlich speichern aufbewahrung",
+        ports.AIDeletionEmail:        "löschung löschen recht betroffenenrechte auskunft e-mail kontakt geltend machen",
+        ports.AIChildrenRestrictions: "kinder minderjährige alter jahre einwilligung eltern erziehungsberechtigten",
+    },
+    "fr": {
+        ports.AIDataSale:             "vendre vente vendons données personnelles courtiers contrepartie",
+        ports.AIDataSharing:          "partager partageons communiquer transmettre destinataires tiers partenaires prestataires sous-traitants",
+        ports.AITraining:             "entraîner entraînement apprentissage automatique intelligence artificielle ia modèles",
+        ports.AIRetentionSpecified:   "durée conservation conserver conservées jours mois ans supprimées",
+        ports.AIRetentionIndefinite:  "indéfiniment illimitée permanente aussi longtemps nécessaire conserver conservation",
+        ports.AIDeletionEmail:        "effacement suppression supprimer droits exercer accès e-mail contact",
+        ports.AIChildrenRestrictions: "enfants mineurs âge ans consentement parental parents",
+    },
+    "es": {
+        ports.AIDataSale:             "vender venta vendemos datos personales corredores contraprestación",
+        ports.AIDataSharing:          "compartir compartimos comunicar ceder cesiones destinatarios terceros socios proveedores encargados",
+        ports.AITraining:             "entrenar entrenamiento aprendizaje automático inteligencia artificial ia modelos",
+        ports.AIRetentionSpecified:   "plazo conservación conservar conservamos días meses años suprimir eliminar",
+        ports.AIRetentionIndefinite:  "indefinidamente permanente tiempo indefinido necesario conservar conservación",
+        ports.AIDeletionEmail:        "supresión eliminación derechos ejercer acceso correo electrónico contacto",
+        ports.AIChildrenRestrictions: "niños menores edad años consentimiento padres tutores",
+    },
+    "nl": {
+        ports.AIDataSale:             "verkopen verkoop verkocht persoonsgegevens datahandelaren tegenprestatie",
+        ports.AIDataSharing:          "delen gedeeld verstrekken doorgeven ontvangers derden partners dienstverleners verwerkers",
+        ports.AITraining:             "trainen training machine learning kunstmatige intelligentie ai modellen",
+        ports.AIRetentionSpecified:   "bewaartermijn bewaren bewaard dagen maanden jaren verwijderen verwijderd",
+        ports.AIRetentionIndefinite:  "onbeperkt onbepaalde tijd permanent zolang noodzakelijk bewaren",
+        ports.AIDeletionEmail:        "verwijdering verwijderen rechten uitoefenen inzage e-mail contact",
+        ports.AIChildrenRestrictions: "kinderen minderjarigen leeftijd jaar toestemming ouders voogd",
+    },
+    "it": {
+        ports.AIDataSale:             "vendere vendita vendiamo dati personali intermediari corrispettivo",
+        ports.AIDataSharing:          "condividere condividiamo comunicare trasmettere destinatari terzi partner fornitori responsabili",
+        ports.AITraining:             "addestrare addestramento apprendimento automatico intelligenza artificiale ia modelli",
+        ports.AIRetentionSpecified:   "conservazione conservare conserviamo giorni mesi anni cancellati periodo",
+        ports.AIRetentionIndefinite:  "indefinitamente illimitato permanente tempo necessario conservare conservazione",
+        ports.AIDeletionEmail:        "cancellazione cancellare diritti esercitare accesso email contatto",
+        ports.AIChildrenRestrictions: "bambini minori età anni consenso genitori tutore",
+    },
+    "pt": {
+        ports.AIDataSale:             "vender venda vendemos dados pessoais corretores contrapartida",
+        ports.AIDataSharing:          "compartilhar compartilhamos partilhar divulgar destinatários terceiros parceiros fornecedores operadores",
+        ports.AITraining:             "treinar treinamento aprendizado automático inteligência artificial ia modelos",
+        ports.AIRetentionSpecified:   "retenção armazenamento armazenar conservar dias meses anos eliminar excluir",
+        ports.AIRetentionIndefinite:  "indefinidamente permanente prazo indeterminado necessário armazenar conservar",
+        ports.AIDeletionEmail:        "eliminação exclusão direitos exercer titular acesso e-mail contato",
+        ports.AIChildrenRestrictions: "crianças menores idade anos consentimento pais responsável",
+    },
+}
+
+// aiOutput is one extractor call: its evidence row and the facts located in the document.
+type aiOutput struct {
+    Evidence domain.Evidence
+    Facts    []rules.Fact
+}
+
+// chunk is a section range sent to the extractor in one call, with the categories
+// it was routed to and their BM25 scores.
+type chunk struct {
+    chunks.Chunk
+    Categories []string
+    Scores     map[string]float64
+}
+
+// extractAI sends a document's chunks to the extractor while sp allows. Errors
+// soft-fail per chunk.
+func (s *Scanner) extractAI(ctx context.Context, d policyDoc, sp *spend) []aiOutput {
+    info := s.AI.Info()
+    var out []aiOutput
+    for _, c := range s.route(d.Doc) {
+        if ctx.Err() != nil { break }
+        meter := sp.meter(ctx, c.Tokens+aiOverhead)
+        if !meter.Allow() {
+            log.Printf("policy: %s ai budget exhausted at %s, continuing with rules only", sp.exceeded, c.SectionURL)
+            break
+        }
+        content := d.Doc.Text[c.Start:c.End]
+        got, usage, err := s.AI.ExtractFacts(ctx, ports.PolicyDoc{
+            Content: content, SourceURL: d.Doc.URL, SectionURL: c.SectionURL, Language: d.Doc.Language, Categories: c.Categories,
+            Meter: meter,
+        })
+        // Only cache hits come back without a provider call; the meter recorded the rest.
+        cached := err == nil && usage.Calls == 0
+        if cached {
+            usage = sp.record(ctx, info, usage, true)
+        } else {
+            usage = meter.used
+        }
+        if err != nil {
+            log.Printf("policy: ai extraction %s: %v", c.SectionURL, err)
+            continue
+        }
+        var facts []rules.Fact
+        var dropped []ports.Fact
+        fuzzy := 0
+        for _, f := range got {
+            rf, ok := s.locate(d, c, f)
+            if !ok {
+                dropped = append(dropped, f)
+                continue
+            }
+            if rf.Similarity < 1 || rf.Quote != f.Quote { fuzzy++ }
+            facts = append(facts, rf)
+        }
+        raw, _ := json.Marshal(map[string]any{"model": info, "chunk": content, "facts": got})
+        ev := scanners.NewEvidence("policy.ai", c.SectionURL, raw, map[string]any{
+            "section_url": c.SectionURL,
+            "heading":     c.Heading,
+            "path":        c.Path,
+            "chunk_hash":  scanners.Hash([]byte(content)),
+            "span_start":  c.Start,
+            "span_end":    c.End,
+            "tokens":      c.Tokens,
+            "categories":  c.Categories,
+            "scores":      c.Scores,
+            "facts":       facts,
+            "dropped":     dropped,
+        })
+        ev.Meta = map[string]any{
+            "provider": info.Provider, "model": info.Model, "prompt_version": info.PromptVersion, "prompt_hash": info.PromptHash,
+            "prompt_tokens": usage.PromptTokens, "completion_tokens": usage.CompletionTokens, "cost_usd": usage.CostUSD,
+        }
+        if cached {
+            ev.Meta["cached"] = true
+        } else {
+            // Quote counts feed the ai_quote_stats view, which tracks hallucination rates per model
+            // and prompt; cache hits are left out so repeat scans do not count twice.
+            ev.Meta["quotes_verified"], ev.Meta["quotes_fuzzy"], ev.Meta["quotes_dropped"] = len(facts), fuzzy, len(dropped)
+        }
+        out = append(out, aiOutput{Evidence: ev, Facts: facts})
+    }
+    return out
+}
+
+// locate verifies an extracted quote against its chunk. The fact takes the source
+// text at the matched offsets as its quote; quotes below the similarity threshold
+// are treated as hallucinated and dropped.
+func (s *Scanner) locate(d policyDoc, c chunk, f ports.Fact) (rules.Fact, bool) {
+    code, ok := aiCodes[f.Category]
+    if !ok { return rules.Fact{}, false }
+    threshold := s.QuoteThreshold
+    if threshold == 0 { threshold = quotes.DefaultThreshold }
+    m, ok := quotes.Find(d.Doc.Text[c.Start:c.End], f.Quote, threshold)
+    if !ok { return rules.Fact{}, false }
+    start, end := c.Start+m.Start, c.Start+m.End
+    rf := rules.Fact{
+        RuleID: aiRulePrefix + f.Category, Code: code, Value: f.Value, Severity: s.severityFor(code),
+        Confidence: f.Confidence * m.Similarity, Quote: d.Doc.Text[start:end], SpanStart: start, SpanEnd: end,
+        Match: f.Quote, Similarity: m.Similarity, DocType: d.Type, SectionURL: f.SectionURL,
+    }
+    if sec, ok := d.Doc.SectionAt(start); ok {
+        rf.Section = sec.Heading
+        rf.SectionURL = d.Doc.SectionURL(sec)
+    }
+    return rf, true
+}
+
+// route splits a document along its headings and sends each category to the chunks
+// BM25 ranks highest for it, keeping the strongest maxAIChunks in document order.
+// Documents in a language with local terms are queried in it too. When no chunk
+// matches any query the leading chunks go out with every category.
+func (s *Scanner) route(doc ports.Document) []chunk {
+    split := chunks.Split(doc, s.Chunking)
+    if len(split) == 0 { return nil }
+    texts := make([]string, len(split))
+    for i, c := range split { texts[i] = doc.Text[c.Start:c.End] }
+    ix := chunks.NewIndex(texts)
+
+    routed := map[int]*chunk{}
+    best := map[int]float64{}
+    for _, cat := range ports.AICategories {
+        query := aiQueries[cat.Name]
+        if local, ok := aiLocalQueries[doc.Language][cat.Name]; ok { query += " " + local }
+        for _, r := range ix.Top(query, perCategory) {
+            c := routed[r.Chunk]
+            if c == nil {
+                c = &chunk{Chunk: split[r.Chunk], Scores: map[string]float64{}}
+                routed[r.Chunk] = c
+            }
+            c.Categories = append(c.Categories, cat.Name)
+            c.Scores[cat.Name] = r.Score
+            if r.Score > best[r.Chunk] { best[r.Chunk] = r.Score }
+        }
+    }
+    if len(routed) == 0 {
+        var out []chunk
+        for _, c := range split {
+            if len(out) == maxAIChunks { break }
+            out = append(out, chunk{Chunk: c})
+        }
+        return out
+    }
+    keep := make([]int, 0, len(routed))
+    for i := range routed { keep = append(keep, i) }
+    sort.Slice(keep, func(a, b int) bool {
+        if best[keep[a]] != best[keep[b]] { return best[keep[a]] > best[keep[b]] }
+        return keep[a] < keep[b]
+    })
+    if len(keep) > maxAIChunks { keep = keep[:maxAIChunks] }
+    sort.Ints(keep)
+    out := make([]chunk, 0, len(keep))
+    for _, i := range keep { out = append(out, *routed[i]) }
+    return out
+}
@@ -0,0 +1,116 @@
+package policy
+
+import (
+    "context"
+    "log"
+    "time"
+
+    "camille/internal/ports"
+)
+
+// aiOverhead approximates the prompt and reply tokens a call adds to its chunk.
+const aiOverhead = 700
+
+// Budget caps AI spend per scan, per tenant per UTC day and across all tenants per
+// UTC day; zero limits are unlimited. Tenants are self-declared by submitters, so
+// only the global cap bounds total spend. Every provider request, retries and
+// shadow runs included, is checked before it is sent; once one would cross a
+// limit the rest of the scan runs on the rule catalog alone. Daily totals are read
+// once per scan, so concurrent scans can overshoot by what they spend meanwhile.
+type Budget struct {
+    Usage             ports.AIUsageRepository // optional: persists calls and supplies daily totals
+    Pricing           ports.AIPricing
+    ScanTokens        int
+    ScanUSD           float64
+    DailyTokens       int
+    DailyUSD          float64
+    GlobalDailyTokens int
+    GlobalDailyUSD    float64
+}
+
+// Budget limits that stopped AI extraction.
+const (
+    budgetScan   = "scan"
+    budgetDaily  = "daily"
+    budgetGlobal = "global_daily"
+)
+
+// spend tracks one scan against a Budget.
+type spend struct {
+    b        Budget
+    t        ports.ScanTarget
+    scan     ports.AIUsage
+    day      ports.AIUsage // the tenant's
+    all      ports.AIUsage // every tenant's
+    exceeded string
+}
+
+func (s *Scanner) startSpend(ctx context.Context, t ports.ScanTarget) *spend {
+    sp := &spend{t: t}
+    if s.Budget != nil { sp.b = *s.Budget }
+    if sp.b.Usage != nil && (sp.b.DailyTokens > 0 || sp.b.DailyUSD > 0) {
+        day, err := sp.b.Usage.TenantAIUsage(ctx, t.Tenant, time.Now().UTC().Truncate(24*time.Hour))
+        if err != nil { log.Printf("policy: ai usage for tenant %q: %v", t.Tenant, err) }
+        sp.day = day
+    }
+    if sp.b.Usage != nil && (sp.b.GlobalDailyTokens > 0 || sp.b.GlobalDailyUSD > 0) {
+        totals, err := sp.b.Usage.AIUsageTotals(ctx, ports.AIUsageFilter{Since: time.Now().UTC().Truncate(24 * time.Hour)})
+        if err != nil { log.Printf("policy: ai usage for all tenants: %v", err) }
+        for _, t := range totals { sp.all.Add(t.AIUsage) }
+    }
+    return sp
+}
+
+// allow reports whether a call of about tokens fits the remaining budget; once it
+// does not, every later call is refused too.
+func (sp *spend) allow(tokens int) bool {
+    if sp.exceeded != "" { return false }
+    next := ports.AIUsage{PromptTokens: tokens}
+    cost := sp.b.Pricing.Cost(next)
+    switch {
+    case over(sp.scan.Tokens()+tokens, sp.b.ScanTokens) || overUSD(sp.scan.CostUSD+cost, sp.b.ScanUSD):
+        sp.exceeded = budgetScan
+    case over(sp.day.Tokens()+tokens, sp.b.DailyTokens) || overUSD(sp.day.CostUSD+cost, sp.b.DailyUSD):
+        sp.exceeded = budgetDaily
+    case over(sp.all.Tokens()+tokens, sp.b.GlobalDailyTokens) || overUSD(sp.all.CostUSD+cost, sp.b.GlobalDailyUSD):
+        sp.exceeded = budgetGlobal
+    }
+    return sp.exceeded == ""
+}
+
+// record prices a call, counts it against the scan and the tenant's day and stores it.
+func (sp *spend) record(ctx context.Context, info ports.AIModelInfo, u ports.AIUsage, cached bool) ports.AIUsage {
+    u.CostUSD = sp.b.Pricing.Cost(u)
+    if u.Calls == 0 { u.Calls = 1 }
+    sp.scan.Add(u)
+    sp.day.Add(u)
+    sp.all.Add(u)
+    if sp.b.Usage != nil {
+        rec := ports.AIUsageRecord{ScanID: sp.t.ScanID, Tenant: sp.t.Tenant, AIModelInfo: info, AIUsage: u, Cached: cached}
+        if err := sp.b.Usage.RecordAIUsage(ctx, rec); err != nil { log.Printf("policy: record ai usage: %v", err) }
+    }
+    return u
+}
+
+// chunkMeter is the ports.AIMeter for one chunk's extraction: each request must
+// fit the remaining budget at the chunk's estimate and is recorded once made.
+type chunkMeter struct {
+    ctx    context.Context
+    sp     *spend
+    tokens int
+    used   ports.AIUsage
+}
+
+func (sp *spend) meter(ctx context.Context, tokens int) *chunkMeter {
+    return &chunkMeter{ctx: ctx, sp: sp, tokens: tokens}
+}
+
+func (m *chunkMeter) Allow() bool { return m.sp.allow(m.tokens) }
+
+func (m *chunkMeter) Charge(info ports.AIModelInfo, u ports.AIUsage) {
+    m.used.Add(m.sp.record(m.ctx, info, u, false))
+}
+
+func over(n, limit int) bool { return limit > 0 && n > limit }
+
+func overUSD(v, limit float64) bool { return limit > 0 && v > limit }
@@ -0,0 +1,117 @@
+package policy
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/chunks"
+    "camille/internal/scanners/policy/diff"
+    "camille/internal/scanners/policy/rules"
+)
+
+// minQueryTerms is how many distinct terms of a category's routing query a changed
+// clause needs to count as touching the category without a rule hit.
+const minQueryTerms = 3
+
+// queryTerms holds the stemmed term set of each routing query.
+var queryTerms = sync.OnceValue(func() map[string]map[string]bool {
+    out := map[string]map[string]bool{}
+    for cat, q := range aiQueries {
+        out[cat] = map[string]bool{}
+        for _, t := range chunks.Terms(q) { out[cat][t] = true }
+    }
+    return out
+})
+
+// Compare diffs two versions of a policy document of type typ and classifies each
+// changed clause by the rule signals its text yields and the extraction categories
+// it touches. SignalChanges runs the catalog over both whole documents, so it also
+// catches changes whose meaning depends on surrounding clauses.
+func Compare(catalog *rules.Catalog, typ string, from, to ports.PolicyVersion) ports.PolicyDiff {
+    a, b := from.Document(), to.Document()
+    res := diff.Compare(a, b)
+    out := ports.PolicyDiff{From: from, To: to, Changes: res.Changes, Categories: []string{}}
+    out.Summary.SectionsAdded, out.Summary.SectionsRemoved = res.SectionsAdded, res.SectionsRemoved
+    touched := map[string]bool{}
+    for i := range out.Changes {
+        c := &out.Changes[i]
+        switch c.Kind {
+        case ports.ChangeAdded:
+            out.Summary.Added++
+        case ports.ChangeRemoved:
+            out.Summary.Removed++
+        case ports.ChangeModified:
+            out.Summary.Modified++
+        }
+        codes := map[string]bool{}
+        if catalog != nil {
+            for _, f := range catalog.Apply(ports.Document{Text: c.From, Language: a.Language}, typ) { codes[f.Code] = true }
+            for _, f := range catalog.Apply(ports.Document{Text: c.To, Language: b.Language}, typ) { codes[f.Code] = true }
+        }
+        c.Signals = keys(codes)
+        c.Categories = categorize(c.Signals, c.From+"\n"+c.To)
+        for _, cat := range c.Categories { touched[cat] = true }
+    }
+    out.Categories = keys(touched)
+    if catalog != nil { out.SignalChanges = signalChanges(catalog, typ, a, b) }
+    return out
+}
+
+// categorize maps rule codes back to their extraction categories and adds the
+// categories whose routing query text shares at least minQueryTerms terms.
+func categorize(codes []string, text string) []string {
+    cats := map[string]bool{}
+    for cat, code := range aiCodes {
+        if containsCode(codes, code) { cats[cat] = true }
+    }
+    terms := map[string]bool{}
+    for _, t := range chunks.Terms(text) { terms[t] = true }
+    for cat, q := range queryTerms() {
+        n := 0
+        for t := range q {
+            if terms[t] { n++ }
+        }
+        if n >= minQueryTerms { cats[cat] = true }
+    }
+    return keys(cats)
+}
+
+// signalChanges compares the most confident fact per code in each document; in a
+// privacy policy a code with no fact takes the catalog's absent value, if any.
+func signalChanges(catalog *rules.Catalog, typ string, a, b ports.Document) []ports.PolicySignalChange {
+    before, after := bestValues(catalog, typ, a), bestValues(catalog, typ, b)
+    out := []ports.PolicySignalChange{}
+    for _, code := range catalog.Codes() {
+        x, okx := before[code]
+        y, oky := after[code]
+        if !okx && !oky { continue }
+        if okx && oky && fmt.Sprint(x) == fmt.Sprint(y) { continue }
+        out = append(out, ports.PolicySignalChange{Code: code, From: x, To: y})
+    }
+    return out
+}
+
+func bestValues(catalog *rules.Catalog, typ string, doc ports.Document) map[string]any {
+    out := map[string]any{}
+    if doc.Text == "" { return out }
+    best := map[string]rules.Fact{}
+    for _, f := range catalog.Apply(doc, typ) {
+        if cur, ok := best[f.Code]; !ok || f.Confidence > cur.Confidence { best[f.Code] = f }
+    }
+    for code, f := range best { out[code] = f.Value }
+    if typ != domain.PolicyPrivacy { return out }
+    for code, v := range catalog.Absent {
+        if _, ok := out[code]; !ok { out[code] = v }
+    }
+    return out
+}
+
+func keys(set map[string]bool) []string {
+    out := make([]string, 0, len(set))
+    for k := range set { out = append(out, k) }
+    sort.Strings(out)
+    return out
+}
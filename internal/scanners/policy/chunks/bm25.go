@@ -0,0 +1,110 @@
+package chunks
+
+import (
+    "math"
+    "sort"
+    "strings"
+    "unicode"
+)
+
+// BM25 parameters (Robertson/Spärck Jones defaults).
+const (
+    k1 = 1.2
+    b  = 0.75
+)
+
+// Index is a BM25 index over one document's chunks.
+type Index struct {
+    tf   []map[string]int
+    lens []int
+    df   map[string]int
+    avg  float64
+}
+
+// NewIndex indexes texts; scores refer to them by position.
+func NewIndex(texts []string) *Index {
+    ix := &Index{df: map[string]int{}}
+    total := 0
+    for _, t := range texts {
+        tf := map[string]int{}
+        terms := Terms(t)
+        for _, term := range terms { tf[term]++ }
+        for term := range tf { ix.df[term]++ }
+        ix.tf = append(ix.tf, tf)
+        ix.lens = append(ix.lens, len(terms))
+        total += len(terms)
+    }
+    if len(texts) > 0 { ix.avg = float64(total) / float64(len(texts)) }
+    return ix
+}
+
+// Score returns the BM25 score of every indexed text for query.
+func (ix *Index) Score(query string) []float64 {
+    out := make([]float64, len(ix.tf))
+    n := float64(len(ix.tf))
+    seen := map[string]bool{}
+    for _, term := range Terms(query) {
+        if seen[term] { continue }
+        seen[term] = true
+        df := float64(ix.df[term])
+        if df == 0 { continue }
+        idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+        for i, tf := range ix.tf {
+            f := float64(tf[term])
+            if f == 0 { continue }
+            out[i] += idf * f * (k1 + 1) / (f + k1*(1-b+b*float64(ix.lens[i])/ix.avg))
+        }
+    }
+    return out
+}
+
+// Ranked is a chunk's position and score for one query.
+type Ranked struct {
+    Chunk int
+    Score float64
+}
+
+// Top returns up to k texts scoring above zero, best first; ties keep document order.
+func (ix *Index) Top(query string, k int) []Ranked {
+    var out []Ranked
+    for i, s := range ix.Score(query) {
+        if s > 0 { out = append(out, Ranked{Chunk: i, Score: s}) }
+    }
+    sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+    if len(out) > k { out = out[:k] }
+    return out
+}
+
+// stopwords are dropped from queries and chunks alike.
+var stopwords = map[string]bool{
+    "a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+    "for": true, "from": true, "has": true, "have": true, "in": true, "is": true, "it": true, "its": true,
+    "of": true, "on": true, "or": true, "our": true, "that": true, "the": true, "this": true, "to": true,
+    "us": true, "we": true, "with": true, "you": true, "your": true, "may": true, "will": true,
+}
+
+// Terms lower-cases s, splits it on non-alphanumerics, drops stopwords and strips
+// common English suffixes so "shares", "shared" and "sharing" meet at "shar".
+func Terms(s string) []string {
+    var out []string
+    for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+        if stopwords[w] { continue }
+        out = append(out, stem(w))
+    }
+    return out
+}
+
+func stem(w string) string {
+    switch {
+    case len(w) > 5 && strings.HasSuffix(w, "ies"):
+        w = w[:len(w)-3] + "y"
+    case len(w) > 5 && strings.HasSuffix(w, "ing"):
+        w = w[:len(w)-3]
+    case len(w) > 4 && strings.HasSuffix(w, "ed"):
+        w = w[:len(w)-2]
+    case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+        w = w[:len(w)-1]
+    }
+    if len(w) > 3 && strings.HasSuffix(w, "e") { w = w[:len(w)-1] }
+    return w
+}
@@ -0,0 +1,143 @@
+// Package chunks splits extracted policy documents into extractor-sized pieces
+// along their heading tree and ranks the pieces per extraction category, so only
+// the sections likely to hold a fact are sent to a (costly) model.
+package chunks
+
+import (
+    "strings"
+    "unicode/utf8"
+
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/textutil"
+)
+
+// Options bounds chunk size in estimated tokens.
+type Options struct {
+    MaxTokens int // hard cap per chunk
+    Overlap   int // tokens repeated between windows of an oversized section
+    MinTokens int // smaller neighbouring chunks are merged
+}
+
+// DefaultOptions suits 8k-context models with room for the prompt and reply.
+var DefaultOptions = Options{MaxTokens: 1200, Overlap: 80, MinTokens: 120}
+
+// Chunk is a byte range of Document.Text. Heading and SectionURL come from the
+// section the chunk starts in; Path lists the headings above it, outermost first.
+type Chunk struct {
+    Start      int      `json:"start"`
+    End        int      `json:"end"`
+    Heading    string   `json:"heading,omitempty"`
+    Path       []string `json:"path,omitempty"`
+    SectionURL string   `json:"section_url"`
+    Tokens     int      `json:"tokens"`
+}
+
+// Tokens estimates the token count of s (about four bytes of English per token).
+func Tokens(s string) int { return (utf8.RuneCountInString(s) + 3) / 4 }
+
+// Split walks doc's heading tree: a section within the budget is one chunk,
+// a larger one yields its own lead text and then its subsections, and a leaf
+// still over budget is cut into overlapping windows at sentence boundaries.
+func Split(doc ports.Document, opt Options) []Chunk {
+    if opt.MaxTokens <= 0 { opt = DefaultOptions }
+    if opt.Overlap >= opt.MaxTokens/2 { opt.Overlap = opt.MaxTokens / 4 }
+    s := splitter{doc: doc, opt: opt, children: map[int][]int{}}
+    var roots []int
+    for i, sec := range doc.Sections {
+        if sec.Parent < 0 { roots = append(roots, i) } else { s.children[sec.Parent] = append(s.children[sec.Parent], i) }
+    }
+    lead := len(doc.Text)
+    if len(roots) > 0 { lead = doc.Sections[roots[0]].Start }
+    s.emit(0, lead, -1)
+    for _, i := range roots { s.section(i) }
+    return s.merge()
+}
+
+type splitter struct {
+    doc      ports.Document
+    opt      Options
+    children map[int][]int
+    out      []Chunk
+}
+
+func (s *splitter) section(i int) {
+    sec := s.doc.Sections[i]
+    kids := s.children[i]
+    if Tokens(s.doc.Text[sec.Start:sec.End]) <= s.opt.MaxTokens || len(kids) == 0 {
+        s.emit(sec.Start, sec.End, i)
+        return
+    }
+    s.emit(sec.Start, s.doc.Sections[kids[0]].Start, i)
+    for _, k := range kids { s.section(k) }
+}
+
+// emit adds text[start:end] under section sec (-1 for the preamble), windowing it
+// when it exceeds the budget.
+func (s *splitter) emit(start, end, sec int) {
+    text := s.doc.Text
+    for start < end && textutil.IsSpace(text[start]) { start++ }
+    for end > start && textutil.IsSpace(text[end-1]) { end-- }
+    if start >= end { return }
+    maxBytes := s.opt.MaxTokens * 4
+    for {
+        if Tokens(text[start:end]) <= s.opt.MaxTokens {
+            s.out = append(s.out, s.chunk(start, end, sec))
+            return
+        }
+        cut := boundary(text, start, start+maxBytes)
+        s.out = append(s.out, s.chunk(start, cut, sec))
+        next := wordStart(text, cut-s.opt.Overlap*4, cut)
+        if next <= start { next = cut }
+        start = next
+    }
+}
+
+func (s *splitter) chunk(start, end, sec int) Chunk {
+    c := Chunk{Start: start, End: end, SectionURL: s.doc.URL, Tokens: Tokens(s.doc.Text[start:end])}
+    if sec < 0 { return c }
+    c.Heading = s.doc.Sections[sec].Heading
+    c.SectionURL = s.doc.SectionURL(s.doc.Sections[sec])
+    for p := s.doc.Sections[sec].Parent; p >= 0; p = s.doc.Sections[p].Parent {
+        c.Path = append([]string{s.doc.Sections[p].Heading}, c.Path...)
+    }
+    return c
+}
+
+// merge folds chunks under MinTokens into the following chunk when the two are
+// contiguous and fit the budget together; the merged chunk keeps the first heading.
+func (s *splitter) merge() []Chunk {
+    var out []Chunk
+    for _, c := range s.out {
+        if n := len(out); n > 0 {
+            prev := &out[n-1]
+            if prev.Tokens < s.opt.MinTokens && prev.End <= c.Start && strings.TrimSpace(s.doc.Text[prev.End:c.Start]) == "" {
+                if joined := Tokens(s.doc.Text[prev.Start:c.End]); joined <= s.opt.MaxTokens {
+                    prev.End, prev.Tokens = c.End, joined
+                    continue
+                }
+            }
+        }
+        out = append(out, c)
+    }
+    return out
+}
+
+// boundary picks a cut at or before limit: the last paragraph break, else sentence
+// end, else space in the second half of the window; failing those, limit itself.
+func boundary(text string, start, limit int) int {
+    if limit >= len(text) { return len(text) }
+    for !utf8.RuneStart(text[limit]) { limit-- }
+    window := text[start:limit]
+    half := len(window) / 2
+    for _, sep := range []string{"\n\n", "\n", ". ", "; ", " "} {
+        if i := strings.LastIndex(window, sep); i >= half { return start + i + len(sep) }
+    }
+    return limit
+}
+
+// wordStart moves i forward to the start of a word, not past limit.
+func wordStart(text string, i, limit int) int {
+    if i <= 0 { return 0 }
+    for i < limit && !textutil.IsSpace(text[i-1]) { i++ }
+    return i
+}
@@ -0,0 +1,117 @@
+package chunks
+
+import (
+    "fmt"
+    "strings"
+    "testing"
+    "unicode/utf8"
+
+    "camille/internal/ports"
+)
+
+// part is a heading at level with its own text; a section runs to the next
+// heading at the same or a higher level.
+type part struct {
+    level   int
+    heading string
+    body    string
+}
+
+// build lays parts out as "heading\nbody\n" and rebuilds the heading tree.
+func build(lead string, parts ...part) ports.Document {
+    doc := ports.Document{URL: "https://example.com/privacy", Text: lead}
+    var open []int
+    for _, p := range parts {
+        for len(open) > 0 && doc.Sections[open[len(open)-1]].Level >= p.level {
+            doc.Sections[open[len(open)-1]].End = len(doc.Text)
+            open = open[:len(open)-1]
+        }
+        parent := -1
+        if len(open) > 0 { parent = open[len(open)-1] }
+        doc.Sections = append(doc.Sections, ports.Section{Heading: p.heading, Level: p.level, Parent: parent, Start: len(doc.Text)})
+        open = append(open, len(doc.Sections)-1)
+        doc.Text += p.heading + "\n" + p.body + "\n"
+    }
+    for _, i := range open { doc.Sections[i].End = len(doc.Text) }
+    return doc
+}
+
+func sentences(n int, word string) string {
+    var b strings.Builder
+    for i := 0; i < n; i++ { fmt.Fprintf(&b, "Sentence %d is about %s and nothing else. ", i, word) }
+    return strings.TrimSpace(b.String())
+}
+
+func TestSplitSectionsWithinBudget(t *testing.T) {
+    doc := build("Preamble text.\n",
+        part{1, "Privacy", "Intro."},
+        part{2, "Sharing", sentences(3, "sharing")},
+        part{2, "Retention", sentences(3, "retention")},
+    )
+    got := Split(doc, Options{MaxTokens: 1000, MinTokens: 1})
+    if len(got) != 2 { t.Fatalf("chunks = %+v, want preamble and one section", got) }
+    if got[1].Heading != "Privacy" || got[1].End != len(strings.TrimRight(doc.Text, "\n")) { t.Errorf("section chunk = %+v", got[1]) }
+}
+
+func TestSplitDescendsIntoLargeSections(t *testing.T) {
+    doc := build("",
+        part{1, "Privacy", "Intro."},
+        part{2, "Sharing", sentences(6, "sharing")},
+        part{2, "Retention", sentences(6, "retention")},
+    )
+    got := Split(doc, Options{MaxTokens: 120, MinTokens: 1})
+    var heads []string
+    for _, c := range got { heads = append(heads, c.Heading+fmt.Sprint(c.Path)) }
+    if want := "[Privacy[] Sharing[Privacy] Retention[Privacy]]"; fmt.Sprint(heads) != want { t.Errorf("chunks = %v, want %s", heads, want) }
+}
+
+func TestSplitMergesSmallNeighbours(t *testing.T) {
+    doc := build("",
+        part{1, "Privacy", sentences(12, "privacy")},
+        part{2, "Children", "Not for children."},
+        part{2, "Contact", "Write to us."},
+    )
+    got := Split(doc, Options{MaxTokens: 120, MinTokens: 20})
+    last := got[len(got)-1]
+    if last.Heading != "Children" || !strings.HasSuffix(doc.Text[last.Start:last.End], "Write to us.") { t.Errorf("last chunk = %+v %q, want Children merged with Contact", last, doc.Text[last.Start:last.End]) }
+}
+
+func TestSplitWindowsOversizedLeaf(t *testing.T) {
+    doc := build("", part{1, "Everything", sentences(40, "données personnelles")})
+    opt := Options{MaxTokens: 100, Overlap: 20, MinTokens: 1}
+    got := Split(doc, opt)
+    if len(got) < 3 { t.Fatalf("got %d windows, want several", len(got)) }
+    text := doc.Text
+    for i, c := range got {
+        if c.Tokens > opt.MaxTokens { t.Errorf("window %d: %d tokens over budget", i, c.Tokens) }
+        if !utf8.ValidString(text[c.Start:c.End]) { t.Errorf("window %d cuts a rune", i) }
+        if c.Heading != "Everything" { t.Errorf("window %d heading %q", i, c.Heading) }
+        if i == 0 { continue }
+        prev := got[i-1]
+        if c.Start >= prev.End { t.Errorf("window %d starts at %d, after the previous end %d: no overlap", i, c.Start, prev.End) }
+        if c.Start <= prev.Start { t.Errorf("window %d does not advance", i) }
+        if text[c.Start-1] != ' ' && text[c.Start-1] != '\n' { t.Errorf("window %d starts mid-word: %q", i, text[c.Start:c.Start+10]) }
+    }
+    if got[0].Start != 0 || got[len(got)-1].End != len(strings.TrimRight(text, "\n")) { t.Error("windows do not cover the section") }
+}
+
+func TestBoundary(t *testing.T) {
+    for _, tc := range []struct {
+        name        string
+        text        string
+        start, lim  int
+        want        int
+    }{
+        {"paragraph break", "aaaa bbbb.\n\ncccc dddd", 0, 16, 12},
+        {"sentence end", "aaaa bbbb. cccc dddd eeee", 0, 16, 11},
+        {"space", "aaaaaaaaaa bbbbbbbbbb", 0, 15, 11},
+        {"past the end", "short", 0, 10, 5},
+        // "é" is two bytes: a limit on its second byte moves back to the rune start.
+        {"rune straddles the limit", strings.Repeat("é", 10), 0, 5, 4},
+        {"separator too early", "a bbbbbbbbbbbbbbbbbbbbb", 0, 20, 20},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            if got := boundary(tc.text, tc.start, tc.lim); got != tc.want { t.Errorf("boundary = %d, want %d", got, tc.want) }
+        })
+    }
+}
@@ -0,0 +1,279 @@
+// Package diff aligns two versions of a policy document along their heading trees
+// and reports the clauses added, removed or reworded between them. Sections are
+// matched by heading path, then by heading, then by text, so a renamed or moved
+// section still diffs clause by clause instead of as a wholesale replacement.
+package diff
+
+import (
+    "strconv"
+    "strings"
+    "unicode"
+
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/textutil"
+)
+
+const (
+    // MinSimilarity pairs a removed and an added clause as one reworded clause.
+    MinSimilarity = 0.5
+    // minSectionSimilarity aligns sections whose headings both changed.
+    minSectionSimilarity = 0.6
+    // maxCells bounds LCS tables; larger inputs are compared as multisets.
+    maxCells = 4 << 20
+)
+
+// Result is the clause-level difference between two documents. Changes carry no
+// signals or categories; classifying them is up to the caller.
+type Result struct {
+    Changes         []ports.PolicyChange
+    SectionsAdded   int
+    SectionsRemoved int
+}
+
+// section is a heading's own text, up to its first subsection, split into clauses.
+type section struct {
+    heading string
+    path    []string // ancestor headings, outermost first
+    key     string   // normalized heading path, unique within the document
+    clauses []string
+    words   []string
+}
+
+// Compare diffs old against new.
+func Compare(old, new ports.Document) Result {
+    a, b := sections(old), sections(new)
+    pairs, added, removed := align(a, b)
+    var res Result
+    for j, sb := range b {
+        if i, ok := pairs[j]; ok {
+            res.Changes = append(res.Changes, clauses(a[i], sb)...)
+            continue
+        }
+        if !added[j] { continue }
+        res.SectionsAdded++
+        for _, c := range sb.clauses { res.Changes = append(res.Changes, change(ports.ChangeAdded, sb, "", c, 0)) }
+    }
+    for i, sa := range a {
+        if !removed[i] { continue }
+        res.SectionsRemoved++
+        for _, c := range sa.clauses { res.Changes = append(res.Changes, change(ports.ChangeRemoved, sa, c, "", 0)) }
+    }
+    return res
+}
+
+// sections splits doc into its preamble and the own text of every section.
+func sections(doc ports.Document) []section {
+    text := doc.Text
+    lead := len(text)
+    if len(doc.Sections) > 0 { lead = min(doc.Sections[0].Start, len(text)) }
+    out := []section{newSection("", nil, text[:lead])}
+    seen := map[string]int{}
+    for i, s := range doc.Sections {
+        start, end := min(s.Start, len(text)), min(s.End, len(text))
+        for j := i + 1; j < len(doc.Sections); j++ {
+            if doc.Sections[j].Parent == i {
+                end = min(end, doc.Sections[j].Start)
+                break
+            }
+        }
+        if strings.HasPrefix(text[start:max(start, end)], s.Heading) { start += len(s.Heading) }
+        var path []string
+        for p := s.Parent; p >= 0 && p < len(doc.Sections); p = doc.Sections[p].Parent {
+            path = append([]string{doc.Sections[p].Heading}, path...)
+        }
+        sec := newSection(s.Heading, path, text[start:max(start, end)])
+        // Repeated headings under one parent ("Overview") stay distinct by position.
+        base := sec.key
+        if n := seen[base]; n > 0 { sec.key += "\x00" + strconv.Itoa(n) }
+        seen[base]++
+        out = append(out, sec)
+    }
+    return out
+}
+
+func newSection(heading string, path []string, text string) section {
+    key := make([]string, 0, len(path)+1)
+    for _, h := range append(append([]string{}, path...), heading) { key = append(key, normalize(h)) }
+    cs := Clauses(text)
+    return section{heading: heading, path: path, key: strings.Join(key, "\x00"), clauses: cs, words: words(strings.Join(cs, " "))}
+}
+
+// align pairs new sections (by index in b) with old ones: by heading path, then by
+// heading alone, then by text similarity. The rest are added or removed.
+func align(a, b []section) (pairs map[int]int, added, removed map[int]bool) {
+    pairs, added, removed = map[int]int{}, map[int]bool{}, map[int]bool{}
+    used := map[int]bool{}
+    pass := func(match func(sa, sb section) float64, threshold float64) {
+        for j, sb := range b {
+            if _, ok := pairs[j]; ok { continue }
+            best, score := -1, threshold
+            for i, sa := range a {
+                if used[i] { continue }
+                if s := match(sa, sb); s >= score && (best < 0 || s > score) { best, score = i, s }
+            }
+            if best >= 0 {
+                pairs[j] = best
+                used[best] = true
+            }
+        }
+    }
+    pass(func(sa, sb section) float64 { return boolScore(sa.key == sb.key) }, 1)
+    pass(func(sa, sb section) float64 { return boolScore(sa.heading != "" && normalize(sa.heading) == normalize(sb.heading)) }, 1)
+    pass(func(sa, sb section) float64 {
+        if len(sa.words) == 0 || len(sb.words) == 0 { return 0 }
+        return similarity(sa.words, sb.words)
+    }, minSectionSimilarity)
+    for j, sb := range b {
+        if _, ok := pairs[j]; !ok && len(sb.clauses) > 0 { added[j] = true }
+    }
+    for i, sa := range a {
+        if !used[i] && len(sa.clauses) > 0 { removed[i] = true }
+    }
+    return pairs, added, removed
+}
+
+func boolScore(ok bool) float64 {
+    if ok { return 1 }
+    return 0
+}
+
+// clauses diffs two aligned sections: an LCS over normalized clauses, with each run
+// of removed clauses paired against the added clauses of the same run.
+func clauses(sa, sb section) []ports.PolicyChange {
+    x, y := make([]string, len(sa.clauses)), make([]string, len(sb.clauses))
+    for i, c := range sa.clauses { x[i] = normalize(c) }
+    for j, c := range sb.clauses { y[j] = normalize(c) }
+    var out []ports.PolicyChange
+    var del, ins []int
+    flush := func() {
+        out = append(out, hunk(sa, sb, del, ins)...)
+        del, ins = del[:0], ins[:0]
+    }
+    for _, op := range lcs(x, y) {
+        switch {
+        case op.i >= 0 && op.j >= 0:
+            flush()
+        case op.i >= 0:
+            del = append(del, op.i)
+        default:
+            ins = append(ins, op.j)
+        }
+    }
+    flush()
+    return out
+}
+
+// hunk turns one run of removed (del) and added (ins) clauses into changes: each
+// removed clause takes the most similar unclaimed added one above MinSimilarity.
+func hunk(sa, sb section, del, ins []int) []ports.PolicyChange {
+    var out []ports.PolicyChange
+    claimed := map[int]bool{}
+    for _, i := range del {
+        wa := words(sa.clauses[i])
+        best, score := -1, MinSimilarity
+        for _, j := range ins {
+            if claimed[j] { continue }
+            if s := similarity(wa, words(sb.clauses[j])); s >= score { best, score = j, s }
+        }
+        if best < 0 {
+            out = append(out, change(ports.ChangeRemoved, sa, sa.clauses[i], "", 0))
+            continue
+        }
+        claimed[best] = true
+        out = append(out, change(ports.ChangeModified, sb, sa.clauses[i], sb.clauses[best], score))
+    }
+    for _, j := range ins {
+        if !claimed[j] { out = append(out, change(ports.ChangeAdded, sb, "", sb.clauses[j], 0)) }
+    }
+    return out
+}
+
+func change(kind string, s section, from, to string, sim float64) ports.PolicyChange {
+    return ports.PolicyChange{Kind: kind, Section: s.heading, Path: s.path, From: from, To: to, Similarity: sim}
+}
+
+// op is one LCS step: i and j both set for a kept element, one of them -1 otherwise.
+type op struct{ i, j int }
+
+// lcs aligns x and y. Past maxCells it degrades to multiset matching in order.
+func lcs(x, y []string) []op {
+    n, m := len(x), len(y)
+    if n*m > maxCells { return multiset(x, y) }
+    t := make([][]int32, n+1)
+    for i := range t { t[i] = make([]int32, m+1) }
+    for i := n - 1; i >= 0; i-- {
+        for j := m - 1; j >= 0; j-- {
+            if x[i] == y[j] {
+                t[i][j] = t[i+1][j+1] + 1
+            } else {
+                t[i][j] = max(t[i+1][j], t[i][j+1])
+            }
+        }
+    }
+    var out []op
+    i, j := 0, 0
+    for i < n && j < m {
+        switch {
+        case x[i] == y[j]:
+            out = append(out, op{i, j})
+            i, j = i+1, j+1
+        case t[i+1][j] >= t[i][j+1]:
+            out = append(out, op{i, -1})
+            i++
+        default:
+            out = append(out, op{-1, j})
+            j++
+        }
+    }
+    for ; i < n; i++ { out = append(out, op{i, -1}) }
+    for ; j < m; j++ { out = append(out, op{-1, j}) }
+    return out
+}
+
+func multiset(x, y []string) []op {
+    left := map[string]int{}
+    for _, s := range y { left[s]++ }
+    var out []op
+    for i, s := range x {
+        if left[s] > 0 {
+            left[s]--
+            continue
+        }
+        out = append(out, op{i, -1})
+    }
+    kept := map[string]int{}
+    for _, s := range x { kept[s]++ }
+    for j, s := range y {
+        if kept[s] > 0 {
+            kept[s]--
+            continue
+        }
+        out = append(out, op{-1, j})
+    }
+    return out
+}
+
+// similarity is 2·LCS(words) / (len a + len b): 1 for identical word sequences.
+func similarity(a, b []string) float64 {
+    if len(a)+len(b) == 0 { return 1 }
+    kept := len(a)
+    for _, o := range lcs(a, b) {
+        if o.j < 0 { kept-- }
+    }
+    return 2 * float64(kept) / float64(len(a)+len(b))
+}
+
+// Clauses splits text into sentences and lines, the unit of change, with
+// whitespace runs folded.
+func Clauses(text string) []string {
+    var out []string
+    for _, sp := range textutil.Sentences(text) { out = append(out, strings.Join(strings.Fields(text[sp[0]:sp[1]]), " ")) }
+    return out
+}
+
+// normalize folds case and whitespace so formatting-only edits are not changes.
+func normalize(s string) string { return strings.Join(words(s), " ") }
+
+func words(s string) []string {
+    return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
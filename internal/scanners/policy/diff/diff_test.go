@@ -0,0 +1,121 @@
+package diff
+
+import (
+    "fmt"
+    "strings"
+    "testing"
+
+    "camille/internal/ports"
+)
+
+// part is a heading at level with its own text.
+type part struct {
+    level   int
+    heading string
+    body    string
+}
+
+// build lays parts out as "heading\nbody\n" and rebuilds the heading tree.
+func build(parts ...part) ports.Document {
+    var doc ports.Document
+    var open []int
+    for _, p := range parts {
+        for len(open) > 0 && doc.Sections[open[len(open)-1]].Level >= p.level {
+            doc.Sections[open[len(open)-1]].End = len(doc.Text)
+            open = open[:len(open)-1]
+        }
+        parent := -1
+        if len(open) > 0 { parent = open[len(open)-1] }
+        doc.Sections = append(doc.Sections, ports.Section{Heading: p.heading, Level: p.level, Parent: parent, Start: len(doc.Text)})
+        open = append(open, len(doc.Sections)-1)
+        doc.Text += p.heading + "\n" + p.body + "\n"
+    }
+    for _, i := range open { doc.Sections[i].End = len(doc.Text) }
+    return doc
+}
+
+// summary renders changes as "kind section: from -> to" lines.
+func summary(r Result) string {
+    var out []string
+    for _, c := range r.Changes { out = append(out, fmt.Sprintf("%s %s%v: %s -> %s", c.Kind, c.Section, c.Path, c.From, c.To)) }
+    return strings.Join(out, "\n")
+}
+
+const (
+    sharing   = "We share your data with payment providers. We never sell your data."
+    retention = "We keep invoices for ten years. Logs are deleted after 30 days."
+)
+
+func TestCompare(t *testing.T) {
+    base := build(part{1, "Privacy", "Who we are."}, part{2, "Sharing", sharing}, part{2, "Retention", retention})
+    for _, tc := range []struct {
+        name           string
+        new            ports.Document
+        want           string
+        added, removed int
+    }{
+        {"identical", base, "", 0, 0},
+        {"formatting only", build(part{1, "Privacy", "Who  we are."}, part{2, "Sharing", strings.ToUpper(sharing)}, part{2, "Retention", retention}), "", 0, 0},
+        {"reworded clause",
+            build(part{1, "Privacy", "Who we are."}, part{2, "Sharing", "We share your data with payment providers. We may sell your data to partners."}, part{2, "Retention", retention}),
+            "modified Sharing[Privacy]: We never sell your data. -> We may sell your data to partners.", 0, 0},
+        {"added and removed clauses",
+            build(part{1, "Privacy", "Who we are."}, part{2, "Sharing", "We share your data with payment providers."}, part{2, "Retention", retention + " Backups are kept forever."}),
+            "removed Sharing[Privacy]: We never sell your data. -> \nadded Retention[Privacy]:  -> Backups are kept forever.", 0, 0},
+        {"renamed section",
+            build(part{1, "Privacy", "Who we are."}, part{2, "Who receives your data", sharing}, part{2, "Retention", retention}),
+            "", 0, 0},
+        {"moved section",
+            build(part{1, "Privacy", "Who we are."}, part{2, "Retention", retention}, part{1, "Partners", "Our partners."}, part{2, "Sharing", sharing}),
+            "added Partners[]:  -> Our partners.", 1, 0},
+        {"removed section",
+            build(part{1, "Privacy", "Who we are."}, part{2, "Sharing", sharing}),
+            "removed Retention[Privacy]: We keep invoices for ten years. -> \nremoved Retention[Privacy]: Logs are deleted after 30 days. -> ", 0, 1},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            r := Compare(base, tc.new)
+            if got := summary(r); got != tc.want { t.Errorf("changes:\n%s\nwant:\n%s", got, tc.want) }
+            if r.SectionsAdded != tc.added || r.SectionsRemoved != tc.removed { t.Errorf("sections +%d -%d, want +%d -%d", r.SectionsAdded, r.SectionsRemoved, tc.added, tc.removed) }
+        })
+    }
+}
+
+// Two products each have an "Overview"; a change in the second must not be
+// reported against the first.
+func TestCompareRepeatedHeadings(t *testing.T) {
+    old := build(part{1, "App", ""}, part{2, "Overview", "The app collects your location."}, part{1, "Website", ""}, part{2, "Overview", "The website sets cookies."})
+    new := build(part{1, "App", ""}, part{2, "Overview", "The app collects your location."}, part{1, "Website", ""}, part{2, "Overview", "The website sets analytics cookies."})
+    if got, want := summary(Compare(old, new)), "modified Overview[Website]: The website sets cookies. -> The website sets analytics cookies."; got != want { t.Errorf("changes:\n%s\nwant:\n%s", got, want) }
+
+    // Repeated under one parent, the second stays distinct by position.
+    old = build(part{1, "Terms", ""}, part{2, "Overview", "First part."}, part{2, "Overview", "Second part."})
+    new = build(part{1, "Terms", ""}, part{2, "Overview", "First part."}, part{2, "Overview", "Second part, revised."})
+    if got, want := summary(Compare(old, new)), "modified Overview[Terms]: Second part. -> Second part, revised."; got != want { t.Errorf("changes:\n%s\nwant:\n%s", got, want) }
+}
+
+// Past maxCells lcs falls back to multiset matching: shared clauses are kept
+// wherever they appear, the rest are removed or added.
+func TestLCSMultisetFallback(t *testing.T) {
+    n := 2100
+    x, y := make([]string, n), make([]string, n)
+    for i := range x { x[i], y[i] = fmt.Sprint("clause ", i), fmt.Sprint("clause ", i) }
+    y[7], y[n-1] = "new clause", "clause 0"
+    var del, ins []string
+    for _, o := range lcs(x, y) {
+        switch {
+        case o.i >= 0 && o.j >= 0:
+            t.Fatalf("multiset matching reported a kept pair %v", o)
+        case o.i >= 0:
+            del = append(del, x[o.i])
+        default:
+            ins = append(ins, y[o.j])
+        }
+    }
+    if fmt.Sprint(del, ins) != "[clause 7 clause 2099] [new clause clause 0]" { t.Errorf("removed %v, added %v", del, ins) }
+}
+
+func TestClauses(t *testing.T) {
+    got := Clauses("We use cookies, e.g. for analytics.  See \"Choices\". (Optional) settings\nNext   line")
+    want := []string{"We use cookies, e.g. for analytics.", `See "Choices".`, "(Optional) settings", "Next line"}
+    if fmt.Sprint(got) != fmt.Sprint(want) { t.Errorf("Clauses = %q, want %q", got, want) }
+}
@@ -0,0 +1,481 @@
+// Package discovery finds a site's privacy, terms and cookie policy documents by
+// scoring landing page links, rel attributes, sitemap entries and fallback paths.
+// Keywords in the page's own language are consulted first and rank ahead of
+// equally good matches in other languages.
+package discovery
+
+import (
+    "bytes"
+    "context"
+    "encoding/xml"
+    "fmt"
+    "net/url"
+    "regexp"
+    "sort"
+    "strings"
+
+    "golang.org/x/net/html"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+// Candidate sources.
+const (
+    SourceAnchor   = "anchor"
+    SourceRel      = "rel"
+    SourceSitemap  = "sitemap"
+    SourceFallback = "fallback"
+)
+
+// Candidate is a possible policy document with the reasoning behind its score.
+type Candidate struct {
+    Type     string   `json:"type"`
+    URL      string   `json:"url"`
+    FinalURL string   `json:"final_url,omitempty"`
+    Score    float64  `json:"score"`
+    Sources  []string `json:"sources"`
+    Reasons  []string `json:"reasons"`
+    Verified bool     `json:"verified"`
+    Status   int      `json:"status,omitempty"`
+}
+
+// Discoverer ranks policy candidates. MaxVerify bounds how many top candidates per
+// type are fetched to confirm they resolve within the site.
+type Discoverer struct {
+    Fetcher   ports.Fetcher
+    MaxVerify int
+}
+
+func New(fetcher ports.Fetcher) *Discoverer { return &Discoverer{Fetcher: fetcher, MaxVerify: 2} }
+
+// Discover returns verified candidates ranked by type then descending score.
+func (d *Discoverer) Discover(ctx context.Context, t ports.ScanTarget) ([]Candidate, error) {
+    resp, err := d.Fetcher.Fetch(ctx, ports.FetchRequest{URL: scanners.LandingURL(t)})
+    if err != nil { return nil, err }
+    base, err := url.Parse(resp.URL)
+    if err != nil { return nil, err }
+
+    set := candidateSet{lang: Language(resp.Body)}
+    for _, l := range Links(base, resp.Body) {
+        set.scoreLink(l, t.Domain)
+    }
+    for _, typ := range domain.PolicyTypes {
+        if set.has(typ) { continue }
+        d.sitemap(ctx, t, &set, typ)
+    }
+    for _, typ := range domain.PolicyTypes {
+        if set.has(typ) { continue }
+        for _, p := range fallbacks(typ, set.lang) {
+            set.add(Candidate{Type: typ, URL: t.Origin() + p, Score: 0.5, Sources: []string{SourceFallback}, Reasons: []string{"common path " + p}})
+        }
+    }
+
+    var out []Candidate
+    for _, typ := range domain.PolicyTypes {
+        ranked := set.ranked(typ)
+        verified := 0
+        for _, c := range ranked {
+            if verified >= d.MaxVerify { break }
+            if ctx.Err() != nil { return nil, ctx.Err() }
+            c = d.verify(ctx, t, c, resp.Body)
+            if !c.Verified { continue }
+            verified++
+            out = append(out, c)
+        }
+    }
+    sort.SliceStable(out, func(i, j int) bool {
+        if out[i].Type != out[j].Type { return typeOrder(out[i].Type) < typeOrder(out[j].Type) }
+        return out[i].Score > out[j].Score
+    })
+    return dedupeFinal(out), nil
+}
+
+// Follow ranks the links on an already fetched page, such as the privacy policy, as
+// candidates of typ and verifies the best. Sub-processor lists are usually linked
+// from the privacy policy rather than from the landing page.
+func (d *Discoverer) Follow(ctx context.Context, t ports.ScanTarget, page ports.FetchResponse, typ string) []Candidate {
+    base, err := url.Parse(page.URL)
+    if err != nil { return nil }
+    set := candidateSet{lang: Language(page.Body)}
+    self := normalize(page.URL)
+    for _, l := range Links(base, page.Body) {
+        if normalize(l.URL) != self { set.scoreLink(l, t.Domain) }
+    }
+    var out []Candidate
+    for _, c := range set.ranked(typ) {
+        if len(out) >= d.MaxVerify || c.Score < 3 || ctx.Err() != nil { break }
+        c.Reasons = append(c.Reasons, "linked from "+page.URL)
+        if c = d.verify(ctx, t, c, page.Body); c.Verified && normalize(c.FinalURL) != self { out = append(out, c) }
+    }
+    return dedupeFinal(out)
+}
+
+// Best returns the top candidate of a type.
+func Best(cands []Candidate, typ string) (Candidate, bool) {
+    for _, c := range cands {
+        if c.Type == typ { return c, true }
+    }
+    return Candidate{}, false
+}
+
+// verify fetches a candidate on the scanned site, following redirects only within
+// its registrable domain; candidates hosted elsewhere are not fetched. Single-page
+// apps answer any path with 200 and their shell, so a body identical to the page
+// the candidate was found on is rejected, and a candidate only guessed (fallback
+// path or sitemap entry) must name its document type in its title or first heading.
+func (d *Discoverer) verify(ctx context.Context, t ports.ScanTarget, c Candidate, from []byte) Candidate {
+    if u, err := url.Parse(c.URL); err != nil || scanners.Registrable(u.Hostname()) != t.Domain {
+        c.Reasons = append(c.Reasons, "not on "+t.Domain)
+        return c
+    }
+    resp, err := d.Fetcher.Fetch(ctx, ports.FetchRequest{URL: c.URL, SameSite: true})
+    if err != nil {
+        c.Reasons = append(c.Reasons, "fetch failed: "+err.Error())
+        return c
+    }
+    c.Status = resp.StatusCode
+    c.FinalURL = resp.URL
+    if resp.StatusCode != 200 {
+        c.Reasons = append(c.Reasons, fmt.Sprintf("status %d", resp.StatusCode))
+        return c
+    }
+    if resp.URL != c.URL { c.Reasons = append(c.Reasons, "redirects to "+resp.URL) }
+    if len(from) > 0 && bytes.Equal(resp.Body, from) {
+        c.Reasons = append(c.Reasons, "same page as the one linking it (soft 404)")
+        return c
+    }
+    if guessed(c) && !isPDF(resp) {
+        if score, reason := headingScore(c.Type, resp.Body); score > 0 {
+            c.Reasons = append(c.Reasons, reason)
+        } else {
+            c.Reasons = append(c.Reasons, "title and first heading name no "+c.Type+" document (soft 404)")
+            return c
+        }
+    }
+    c.Verified = true
+    return c
+}
+
+// guessed reports whether nothing on the site linked to the candidate.
+func guessed(c Candidate) bool {
+    for _, src := range c.Sources {
+        if src == SourceAnchor || src == SourceRel { return false }
+    }
+    return true
+}
+
+func isPDF(resp ports.FetchResponse) bool {
+    return strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "pdf") || bytes.HasPrefix(resp.Body, []byte("%PDF-"))
+}
+
+// headingScore scores a page's <title> and first <h1> as link text for typ, in
+// the page's language first.
+func headingScore(typ string, body []byte) (float64, string) {
+    doc, err := html.Parse(bytes.NewReader(body))
+    if err != nil { return 0, "" }
+    var title, h1 string
+    var walk func(*html.Node)
+    walk = func(n *html.Node) {
+        if n.Type == html.ElementNode {
+            switch {
+            case n.Data == "title" && title == "":
+                title = collapse(textOf(n))
+            case n.Data == "h1" && h1 == "":
+                h1 = collapse(textOf(n))
+            }
+        }
+        for c := n.FirstChild; c != nil; c = c.NextSibling { walk(c) }
+    }
+    walk(doc)
+    lang := Language(body)
+    best, reason := 0.0, ""
+    for _, text := range []string{title, h1} {
+        if score, why := textScore(typ, strings.ToLower(text), lang); score > best {
+            best, reason = score, "heading: "+why
+        }
+    }
+    return best, reason
+}
+
+// sitemap scans /sitemap.xml <loc> entries for URL path keywords of typ.
+func (d *Discoverer) sitemap(ctx context.Context, t ports.ScanTarget, set *candidateSet, typ string) {
+    if set.sitemapLocs == nil {
+        set.sitemapLocs = []string{}
+        resp, err := d.Fetcher.Fetch(ctx, ports.FetchRequest{URL: t.Origin() + "/sitemap.xml", SameSite: true})
+        if err != nil || resp.StatusCode != 200 { return }
+        dec := xml.NewDecoder(bytes.NewReader(resp.Body))
+        for len(set.sitemapLocs) < 5000 {
+            tok, err := dec.Token()
+            if err != nil { break }
+            if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "loc" {
+                var loc string
+                if dec.DecodeElement(&loc, &se) == nil { set.sitemapLocs = append(set.sitemapLocs, strings.TrimSpace(loc)) }
+            }
+        }
+    }
+    for _, loc := range set.sitemapLocs {
+        u, err := url.Parse(loc)
+        if err != nil || scanners.Registrable(u.Hostname()) != t.Domain { continue }
+        if score, reason := pathScore(typ, u.Path, set.lang); score > 0 {
+            set.add(Candidate{Type: typ, URL: loc, Score: score + 1, Sources: []string{SourceSitemap}, Reasons: []string{"listed in sitemap.xml", reason}})
+        }
+    }
+}
+
+var htmlLang = regexp.MustCompile(`(?i)<html[^>]*\slang\s*=\s*["']?([a-z]{2,3})`)
+
+// Language returns the primary subtag of the page's html lang attribute, lower-cased.
+func Language(page []byte) string {
+    m := htmlLang.FindSubmatch(page)
+    if m == nil { return "" }
+    return strings.ToLower(string(m[1]))
+}
+
+// Link is an anchor or <link> element from the landing page.
+type Link struct {
+    URL    string
+    Text   string // lower-cased, whitespace-collapsed text, aria-label or title
+    Rel    string
+    Footer bool
+}
+
+// Links extracts anchors and link elements, noting whether each sits in a footer.
+func Links(base *url.URL, body []byte) []Link {
+    doc, err := html.Parse(bytes.NewReader(body))
+    if err != nil { return nil }
+    var out []Link
+    var walk func(n *html.Node, footer bool)
+    walk = func(n *html.Node, footer bool) {
+        if n.Type == html.ElementNode {
+            if isFooter(n) { footer = true }
+            if n.Data == "a" || n.Data == "link" {
+                href := attr(n, "href")
+                if u, ok := resolve(base, href); ok {
+                    text := collapse(textOf(n))
+                    if text == "" { text = collapse(attr(n, "aria-label") + " " + attr(n, "title")) }
+                    out = append(out, Link{URL: u, Text: strings.ToLower(text), Rel: strings.ToLower(attr(n, "rel")), Footer: footer})
+                }
+            }
+        }
+        for c := n.FirstChild; c != nil; c = c.NextSibling { walk(c, footer) }
+    }
+    walk(doc, false)
+    return out
+}
+
+type candidateSet struct {
+    lang        string // landing page language
+    byKey       map[string]*Candidate
+    sitemapLocs []string
+}
+
+func (s *candidateSet) add(c Candidate) {
+    if s.byKey == nil { s.byKey = map[string]*Candidate{} }
+    key := c.Type + " " + normalize(c.URL)
+    if prev, ok := s.byKey[key]; ok {
+        prev.Score += c.Score
+        prev.Sources = appendUnique(prev.Sources, c.Sources...)
+        prev.Reasons = appendUnique(prev.Reasons, c.Reasons...)
+        return
+    }
+    s.byKey[key] = &c
+}
+
+func (s *candidateSet) has(typ string) bool {
+    for _, c := range s.byKey {
+        if c.Type == typ && c.Score >= 3 { return true }
+    }
+    return false
+}
+
+func (s *candidateSet) ranked(typ string) []Candidate {
+    var out []Candidate
+    for _, c := range s.byKey {
+        if c.Type == typ { out = append(out, *c) }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if out[i].Score != out[j].Score { return out[i].Score > out[j].Score }
+        return out[i].URL < out[j].URL
+    })
+    return out
+}
+
+// scoreLink adds a candidate per document type the link plausibly names.
+func (s *candidateSet) scoreLink(l Link, site string) {
+    u, err := url.Parse(l.URL)
+    if err != nil { return }
+    for _, rel := range strings.Fields(l.Rel) {
+        if typ, ok := relTypes[rel]; ok {
+            s.add(Candidate{Type: typ, URL: l.URL, Score: 8, Sources: []string{SourceRel}, Reasons: []string{`rel="` + rel + `"`}})
+        }
+    }
+    named := map[string]float64{}
+    for _, typ := range domain.PolicyTypes {
+        named[typ], _ = textScore(typ, l.Text, s.lang)
+    }
+    for _, typ := range domain.PolicyTypes {
+        tScore, tReason := textScore(typ, l.Text, s.lang)
+        if tScore == 0 && namesOther(named, typ) { continue }
+        pScore, pReason := pathScore(typ, u.Path, s.lang)
+        score := tScore + pScore
+        if score == 0 { continue }
+        var reasons []string
+        if tReason != "" { reasons = append(reasons, tReason) }
+        if pReason != "" { reasons = append(reasons, pReason) }
+        if l.Footer {
+            score++
+            reasons = append(reasons, "in page footer")
+        }
+        if scanners.Registrable(u.Hostname()) != site {
+            score -= 2
+            reasons = append(reasons, "hosted off-site on "+u.Hostname())
+        }
+        s.add(Candidate{Type: typ, URL: l.URL, Score: score, Sources: []string{SourceAnchor}, Reasons: reasons})
+    }
+}
+
+// namesOther reports whether link text clearly names a document other than typ.
+func namesOther(named map[string]float64, typ string) bool {
+    for other, score := range named {
+        if other != typ && score >= 4 { return true }
+    }
+    return false
+}
+
+// textScore returns the best keyword match for link text across languages. A
+// match in the page language scores half a point more.
+func textScore(typ, text, pageLang string) (float64, string) {
+    if text == "" { return 0, "" }
+    var best float64
+    var reason string
+    for _, lang := range ordered(pageLang) {
+        tag, bonus := lang, 0.0
+        if lang == pageLang { tag, bonus = lang+", page language", 0.5 }
+        consider := func(score float64, why string) {
+            if score += bonus; score > best { best, reason = score, why }
+        }
+        kw := catalog[typ][lang]
+        for _, p := range kw.Strong {
+            if text == p {
+                consider(6, fmt.Sprintf("link text %q (%s)", p, tag))
+            } else if strings.Contains(text, p) {
+                consider(4, fmt.Sprintf("link text contains %q (%s)", p, tag))
+            }
+        }
+        for _, p := range kw.Weak {
+            if text == p {
+                consider(3, fmt.Sprintf("link text %q (%s)", p, tag))
+            } else if containsWord(text, p) {
+                consider(1.5, fmt.Sprintf("link text mentions %q (%s)", p, tag))
+            }
+        }
+    }
+    return best, reason
+}
+
+// pathScore returns the first URL path keyword match, trying the page language first.
+func pathScore(typ, path, pageLang string) (float64, string) {
+    path = strings.ToLower(path)
+    if path == "" || path == "/" { return 0, "" }
+    for _, lang := range ordered(pageLang) {
+        for _, p := range catalog[typ][lang].Paths {
+            if strings.Contains(path, p) {
+                return 2, fmt.Sprintf("URL path contains %q (%s)", p, lang)
+            }
+        }
+    }
+    return 0, ""
+}
+
+// fallbacks lists the common paths to probe for typ: English first, then the page language's.
+func fallbacks(typ, pageLang string) []string {
+    var out []string
+    for _, lang := range []string{"en", pageLang} {
+        for _, p := range fallbackPaths[typ][lang] { out = appendUnique(out, p) }
+    }
+    return out
+}
+
+func isFooter(n *html.Node) bool {
+    if n.Data == "footer" || attr(n, "role") == "contentinfo" { return true }
+    idc := strings.ToLower(attr(n, "id") + " " + attr(n, "class"))
+    return strings.Contains(idc, "footer")
+}
+
+func resolve(base *url.URL, href string) (string, bool) {
+    href = strings.TrimSpace(href)
+    if href == "" || strings.HasPrefix(href, "#") { return "", false }
+    u, err := base.Parse(href)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") { return "", false }
+    u.Fragment = ""
+    return u.String(), true
+}
+
+func attr(n *html.Node, key string) string {
+    for _, a := range n.Attr {
+        if a.Key == key { return a.Val }
+    }
+    return ""
+}
+
+func textOf(n *html.Node) string {
+    var b strings.Builder
+    var walk func(*html.Node)
+    walk = func(n *html.Node) {
+        if n.Type == html.TextNode { b.WriteString(n.Data); b.WriteByte(' ') }
+        for c := n.FirstChild; c != nil; c = c.NextSibling { walk(c) }
+    }
+    walk(n)
+    return b.String()
+}
+
+func collapse(s string) string { return strings.Join(strings.Fields(s), " ") }
+
+func containsWord(text, word string) bool {
+    for _, w := range strings.FieldsFunc(text, func(r rune) bool { return r == ' ' || r == '|' || r == '/' || r == '&' || r == ',' }) {
+        if w == word { return true }
+    }
+    return strings.Contains(text, word) && strings.Contains(word, " ")
+}
+
+func normalize(raw string) string {
+    u, err := url.Parse(raw)
+    if err != nil { return raw }
+    u.Fragment = ""
+    u.Host = strings.ToLower(u.Host)
+    u.Path = strings.TrimSuffix(u.Path, "/")
+    return u.String()
+}
+
+// dedupeFinal drops lower-ranked candidates of the same type that redirect to the same document.
+func dedupeFinal(in []Candidate) []Candidate {
+    seen := map[string]bool{}
+    var out []Candidate
+    for _, c := range in {
+        key := c.Type + " " + normalize(c.FinalURL)
+        if seen[key] { continue }
+        seen[key] = true
+        out = append(out, c)
+    }
+    return out
+}
+
+func appendUnique(xs []string, add ...string) []string {
+    for _, a := range add {
+        dup := false
+        for _, x := range xs {
+            if x == a { dup = true; break }
+        }
+        if !dup { xs = append(xs, a) }
+    }
+    return xs
+}
+
+func typeOrder(typ string) int {
+    for i, t := range domain.PolicyTypes {
+        if t == typ { return i }
+    }
+    return len(domain.PolicyTypes)
+}
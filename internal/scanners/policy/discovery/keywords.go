@@ -0,0 +1,113 @@
+package discovery
+
+import "camille/internal/domain"
+
+// keywords holds link text phrases and URL path fragments per document type and language.
+// Strong phrases name the document outright; weak ones are single words that also appear
+// in unrelated links ("privacy" in "privacy settings").
+type keywords struct {
+    Strong []string
+    Weak   []string
+    Paths  []string
+}
+
+// languages fixes the order catalogs are consulted so reasons are stable.
+var languages = []string{"en", "de", "fr", "es", "nl", "it", "pt"}
+
+// ordered returns languages with the page language, when known, moved to the front.
+func ordered(lang string) []string {
+    out := make([]string, 0, len(languages))
+    for _, l := range languages {
+        if l == lang { out = append(out, l) }
+    }
+    for _, l := range languages {
+        if l != lang { out = append(out, l) }
+    }
+    return out
+}
+
+var catalog = map[string]map[string]keywords{
+    domain.PolicyPrivacy: {
+        "en": {Strong: []string{"privacy policy", "privacy notice", "privacy statement", "data protection policy"}, Weak: []string{"privacy", "data protection"}, Paths: []string{"privacy", "data-protection"}},
+        "de": {Strong: []string{"datenschutzerklärung", "datenschutzhinweise", "datenschutzrichtlinie"}, Weak: []string{"datenschutz"}, Paths: []string{"datenschutz"}},
+        "fr": {Strong: []string{"politique de confidentialité", "politique de protection des données", "charte de confidentialité"}, Weak: []string{"confidentialité", "données personnelles"}, Paths: []string{"confidentialite", "donnees-personnelles", "vie-privee"}},
+        "es": {Strong: []string{"política de privacidad", "aviso de privacidad", "política de protección de datos"}, Weak: []string{"privacidad", "protección de datos"}, Paths: []string{"privacidad", "proteccion-de-datos"}},
+        "nl": {Strong: []string{"privacybeleid", "privacyverklaring", "privacyreglement"}, Weak: []string{"privacy"}, Paths: []string{"privacybeleid", "privacyverklaring"}},
+        "it": {Strong: []string{"informativa sulla privacy", "informativa privacy", "politica sulla privacy"}, Weak: []string{"privacy"}, Paths: []string{"informativa-privacy", "privacy-policy"}},
+        "pt": {Strong: []string{"política de privacidade", "aviso de privacidade"}, Weak: []string{"privacidade"}, Paths: []string{"privacidade", "politica-de-privacidade"}},
+    },
+    domain.PolicyTerms: {
+        "en": {Strong: []string{"terms of service", "terms of use", "terms and conditions", "terms & conditions", "user agreement"}, Weak: []string{"terms", "legal"}, Paths: []string{"terms", "tos", "terms-of-service", "terms-of-use", "legal"}},
+        "de": {Strong: []string{"allgemeine geschäftsbedingungen", "nutzungsbedingungen"}, Weak: []string{"agb"}, Paths: []string{"agb", "nutzungsbedingungen"}},
+        "fr": {Strong: []string{"conditions générales d'utilisation", "conditions générales de vente", "conditions d'utilisation"}, Weak: []string{"cgu", "cgv", "conditions générales"}, Paths: []string{"cgu", "cgv", "conditions-generales"}},
+        "es": {Strong: []string{"términos y condiciones", "condiciones de uso", "condiciones generales"}, Weak: []string{"términos", "aviso legal"}, Paths: []string{"terminos", "condiciones"}},
+        "nl": {Strong: []string{"algemene voorwaarden", "gebruiksvoorwaarden"}, Weak: []string{"voorwaarden"}, Paths: []string{"algemene-voorwaarden", "voorwaarden"}},
+        "it": {Strong: []string{"termini e condizioni", "condizioni d'uso", "condizioni generali"}, Weak: []string{"termini"}, Paths: []string{"termini", "condizioni"}},
+        "pt": {Strong: []string{"termos de uso", "termos e condições", "termos de serviço"}, Weak: []string{"termos"}, Paths: []string{"termos"}},
+    },
+    domain.PolicyCookies: {
+        "en": {Strong: []string{"cookie policy", "cookie notice", "cookies policy", "use of cookies"}, Weak: []string{"cookies"}, Paths: []string{"cookie-policy", "cookies", "cookie"}},
+        "de": {Strong: []string{"cookie-richtlinie", "cookie-hinweise", "cookie-erklärung"}, Weak: []string{"cookies"}, Paths: []string{"cookie-richtlinie", "cookies"}},
+        "fr": {Strong: []string{"politique de cookies", "politique relative aux cookies", "gestion des cookies"}, Weak: []string{"cookies"}, Paths: []string{"politique-cookies", "cookies"}},
+        "es": {Strong: []string{"política de cookies"}, Weak: []string{"cookies"}, Paths: []string{"politica-de-cookies", "cookies"}},
+        "nl": {Strong: []string{"cookiebeleid", "cookieverklaring"}, Weak: []string{"cookies"}, Paths: []string{"cookiebeleid", "cookies"}},
+        "it": {Strong: []string{"cookie policy", "informativa sui cookie"}, Weak: []string{"cookie"}, Paths: []string{"cookie-policy", "cookie"}},
+        "pt": {Strong: []string{"política de cookies"}, Weak: []string{"cookies"}, Paths: []string{"politica-de-cookies", "cookies"}},
+    },
+    domain.PolicySubprocessors: {
+        "en": {Strong: []string{"sub-processors", "subprocessors", "sub processors", "list of sub-processors", "third-party sub-processors"}, Weak: []string{"processors", "service providers"}, Paths: []string{"subprocessor", "sub-processor", "sub_processor"}},
+        "de": {Strong: []string{"unterauftragsverarbeiter", "liste der unterauftragsverarbeiter"}, Weak: []string{"auftragsverarbeiter", "dienstleister"}, Paths: []string{"unterauftragsverarbeiter", "subunternehmer"}},
+        "fr": {Strong: []string{"sous-traitants ultérieurs", "liste des sous-traitants"}, Weak: []string{"sous-traitants"}, Paths: []string{"sous-traitants", "sous-traitant"}},
+        "es": {Strong: []string{"subencargados del tratamiento", "lista de subencargados"}, Weak: []string{"subencargados"}, Paths: []string{"subencargados", "subprocesadores"}},
+        "nl": {Strong: []string{"subverwerkers", "lijst van subverwerkers"}, Weak: []string{"verwerkers"}, Paths: []string{"subverwerkers"}},
+        "it": {Strong: []string{"sub-responsabili del trattamento", "elenco dei sub-responsabili"}, Weak: []string{"sub-responsabili", "subresponsabili"}, Paths: []string{"sub-responsabili", "subresponsabili"}},
+        "pt": {Strong: []string{"suboperadores", "subprocessadores", "lista de suboperadores"}, Weak: []string{"suboperador"}, Paths: []string{"suboperadores", "subprocessadores"}},
+    },
+}
+
+// relTypes maps registered link relations to document types.
+var relTypes = map[string]string{
+    "privacy-policy":   domain.PolicyPrivacy,
+    "terms-of-service": domain.PolicyTerms,
+}
+
+// fallbackPaths are probed when markup yields no candidate for a type: the
+// English paths always, plus those of the landing page's language.
+var fallbackPaths = map[string]map[string][]string{
+    domain.PolicyPrivacy: {
+        "en": {"/privacy", "/privacy-policy", "/legal/privacy", "/privacy.html"},
+        "de": {"/datenschutz", "/datenschutzerklaerung"},
+        "fr": {"/confidentialite", "/politique-de-confidentialite"},
+        "es": {"/privacidad", "/politica-de-privacidad"},
+        "nl": {"/privacybeleid", "/privacyverklaring"},
+        "it": {"/informativa-privacy", "/privacy-policy"},
+        "pt": {"/privacidade", "/politica-de-privacidade"},
+    },
+    domain.PolicyTerms: {
+        "en": {"/terms", "/terms-of-service", "/legal/terms", "/tos", "/terms.html"},
+        "de": {"/agb", "/nutzungsbedingungen"},
+        "fr": {"/cgu", "/cgv", "/conditions-generales"},
+        "es": {"/terminos", "/terminos-y-condiciones"},
+        "nl": {"/algemene-voorwaarden", "/voorwaarden"},
+        "it": {"/termini", "/termini-e-condizioni"},
+        "pt": {"/termos", "/termos-de-uso"},
+    },
+    domain.PolicyCookies: {
+        "en": {"/cookie-policy", "/cookies", "/legal/cookies"},
+        "de": {"/cookie-richtlinie"},
+        "fr": {"/politique-cookies"},
+        "es": {"/politica-de-cookies"},
+        "nl": {"/cookiebeleid"},
+        "it": {"/cookie-policy"},
+        "pt": {"/politica-de-cookies"},
+    },
+    domain.PolicySubprocessors: {
+        "en": {"/subprocessors", "/sub-processors", "/legal/subprocessors", "/legal/sub-processors"},
+        "de": {"/unterauftragsverarbeiter"},
+        "fr": {"/sous-traitants"},
+        "es": {"/subencargados"},
+        "nl": {"/subverwerkers"},
+        "it": {"/sub-responsabili"},
+        "pt": {"/suboperadores"},
+    },
+}
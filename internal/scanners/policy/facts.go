@@ -0,0 +1,110 @@
+package policy
+
+import (
+    "context"
+    "encoding/json"
+    "strings"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/policy/rules"
+)
+
+// Sources of fact-derived signals: the deterministic rule catalog or the AI extractor.
+const (
+    rulesSource = "policy.rules"
+    aiSource    = "policy.ai"
+)
+
+// aiRulePrefix marks facts that came from the AI extractor in rules.Fact.RuleID.
+const aiRulePrefix = "ai:"
+
+// extractFacts runs the catalog, and the AI extractor when configured, over readable
+// documents and emits one signal per code from its most confident fact. Codes with
+// an absent value fall back to it when the privacy policy was read; if it could not
+// be read every code is "unknown". AI calls are metered against s.Budget.
+func (s *Scanner) extractFacts(ctx context.Context, t ports.ScanTarget, res *ports.ScanResult, docs []policyDoc) {
+    sp := s.startSpend(ctx, t)
+    var facts []rules.Fact
+    readPrivacy := false
+    refs := map[string][]string{}
+    for _, d := range docs {
+        if d.Doc.Unreadable { continue }
+        if d.Type == domain.PolicyPrivacy { readPrivacy = true }
+        for _, f := range s.Rules.Apply(d.Doc, d.Type) {
+            facts = append(facts, f)
+            refs[f.Code] = appendRef(refs[f.Code], d.Evidence)
+        }
+        if s.AI == nil || d.Type != domain.PolicyPrivacy { continue }
+        for _, out := range s.extractAI(ctx, d, sp) {
+            res.Evidence = append(res.Evidence, out.Evidence)
+            for _, f := range out.Facts {
+                facts = append(facts, f)
+                refs[f.Code] = appendRef(refs[f.Code], out.Evidence.Hash)
+            }
+        }
+    }
+
+    raw, _ := json.Marshal(map[string]any{"version": s.Rules.Version, "facts": facts})
+    ev := scanners.NewEvidence("policy.rules", "", raw, map[string]any{"version": s.Rules.Version, "facts": facts})
+    ev.Meta = map[string]any{"rules_version": s.Rules.Version}
+    if s.AI != nil {
+        ev.Meta["ai_usage"] = sp.scan
+        if sp.exceeded != "" { ev.Meta["ai_budget_exceeded"] = sp.exceeded }
+    }
+    res.Evidence = append(res.Evidence, ev)
+
+    best := map[string]rules.Fact{}
+    for _, f := range facts {
+        if cur, ok := best[f.Code]; !ok || f.Confidence > cur.Confidence { best[f.Code] = f }
+    }
+    for _, code := range s.codes() {
+        f, ok := best[code]
+        switch {
+        case ok:
+            severity, src := f.Severity, rulesSource
+            if f.Negated || f.Value == false { severity = "info" }
+            if strings.HasPrefix(f.RuleID, aiRulePrefix) { src = aiSource }
+            res.Signals = append(res.Signals, scanners.NewSignal(code, f.Value, severity, f.Confidence, src, append([]string{ev.Hash}, refs[code]...)...))
+        case !readPrivacy:
+            res.Signals = append(res.Signals, scanners.NewSignal(code, "unknown", "info", 0.3, rulesSource, ev.Hash))
+        default:
+            if v, has := s.Rules.Absent[code]; has {
+                res.Signals = append(res.Signals, scanners.NewSignal(code, v, "low", 0.4, rulesSource, ev.Hash))
+            }
+        }
+    }
+}
+
+// codes lists every code the catalog or the AI extractor can produce.
+func (s *Scanner) codes() []string {
+    out := s.Rules.Codes()
+    if s.AI == nil { return out }
+    for _, c := range ports.AICategories {
+        if code := aiCodes[c.Name]; !containsCode(out, code) { out = append(out, code) }
+    }
+    return out
+}
+
+// severityFor borrows the severity the catalog gives a code.
+func (s *Scanner) severityFor(code string) string {
+    for _, r := range s.Rules.Rules {
+        if r.Signal == code { return r.Severity }
+    }
+    return "info"
+}
+
+func appendRef(refs []string, ref string) []string {
+    for _, r := range refs {
+        if r == ref { return refs }
+    }
+    return append(refs, ref)
+}
+
+func containsCode(codes []string, code string) bool {
+    for _, c := range codes {
+        if c == code { return true }
+    }
+    return false
+}
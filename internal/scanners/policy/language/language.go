@@ -0,0 +1,80 @@
+// Package language guesses which of the supported languages a policy is written
+// in from the function words it uses. Declared languages (html lang) are often
+// left at a template's default on translated pages, so the text decides when it
+// is clear enough.
+package language
+
+import (
+    "strings"
+    "unicode"
+)
+
+// Supported lists the languages with keyword and rule catalogs, in the order
+// ties are broken.
+var Supported = []string{"en", "de", "fr", "es", "nl", "it", "pt"}
+
+// stopwords are frequent words that mostly belong to one language. Words that are
+// also common in another supported language (de, la, que, en, se, por, il, do) are
+// left out so they do not blur the counts; no word is listed twice.
+var stopwords = map[string][]string{
+    "en": {"the", "and", "of", "to", "you", "your", "we", "that", "with", "this", "or", "be", "by", "our", "are", "will", "may", "which", "from", "have"},
+    "de": {"der", "und", "ist", "nicht", "sie", "ihre", "wir", "mit", "von", "für", "werden", "dem", "eine", "auf", "zu", "ihrer", "oder", "ihnen", "wird", "sich"},
+    "fr": {"les", "et", "est", "vous", "nous", "vos", "votre", "pour", "dans", "pas", "sur", "leur", "aux", "sont", "au", "ces", "être", "qui", "cette", "avec"},
+    "es": {"el", "los", "las", "y", "usted", "sus", "nuestro", "nuestros", "nuestra", "datos", "puede", "pueden", "cuando", "estos", "también", "hemos", "tratamiento", "mediante", "cómo", "nosotros"},
+    "nl": {"het", "van", "een", "niet", "wij", "uw", "voor", "met", "op", "zijn", "worden", "dat", "ons", "onze", "gegevens", "kunnen", "wordt", "naar", "deze", "ook"},
+    "it": {"di", "che", "per", "sono", "dei", "della", "gli", "nostro", "nostri", "suoi", "dati", "nel", "alla", "questo", "delle", "essere", "nostra", "questa", "anche", "possono"},
+    "pt": {"os", "não", "dos", "você", "seus", "nós", "em", "ao", "seu", "sua", "dados", "pelo", "pela", "são", "também", "nossa", "nossos", "podem", "tratamento", "pelos"},
+}
+
+var lookup = func() map[string][]string {
+    m := map[string][]string{}
+    for lang, words := range stopwords {
+        for _, w := range words { m[w] = append(m[w], lang) }
+    }
+    return m
+}()
+
+// sample bounds how much text Detect reads; a policy's opening pages settle it.
+const sample = 20000
+
+// minHits is the number of stopwords Detect needs before it commits to an answer.
+const minHits = 12
+
+// Detect returns the most likely supported language of text and a confidence in
+// [0,1]: how far the best language's stopword count leads the runner-up's. Text
+// too short or too mixed to tell returns "" and 0.
+func Detect(text string) (string, float64) {
+    if len(text) > sample { text = text[:sample] }
+    counts := map[string]int{}
+    hits := 0
+    for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+        langs, ok := lookup[w]
+        if !ok { continue }
+        hits++
+        for _, l := range langs { counts[l]++ }
+    }
+    if hits < minHits { return "", 0 }
+    best, second := "", 0
+    for _, l := range Supported {
+        switch n := counts[l]; {
+        case best == "" || n > counts[best]:
+            if best != "" { second = counts[best] }
+            best = l
+        case n > second:
+            second = n
+        }
+    }
+    if counts[best] == 0 { return "", 0 }
+    return best, float64(counts[best]-second) / float64(counts[best])
+}
+
+// threshold is the confidence at which the text overrides a declared language.
+const threshold = 0.3
+
+// Resolve returns the language of a document that declares declared (a primary
+// subtag, possibly empty): the detected language when the text is clear about it,
+// otherwise declared.
+func Resolve(declared, text string) string {
+    if lang, conf := Detect(text); lang != "" && conf >= threshold { return lang }
+    return declared
+}
@@ -0,0 +1,188 @@
+package policy
+
+import (
+    "context"
+    "encoding/json"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/geo"
+    "camille/internal/scanners/policy/metadata"
+)
+
+// staleAfter is how long since its stated date a policy may go before it counts as stale.
+const staleAfter = 5 * 365 * 24 * time.Hour
+
+// extractMetadata reads dates, contacts and cited laws from readable documents and
+// stores them as quoted evidence. Each document reports whether it is dated and,
+// when it is, how old and whether stale; contact signals follow the privacy policy.
+func (s *Scanner) extractMetadata(ctx context.Context, res *ports.ScanResult, docs []policyDoc) {
+    now := time.Now().UTC()
+    var items []metadata.Item
+    byType := map[string][]metadata.Item{}
+    readPrivacy := false
+    for _, d := range docs {
+        if d.Doc.Unreadable { continue }
+        if d.Type == domain.PolicyPrivacy { readPrivacy = true }
+        its := metadata.Extract(d.Doc, d.Type, now)
+        byType[d.Type] = its
+        items = append(items, its...)
+    }
+    if len(byType) == 0 { return }
+    if items == nil { items = []metadata.Item{} }
+    raw, _ := json.Marshal(items)
+    ev := scanners.NewEvidence("policy.metadata", "", raw, map[string]any{"items": items})
+    res.Evidence = append(res.Evidence, ev)
+
+    for _, d := range docs {
+        its, ok := byType[d.Type]
+        if !ok { continue }
+        refs := []string{ev.Hash, d.Evidence}
+        prefix := "policy." + d.Type + "."
+        updated, hasUpdated := metadata.Find(its, metadata.LastUpdated)
+        effective, hasEffective := metadata.Find(its, metadata.Effective)
+        res.Signals = append(res.Signals, scanners.NewSignal(prefix+"dated", hasUpdated || hasEffective, "info", 0.8, source, refs...))
+        if hasUpdated { res.Signals = append(res.Signals, scanners.NewSignal(prefix+"updated", updated.Value, "info", 0.8, source, refs...)) }
+        if hasEffective { res.Signals = append(res.Signals, scanners.NewSignal(prefix+"effective", effective.Value, "info", 0.8, source, refs...)) }
+        if date, ok := latestDate(updated.Value, effective.Value); ok {
+            age := now.Sub(date)
+            stale, severity := age > staleAfter, "info"
+            if stale { severity = "medium" }
+            res.Signals = append(res.Signals,
+                scanners.NewSignal(prefix+"age_days", int(age.Hours()/24), "info", 0.8, source, refs...),
+                scanners.NewSignal(prefix+"stale", stale, severity, 0.8, source, refs...))
+        }
+        if law, ok := metadata.Find(its, metadata.GoverningLaw); ok {
+            res.Signals = append(res.Signals, scanners.NewSignal(prefix+"governing_law", law.Value, "info", 0.6, source, refs...))
+        }
+    }
+
+    seen := map[string]bool{}
+    for _, l := range metadata.All(items, metadata.Law) {
+        if seen[l.Value] { continue }
+        seen[l.Value] = true
+        res.Signals = append(res.Signals, scanners.NewSignal("policy.law."+l.Value+".referenced", true, "info", 0.9, source, ev.Hash))
+    }
+
+    var ages []int
+    for _, a := range metadata.All(items, metadata.ChildAge) {
+        if n, err := strconv.Atoi(a.Value); err == nil && !containsInt(ages, n) { ages = append(ages, n) }
+    }
+    if len(ages) > 0 {
+        sort.Ints(ages)
+        res.Signals = append(res.Signals, scanners.NewSignal("policy.children.ages", ages, "info", 0.7, source, ev.Hash))
+    }
+    if addr, ok := metadata.Find(items, metadata.Address); ok {
+        if cs := geo.Countries(addr.Value); len(cs) == 1 {
+            res.Signals = append(res.Signals, scanners.NewSignal("policy.contact.address.country", cs[0], "info", 0.6, source, ev.Hash))
+        }
+        if rs := geo.Regions(addr.Value); len(rs) > 0 {
+            res.Signals = append(res.Signals, scanners.NewSignal("policy.contact.address.region", rs[0], "info", 0.6, source, ev.Hash))
+        }
+    }
+
+    if !readPrivacy { return }
+    present := func(code, field string, confidence float64) {
+        _, ok := metadata.Find(items, field)
+        severity := "info"
+        if !ok { severity = "low" }
+        res.Signals = append(res.Signals, scanners.NewSignal(code, ok, severity, confidence, source, ev.Hash))
+    }
+    present("policy.contact.controller.named", metadata.Controller, 0.7)
+    present("policy.contact.dpo.named", metadata.DPO, 0.7)
+    present("policy.contact.address.present", metadata.Address, 0.6)
+    present("policy.contact.dsr.email_present", metadata.DSREmail, 0.8)
+    present("policy.contact.dsr.form_present", metadata.DSRForm, 0.7)
+    s.checkMX(ctx, res, metadata.All(items, metadata.DSREmail))
+}
+
+func containsInt(xs []int, n int) bool {
+    for _, x := range xs {
+        if x == n { return true }
+    }
+    return false
+}
+
+// latestDate is the later of two stated dates; either may be empty.
+func latestDate(a, b string) (time.Time, bool) {
+    var out time.Time
+    for _, v := range []string{a, b} {
+        if t, err := time.Parse("2006-01-02", v); err == nil && t.After(out) { out = t }
+    }
+    return out, !out.IsZero()
+}
+
+// mxResult is the MX lookup for one DSR email domain.
+type mxResult struct {
+    Domain string   `json:"domain"`
+    Emails []string `json:"emails"`
+    MX     []string `json:"mx"`
+    // Implicit is set when the domain has no MX and mail goes to its A/AAAA address.
+    Implicit bool   `json:"implicit,omitempty"`
+    Valid    bool   `json:"valid"`
+    Error    string `json:"error,omitempty"`
+}
+
+// checkMX looks up the mail exchangers of every DSR email domain. A domain without
+// MX records falls back to its A/AAAA address (RFC 5321 §5.1); only a null MX
+// (RFC 7505), or no MX and no address, cannot receive requests. Lookups that fail
+// in transit are recorded but decide nothing.
+func (s *Scanner) checkMX(ctx context.Context, res *ports.ScanResult, emails []metadata.Item) {
+    if s.Resolver == nil || len(emails) == 0 { return }
+    byDomain := map[string]*mxResult{}
+    var domains []string
+    for _, e := range emails {
+        _, host, _ := strings.Cut(e.Value, "@")
+        r := byDomain[host]
+        if r == nil {
+            r = &mxResult{Domain: host, MX: []string{}}
+            byDomain[host] = r
+            domains = append(domains, host)
+        }
+        r.Emails = append(r.Emails, e.Value)
+    }
+    sort.Strings(domains)
+    results := make([]mxResult, 0, len(domains))
+    checked, valid := 0, true
+    for _, host := range domains {
+        r := byDomain[host]
+        recs, err := s.Resolver.LookupMX(ctx, host)
+        if err != nil {
+            if ctx.Err() != nil { return }
+            r.Error = err.Error()
+            results = append(results, *r)
+            continue
+        }
+        for _, mx := range recs {
+            if h := strings.TrimSuffix(mx.Host, "."); h != "" {
+                r.MX = append(r.MX, h)
+                r.Valid = true
+            }
+        }
+        if len(recs) == 0 {
+            ips, err := s.Resolver.LookupIP(ctx, host)
+            if err != nil {
+                if ctx.Err() != nil { return }
+                r.Error = err.Error()
+                results = append(results, *r)
+                continue
+            }
+            r.Implicit, r.Valid = len(ips) > 0, len(ips) > 0
+        }
+        checked++
+        valid = valid && r.Valid
+        results = append(results, *r)
+    }
+    raw, _ := json.Marshal(results)
+    ev := scanners.NewEvidence("policy.dsr.mx", "", raw, map[string]any{"domains": results})
+    res.Evidence = append(res.Evidence, ev)
+    if checked == 0 { return }
+    severity := "info"
+    if !valid { severity = "medium" }
+    res.Signals = append(res.Signals, scanners.NewSignal("policy.contact.dsr.email.mx_valid", valid, severity, 0.9, source, ev.Hash))
+}
@@ -0,0 +1,431 @@
+// Package metadata reads the structured facts a policy states about itself: when
+// it took effect or was last updated, who the controller and DPO are, where to
+// write, how to file a data subject request, which laws it cites and from what age
+// it lets children use the service. Every item
+// keeps the exact span and sentence it was read from.
+package metadata
+
+import (
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/textutil"
+)
+
+// Item fields.
+const (
+    LastUpdated  = "last_updated"
+    Effective    = "effective_date"
+    Controller   = "controller"
+    DPO          = "dpo"
+    Address      = "address"
+    DSREmail     = "dsr_email"
+    DSRForm      = "dsr_form"
+    Law          = "law"
+    GoverningLaw = "governing_law"
+    ChildAge     = "child_age"
+)
+
+// maxQuote bounds the sentence stored with an item.
+const maxQuote = 300
+
+// Item is one metadata value. Quote is the sentence around Document.Text[SpanStart:SpanEnd].
+type Item struct {
+    Field      string `json:"field"`
+    Value      string `json:"value"`
+    Quote      string `json:"quote"`
+    SpanStart  int    `json:"span_start"`
+    SpanEnd    int    `json:"span_end"`
+    DocType    string `json:"doc_type,omitempty"`
+    Section    string `json:"section,omitempty"`
+    SectionURL string `json:"section_url"`
+}
+
+// Find returns the first item of field.
+func Find(items []Item, field string) (Item, bool) {
+    for _, it := range items {
+        if it.Field == field { return it, true }
+    }
+    return Item{}, false
+}
+
+// All returns every item of field.
+func All(items []Item, field string) []Item {
+    var out []Item
+    for _, it := range items {
+        if it.Field == field { out = append(out, it) }
+    }
+    return out
+}
+
+// Extract reads every metadata item from doc, in field order and then by position.
+// Dates after now (plus a day of slack for time zones) are ignored.
+func Extract(doc ports.Document, typ string, now time.Time) []Item {
+    x := extractor{doc: doc, typ: typ}
+    x.dates(now)
+    x.controller()
+    x.dpo()
+    x.address()
+    x.contacts()
+    x.laws()
+    x.childAge()
+    return x.out
+}
+
+type extractor struct {
+    doc ports.Document
+    typ string
+    out []Item
+}
+
+func (x *extractor) add(field, value string, start, end int) {
+    text := x.doc.Text
+    qs, qe := textutil.Sentence(text, start, end, maxQuote)
+    it := Item{Field: field, Value: value, Quote: text[qs:qe], SpanStart: start, SpanEnd: end, DocType: x.typ, SectionURL: x.doc.URL}
+    if sec, ok := x.doc.SectionAt(start); ok {
+        it.Section = sec.Heading
+        it.SectionURL = x.doc.SectionURL(sec)
+    }
+    x.out = append(x.out, it)
+}
+
+var (
+    dateLabel = regexp.MustCompile(`(?i)\b(last\s+(?:updated|modified|revised|amended)|updated|revised|revision\s+date|effective(?:\s+date)?|in\s+effect)\b(?:\s+(?:on|as\s+of|from|since))?\s*[:\-–—]?\s*`)
+    month     = `(?i:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?`
+    dateExpr  = regexp.MustCompile(`^(?:` +
+        `(\d{4})-(\d{1,2})-(\d{1,2})` +
+        `|(` + month + `)\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})` +
+        `|(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?(` + month + `),?\s+(\d{4})` +
+        `|(\d{1,2})([/.])(\d{1,2})[/.](\d{4})` +
+        `|(` + month + `),?\s+(\d{4})` +
+        `)\b`)
+)
+
+// dates finds labelled dates: "Last updated: March 3, 2024", "Effective as of
+// 1 January 2023". Slashed dates read as month/day unless that is impossible;
+// dotted ones as day.month. Documents in a language with a dateLocale are also
+// read with its labels and month names ("Stand: 1. März 2024").
+func (x *extractor) dates(now time.Time) {
+    text := x.doc.Text
+    found := map[string]bool{}
+    keep := func(field string, t time.Time, ok bool, start, end int) {
+        if !ok || t.Year() < 1990 || t.After(now.Add(24*time.Hour)) { return }
+        found[field] = true
+        x.add(field, t.Format("2006-01-02"), start, end)
+    }
+    for _, m := range dateLabel.FindAllStringSubmatchIndex(text, -1) {
+        field := LastUpdated
+        if label := strings.ToLower(text[m[2]:m[3]]); strings.Contains(label, "effective") || strings.Contains(label, "in effect") { field = Effective }
+        if found[field] { continue }
+        d := dateExpr.FindStringSubmatchIndex(text[m[1]:])
+        if d == nil { continue }
+        t, ok := parseDate(text[m[1]:], d)
+        keep(field, t, ok, m[0], m[1]+d[1])
+    }
+    loc, ok := dateLocales[x.doc.Language]
+    if !ok { return }
+    for _, m := range loc.label.FindAllStringSubmatchIndex(text, -1) {
+        field, start := LastUpdated, m[2]
+        if m[4] >= 0 { field, start = Effective, m[4] }
+        if found[field] { continue }
+        d := loc.expr.FindStringSubmatchIndex(text[m[1]:])
+        if d == nil { continue }
+        t, ok := loc.parse(text[m[1]:], d)
+        keep(field, t, ok, start, m[1]+d[1])
+    }
+}
+
+func parseDate(s string, d []int) (time.Time, bool) {
+    g := func(i int) string {
+        if d[2*i] < 0 { return "" }
+        return s[d[2*i]:d[2*i+1]]
+    }
+    var y, mo, day int
+    switch {
+    case g(1) != "":
+        y, mo, day = textutil.Atoi(g(1)), textutil.Atoi(g(2)), textutil.Atoi(g(3))
+    case g(4) != "":
+        y, mo, day = textutil.Atoi(g(6)), monthNum(g(4)), textutil.Atoi(g(5))
+    case g(7) != "":
+        y, mo, day = textutil.Atoi(g(9)), monthNum(g(8)), textutil.Atoi(g(7))
+    case g(10) != "":
+        a, b := textutil.Atoi(g(10)), textutil.Atoi(g(12))
+        y, mo, day = textutil.Atoi(g(13)), a, b
+        if g(11) == "." || a > 12 { mo, day = b, a }
+    case g(14) != "":
+        y, mo, day = textutil.Atoi(g(15)), monthNum(g(14)), 1
+    }
+    return makeDate(y, mo, day)
+}
+
+func makeDate(y, mo, day int) (time.Time, bool) {
+    if mo < 1 || mo > 12 || day < 1 || day > 31 { return time.Time{}, false }
+    t := time.Date(y, time.Month(mo), day, 0, 0, 0, 0, time.UTC)
+    if t.Day() != day { return time.Time{}, false }
+    return t, true
+}
+
+func monthNum(s string) int {
+    s = strings.ToLower(s)
+    for i, m := range []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"} {
+        if strings.HasPrefix(s, m) { return i + 1 }
+    }
+    return 0
+}
+
+// dateLocale is how one language labels and writes a policy's dates. Label
+// group 1 is a last-updated label, group 2 an effective-date one.
+type dateLocale struct {
+    label  *regexp.Regexp
+    expr   *regexp.Regexp
+    months map[string]int
+}
+
+// newDateLocale builds a dateLocale from label alternations and month names,
+// January first; "|" separates spellings of one month.
+func newDateLocale(updated, effective string, months ...string) dateLocale {
+    loc := dateLocale{months: map[string]int{}}
+    var names []string
+    for i, m := range months {
+        for _, n := range strings.Split(m, "|") {
+            loc.months[n] = i + 1
+            names = append(names, n)
+        }
+    }
+    month := `(?i:` + strings.Join(names, "|") + `)`
+    loc.label = regexp.MustCompile(`(?i)(?:^|[^\p{L}])(?:(` + updated + `)|(` + effective + `))\s*[:\-–—]?\s*`)
+    loc.expr = regexp.MustCompile(`^(?:` +
+        `(\d{4})-(\d{1,2})-(\d{1,2})` +
+        `|(\d{1,2})(?:\.|er|º|°)?\s+(?:de\s+)?(` + month + `)\s+(?:de\s+)?(\d{4})` +
+        `|(\d{1,2})[/.](\d{1,2})[/.](\d{4})` +
+        `|(` + month + `)\s+(?:de\s+)?(\d{4})` +
+        `)\b`)
+    return loc
+}
+
+// parse reads a loc.expr match. Numeric dates read day first, as they are
+// written everywhere these languages are, unless that is impossible.
+func (loc dateLocale) parse(s string, d []int) (time.Time, bool) {
+    g := func(i int) string {
+        if d[2*i] < 0 { return "" }
+        return s[d[2*i]:d[2*i+1]]
+    }
+    month := func(i int) int { return loc.months[strings.ToLower(g(i))] }
+    switch {
+    case g(1) != "":
+        return makeDate(textutil.Atoi(g(1)), textutil.Atoi(g(2)), textutil.Atoi(g(3)))
+    case g(4) != "":
+        return makeDate(textutil.Atoi(g(6)), month(5), textutil.Atoi(g(4)))
+    case g(7) != "":
+        a, b := textutil.Atoi(g(7)), textutil.Atoi(g(8))
+        if b > 12 { a, b = b, a }
+        return makeDate(textutil.Atoi(g(9)), b, a)
+    case g(10) != "":
+        return makeDate(textutil.Atoi(g(11)), month(10), 1)
+    }
+    return time.Time{}, false
+}
+
+// dateLocales are keyed by language code; English is dateLabel and dateExpr.
+var dateLocales = map[string]dateLocale{
+    "de": newDateLocale(
+        `zuletzt (?:aktualisiert|geändert|überarbeitet)(?: am)?|aktualisiert am|stand(?: vom)?`,
+        `gültig (?:ab|seit)(?: dem)?|in kraft seit(?: dem)?|wirksam ab`,
+        "januar|jänner", "februar", "märz|maerz", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "dezember"),
+    "fr": newDateLocale(
+        `dernière (?:mise à jour|modification)(?: le)?|mise à jour le|date de mise à jour`,
+        `en vigueur (?:à compter du|à partir du|depuis le|le|au)|date d['’]entrée en vigueur|applicable à compter du`,
+        "janvier", "février|fevrier", "mars", "avril", "mai", "juin", "juillet", "août|aout", "septembre", "octobre", "novembre", "décembre|decembre"),
+    "es": newDateLocale(
+        `última (?:actualización|modificación)(?: el)?|actualizad[oa] el|fecha de actualización`,
+        `(?:vigente|en vigor) (?:desde el|desde|a partir del|a partir de)|fecha de entrada en vigor`,
+        "enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre|setiembre", "octubre", "noviembre", "diciembre"),
+    "nl": newDateLocale(
+        `laatst (?:bijgewerkt|gewijzigd|aangepast)(?: op)?|bijgewerkt op`,
+        `(?:van kracht|geldig) (?:vanaf|sinds|per)|ingangsdatum|in werking getreden op`,
+        "januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"),
+    "it": newDateLocale(
+        `ultimo aggiornamento(?: il| al)?|ultima (?:modifica|revisione)(?: il)?|aggiornat[ao] (?:il|al)`,
+        `in vigore (?:dal|a partire dal)|efficace dal|data di entrata in vigore|valid[ao] dal`,
+        "gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"),
+    "pt": newDateLocale(
+        `última (?:atualização|actualização|modificação|revisão)(?: em)?|atualizad[oa] em|data de atualização`,
+        `(?:em vigor|vigente) (?:desde|a partir de)|data de (?:vigência|entrada em vigor)|válid[oa] a partir de`,
+        "janeiro", "fevereiro", "março|marco", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"),
+}
+
+// name is a run of capitalised words, allowing connectives inside company names.
+const name = `([A-Z][\w&.'’-]*(?:,?\s+(?:[A-Z][\w&.'’-]*|&|and|of|de|du|der|und)){0,7})`
+
+var (
+    controllerPatterns = []*regexp.Regexp{
+        regexp.MustCompile(`(?i:(?:data\s+)?controller)(?i:\s+(?:of|for|responsible\s+for)\s+(?:your\s+|the\s+)?(?:personal\s+)?(?:data|information)(?:\s+\w+){0,4})?\s+(?i:is|will\s+be)\s+` + name),
+        regexp.MustCompile(`(?i:data\s+controller|controller)\s*[:–—-]\s*` + name),
+        regexp.MustCompile(name + `\s+(?:\([^)]{0,80}\)\s*,?\s*)?(?i:is|acts\s+as|will\s+be)\s+(?i:the\s+)?(?i:data\s+)?(?i:controller)\b`),
+    }
+    // notNames are capitalised words that start sentences rather than name anyone.
+    notNames = map[string]bool{"We": true, "Our": true, "This": true, "It": true, "You": true, "The": true, "Who": true, "In": true, "For": true, "If": true}
+)
+
+// controller finds a named data controller.
+func (x *extractor) controller() {
+    for _, re := range controllerPatterns {
+        for _, m := range re.FindAllStringSubmatchIndex(x.doc.Text, -1) {
+            if v, ok := cleanName(x.doc.Text[m[2]:m[3]]); ok {
+                x.add(Controller, v, m[0], m[1])
+                return
+            }
+        }
+    }
+}
+
+var (
+    dpoCue   = regexp.MustCompile(`(?i)\bdata\s+protection\s+officer\b|\bDPO\b`)
+    dpoName  = regexp.MustCompile(`^\s*(?:\(DPO\)\s*)?(?:,|:|–|—|-|\bis\b)\s*` + name)
+    emailRe  = regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`)
+    urlRe    = regexp.MustCompile(`\bhttps?://[^\s<>"'()\[\]]+`)
+    dsrCue   = regexp.MustCompile(`(?i)\b(request|rights?|access|delet\w*|eras\w*|rectif\w*|exercise|opt[- ]out|do not sell|privacy|data protection|unsubscribe|portability)\b`)
+    dsrLocal = regexp.MustCompile(`(?i)^(privacy|dpo|dataprotection|data-protection|data\.protection|gdpr|ccpa|dsar|dsr|legal|compliance|rights|datenschutz)`)
+    dsrPath  = regexp.MustCompile(`(?i)(privacy|dsar|dsr|request|rights|opt-?out|do-not-sell|form|webform|gdpr|ccpa)`)
+)
+
+// dpoWindow is how far after a DPO mention its name or email may appear.
+const dpoWindow = 160
+
+// dpo finds a named data protection officer, or their email.
+func (x *extractor) dpo() {
+    text := x.doc.Text
+    for _, m := range dpoCue.FindAllStringIndex(text, -1) {
+        after := text[m[1]:min(len(text), m[1]+dpoWindow)]
+        if n := dpoName.FindStringSubmatchIndex(after); n != nil {
+            if v, ok := cleanName(after[n[2]:n[3]]); ok && !strings.Contains(strings.ToLower(v), "officer") {
+                x.add(DPO, v, m[0], m[1]+n[3])
+                return
+            }
+        }
+        if e := emailRe.FindStringIndex(after); e != nil {
+            x.add(DPO, strings.ToLower(after[e[0]:e[1]]), m[0], m[1]+e[1])
+            return
+        }
+    }
+}
+
+var addressPatterns = []*regexp.Regexp{
+    regexp.MustCompile(`\b\d{1,5}\s+(?:[A-Z][\w'’-]*\s+){1,4}(?i:street|st\.|avenue|ave\.?|road|rd\.|boulevard|blvd\.?|lane|ln\.|drive|dr\.|way|place|pl\.|court|ct\.|square|sq\.|parkway|pkwy\.?|suite)\b`),
+    regexp.MustCompile(`\b[A-ZÄÖÜ][a-zäöüß]+(?:straße|strasse|str\.|weg|platz|allee|gasse|ring)\s+\d+[a-z]?\b`),
+    regexp.MustCompile(`\b(?:rue|avenue|boulevard|calle|avenida|via|rua|plaza)\s+(?:de\s+|du\s+|des\s+|del\s+|la\s+)?[A-Z][\w'’-]+`),
+    regexp.MustCompile(`,\s*[A-Z]{2}\s+\d{5}(?:-\d{4})?\b`),
+    regexp.MustCompile(`\b[A-Z]{1,2}\d[A-Z\d]?\s+\d[A-Z]{2}\b`),
+}
+
+// addressLead strips the words introducing an address from its sentence.
+var addressLead = regexp.MustCompile(`(?i)^.*?\b(?:located at|is at|are at|address(?:\s+is)?:?|write to(?:\s+us)?(?:\s+at)?|mail(?:ing)?\s+address:?)\s+`)
+
+// address finds the first sentence that reads like a postal address and keeps the
+// address part of it: from the street when one matched, else after its lead-in.
+func (x *extractor) address() {
+    text := x.doc.Text
+    best, street := -1, false
+    var span []int
+    for i, re := range addressPatterns {
+        if m := re.FindStringIndex(text); m != nil && (best < 0 || m[0] < best) { best, span, street = m[0], m, i < 3 }
+    }
+    if span == nil { return }
+    qs, qe := textutil.Sentence(text, span[0], span[1], maxQuote)
+    vs := qs
+    if street { vs = span[0] }
+    v := text[vs:qe]
+    if loc := addressLead.FindStringIndex(v); loc != nil && !street { v = v[loc[1]:] }
+    v = strings.TrimRight(strings.Join(strings.Fields(v), " "), ".")
+    x.add(Address, v, span[0], span[1])
+}
+
+// contacts finds emails and form URLs offered for data subject requests: those
+// with a privacy-flavoured address or in a sentence with a rights cue.
+func (x *extractor) contacts() {
+    text := x.doc.Text
+    seen := map[string]bool{}
+    near := func(start, end int) bool {
+        qs, qe := textutil.Sentence(text, start, end, maxQuote)
+        return dsrCue.MatchString(text[qs:start] + " " + text[end:qe])
+    }
+    for _, m := range emailRe.FindAllStringIndex(text, -1) {
+        addr := strings.ToLower(text[m[0]:m[1]])
+        if seen[addr] { continue }
+        if local, _, _ := strings.Cut(addr, "@"); !dsrLocal.MatchString(local) && !near(m[0], m[1]) { continue }
+        seen[addr] = true
+        x.add(DSREmail, addr, m[0], m[1])
+    }
+    for _, m := range urlRe.FindAllStringIndex(text, -1) {
+        u := strings.TrimRight(text[m[0]:m[1]], ".,;:!?")
+        end := m[0] + len(u)
+        if seen[u] || !dsrPath.MatchString(u[strings.Index(u, "//")+2:]) || !near(m[0], end) { continue }
+        seen[u] = true
+        x.add(DSRForm, u, m[0], end)
+    }
+}
+
+// laws are the statutes a policy may cite, by code. A match directly after
+// notAfter is skipped, so "UK GDPR" does not also count as the EU GDPR.
+var laws = []struct {
+    code, notAfter string
+    re             *regexp.Regexp
+}{
+    {"gdpr", "UK ", regexp.MustCompile(`\bGDPR\b|(?i)\bGeneral Data Protection Regulation\b|\bRegulation \(EU\) 2016/679\b`)},
+    {"uk_gdpr", "", regexp.MustCompile(`\bUK GDPR\b|(?i)\bUnited Kingdom General Data Protection Regulation\b|\bData Protection Act 2018\b`)},
+    {"ccpa", "", regexp.MustCompile(`\bCCPA\b|(?i)\bCalifornia Consumer Privacy Act\b`)},
+    {"cpra", "", regexp.MustCompile(`\bCPRA\b|(?i)\bCalifornia Privacy Rights Act\b`)},
+    {"lgpd", "", regexp.MustCompile(`\bLGPD\b|(?i)\bLei Geral de Prote[çc][ãa]o de Dados\b|\bBrazilian General Data Protection Law\b`)},
+    {"pipeda", "", regexp.MustCompile(`\bPIPEDA\b|(?i)\bPersonal Information Protection and Electronic Documents Act\b`)},
+}
+
+var governingLaw = regexp.MustCompile(`(?i:governed\s+by(?:\s+and\s+construed\s+in\s+accordance\s+with)?\s+the\s+laws?\s+of)\s+(?:the\s+)?(?i:(?:state|commonwealth|province|republic|kingdom)\s+of\s+)?([A-Z][A-Za-z]+(?:\s+[A-Z][A-Za-z]+){0,3}(?:\s+and\s+[A-Z][A-Za-z]+)?)`)
+
+// laws records the first citation of each statute and any governing-law clause.
+func (x *extractor) laws() {
+    text := x.doc.Text
+    for _, l := range laws {
+        for _, m := range l.re.FindAllStringIndex(text, -1) {
+            if l.notAfter != "" && strings.HasSuffix(text[:m[0]], l.notAfter) { continue }
+            x.add(Law, l.code, m[0], m[1])
+            break
+        }
+    }
+    if m := governingLaw.FindStringSubmatchIndex(text); m != nil {
+        x.add(GoverningLaw, text[m[2]:m[3]], m[0], m[1])
+    }
+}
+
+// childAgeExpr matches the age a policy sets for children: "under the age of 16",
+// "under 13", "at least 18 years old", "younger than 16".
+var childAgeExpr = regexp.MustCompile(`(?i)\b(?:(?:children|minors|kids|persons|individuals|anyone|users)\b[^.]{0,60}?\bunder(?:\s+the\s+age\s+of)?|under\s+the\s+age\s+of|younger\s+than|at\s+least)\s+(\d{1,2})\b(?:\s+years)?`)
+
+// childAge records each distinct age between 10 and 21 the policy sets for children.
+func (x *extractor) childAge() {
+    seen := map[string]bool{}
+    for _, m := range childAgeExpr.FindAllStringSubmatchIndex(x.doc.Text, -1) {
+        age := x.doc.Text[m[2]:m[3]]
+        if n, _ := strconv.Atoi(age); n < 10 || n > 21 || seen[age] { continue }
+        seen[age] = true
+        x.add(ChildAge, age, m[0], m[1])
+    }
+}
+
+// corporate suffixes keep their trailing period.
+var corporate = regexp.MustCompile(`(?i)\b(?:ltd|inc|corp|co|llc|plc|s\.a|b\.v|gmbh|ag)\.$`)
+
+// cleanName trims trailing punctuation and connectives and rejects pronouns.
+func cleanName(s string) (string, bool) {
+    s = strings.TrimRight(strings.TrimSpace(s), ",;:")
+    if !corporate.MatchString(s) { s = strings.TrimRight(s, ".") }
+    for _, w := range []string{" and", " of", " de", " du", " der", " und", " &"} {
+        s = strings.TrimSuffix(s, w)
+    }
+    first, _, _ := strings.Cut(s, " ")
+    if s == "" || notNames[first] { return "", false }
+    return s, true
+}
+
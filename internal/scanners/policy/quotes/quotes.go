@@ -0,0 +1,141 @@
+// Package quotes locates model-returned quotes in their source text. Models
+// paraphrase and re-punctuate, so matching runs on a normalized form (case,
+// Unicode compatibility forms, punctuation and whitespace folded) and falls back
+// to approximate substring search; offsets are always mapped back to the source.
+package quotes
+
+import (
+    "strings"
+    "unicode"
+
+    "golang.org/x/text/unicode/norm"
+)
+
+// DefaultThreshold is the minimum similarity for a quote to count as found.
+const DefaultThreshold = 0.85
+
+// maxCells bounds approximate search work (text runes × quote runes).
+const maxCells = 64 << 20
+
+// Match is a quote's location in the source. Start and End are byte offsets;
+// Similarity is 1 - edit distance / normalized quote length.
+type Match struct {
+    Start      int
+    End        int
+    Similarity float64
+    Exact      bool
+}
+
+// Find locates quote in text. It reports false when no substring reaches threshold.
+func Find(text, quote string, threshold float64) (Match, bool) {
+    if i := strings.Index(text, quote); i >= 0 && quote != "" {
+        return Match{Start: i, End: i + len(quote), Similarity: 1, Exact: true}, true
+    }
+    src := normalize(text)
+    q := normalize(quote)
+    if len(q.runes) == 0 || len(src.runes) == 0 { return Match{}, false }
+
+    if i := indexRunes(src.runes, q.runes); i >= 0 {
+        return Match{Start: src.start[i], End: src.end[i+len(q.runes)-1], Similarity: 1}, true
+    }
+    if len(src.runes)*len(q.runes) > maxCells { return Match{}, false }
+    s, e, dist := approximate(src.runes, q.runes)
+    sim := 1 - float64(dist)/float64(len(q.runes))
+    if sim < threshold || e <= s { return Match{}, false }
+    return Match{Start: src.start[s], End: src.end[e-1], Similarity: sim}, true
+}
+
+// normalized is folded text with, per rune, the byte range it came from.
+type normalized struct {
+    runes      []rune
+    start, end []int
+}
+
+// normalize lower-cases, applies NFKC, maps typographic quotes and dashes to ASCII,
+// drops other punctuation and collapses whitespace runs to one space.
+func normalize(s string) normalized {
+    var n normalized
+    space := true
+    for i, r := range s {
+        width := len(string(r))
+        if r == unicode.ReplacementChar { width = 1 }
+        for _, f := range norm.NFKC.String(string(r)) {
+            f = fold(f)
+            switch {
+            case f == 0:
+                continue
+            case unicode.IsSpace(f):
+                if space { continue }
+                space = true
+                f = ' '
+            default:
+                space = false
+            }
+            n.runes = append(n.runes, f)
+            n.start = append(n.start, i)
+            n.end = append(n.end, i+width)
+        }
+    }
+    if len(n.runes) > 0 && n.runes[len(n.runes)-1] == ' ' {
+        n.runes, n.start, n.end = n.runes[:len(n.runes)-1], n.start[:len(n.start)-1], n.end[:len(n.end)-1]
+    }
+    return n
+}
+
+// fold maps a rune to its comparison form; 0 drops it.
+func fold(r rune) rune {
+    switch r {
+    case '‘', '’', '‚', '‛', '′', '`', '´':
+        return 0
+    case '“', '”', '„', '‟', '″', '"', '\'':
+        return 0
+    case '‐', '‑', '‒', '–', '—', '―', '−':
+        return ' '
+    case '\u00ad', '\u200b', '\u200c', '\u200d', '\ufeff':
+        return 0
+    }
+    if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+        if r == '@' || r == '%' || r == '$' || r == '€' || r == '£' { return r }
+        return ' '
+    }
+    return unicode.ToLower(r)
+}
+
+func indexRunes(s, sub []rune) int {
+outer:
+    for i := 0; i+len(sub) <= len(s); i++ {
+        for j := range sub {
+            if s[i+j] != sub[j] { continue outer }
+        }
+        return i
+    }
+    return -1
+}
+
+// approximate finds the substring of text with the least edit distance to pat
+// (Sellers' algorithm), returning its rune range [start, end) and the distance.
+func approximate(text, pat []rune) (start, end, dist int) {
+    m := len(pat)
+    prev := make([]int, m+1)
+    cur := make([]int, m+1)
+    prevStart := make([]int, m+1)
+    curStart := make([]int, m+1)
+    for j := range prev { prev[j] = j }
+    best, bestEnd, bestStart := m+1, 0, 0
+    for i := 1; i <= len(text); i++ {
+        cur[0], curStart[0] = 0, i
+        for j := 1; j <= m; j++ {
+            cost := 1
+            if text[i-1] == pat[j-1] { cost = 0 }
+            d, s := prev[j-1]+cost, prevStart[j-1]
+            if j == 1 { s = i - 1 }
+            if v := prev[j] + 1; v < d { d, s = v, prevStart[j] }
+            if v := cur[j-1] + 1; v < d { d, s = v, curStart[j-1] }
+            cur[j], curStart[j] = d, s
+        }
+        if cur[m] < best { best, bestEnd, bestStart = cur[m], i, curStart[m] }
+        prev, cur = cur, prev
+        prevStart, curStart = curStart, prevStart
+    }
+    return bestStart, bestEnd, best
+}
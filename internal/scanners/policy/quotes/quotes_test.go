@@ -0,0 +1,34 @@
+package quotes
+
+import "testing"
+
+func TestFind(t *testing.T) {
+    const text = "Wir verkaufen Ihre Daten nicht.\nWe “never” sell your personal   information — ever.\nCookies are kept for 13 months."
+    for _, tc := range []struct {
+        name   string
+        quote  string
+        want   string // source text the match must cover; empty for no match
+        exact  bool
+        approx bool
+    }{
+        {"exact", "Cookies are kept for 13 months.", "Cookies are kept for 13 months.", true, false},
+        {"case, quotes, dashes and spacing", `we "never" sell your personal information - ever`, "We “never” sell your personal   information — ever", false, false},
+        {"multi-byte runes", "wir verkaufen ihre daten nicht", "Wir verkaufen Ihre Daten nicht", false, false},
+        {"paraphrased", "We never sell your personal informations", "We “never” sell your personal   information", false, true},
+        {"unrelated", "We share your data with advertisers", "", false, false},
+        {"empty", "", "", false, false},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            m, ok := Find(text, tc.quote, DefaultThreshold)
+            if ok != (tc.want != "") { t.Fatalf("found = %v, want %v (%+v)", ok, tc.want != "", m) }
+            if !ok { return }
+            if got := text[m.Start:m.End]; got != tc.want { t.Errorf("matched %q, want %q", got, tc.want) }
+            if m.Exact != tc.exact { t.Errorf("Exact = %v, want %v", m.Exact, tc.exact) }
+            if approx := m.Similarity < 1; approx != tc.approx { t.Errorf("Similarity = %.2f", m.Similarity) }
+        })
+    }
+}
+
+func TestFindBelowThreshold(t *testing.T) {
+    if m, ok := Find("We keep logs for thirty days.", "We keep receipts for ten years.", DefaultThreshold); ok { t.Errorf("matched %+v below threshold", m) }
+}
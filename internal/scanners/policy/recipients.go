@@ -0,0 +1,87 @@
+package policy
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+    "sort"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/policy/recipients"
+)
+
+// extractRecipients lists the third parties named by the privacy and cookie
+// policies and the sub-processor page, stores them as policy.recipients evidence
+// and, when an earlier scan stored a list, reports what was added or removed.
+// Recipients from a document that was found but could not be fetched or read this
+// time, as listed in unfetched, are carried over from the earlier list rather than
+// reported removed; a document no longer found takes its recipients with it.
+func (s *Scanner) extractRecipients(ctx context.Context, t ports.ScanTarget, res *ports.ScanResult, docs []policyDoc, unfetched map[string]bool) {
+    var lists [][]ports.Recipient
+    var refs []string
+    unread := map[string]bool{}
+    for typ := range unfetched { unread[typ] = true }
+    for _, d := range docs {
+        if d.Doc.Unreadable { unread[d.Type] = true }
+        if d.Doc.Unreadable || d.Type == domain.PolicyTerms { continue }
+        lists = append(lists, recipients.Extract(d.Doc, d.Type, s.Trackers))
+        refs = append(refs, d.Evidence)
+    }
+    if len(lists) == 0 { return }
+    list := recipients.Merge(lists...)
+    prev, ok := s.previousRecipients(ctx, t)
+    if ok { list = recipients.Carry(prev, list, unread) }
+    raw, _ := json.Marshal(list)
+    ev := scanners.NewEvidence("policy.recipients", "", raw, map[string]any{"recipients": list})
+    res.Evidence = append(res.Evidence, ev)
+    refs = append([]string{ev.Hash}, refs...)
+
+    listed := 0
+    var countries []string
+    for _, r := range list {
+        if r.Role == ports.RoleSubprocessor { listed++ }
+        for _, c := range r.Countries {
+            if !containsCode(countries, c) { countries = append(countries, c) }
+        }
+    }
+    sort.Strings(countries)
+    if countries == nil { countries = []string{} }
+    res.Signals = append(res.Signals,
+        scanners.NewSignal("policy.recipients.count", len(list), "info", 0.7, source, refs...),
+        scanners.NewSignal("policy.recipients.subprocessors", listed, "info", 0.8, source, refs...),
+        scanners.NewSignal("policy.recipients.countries", countries, "info", 0.6, source, refs...))
+    if ok { recipientChanges(res, prev, list, ev.Hash) }
+}
+
+// previousRecipients loads the list stored by the domain's previous scan.
+func (s *Scanner) previousRecipients(ctx context.Context, t ports.ScanTarget) ([]ports.Recipient, bool) {
+    if s.Recipients == nil || t.Domain == "" { return nil, false }
+    snaps, err := s.Recipients.RecipientSnapshots(ctx, t.Domain, 1)
+    if err != nil {
+        log.Printf("policy %s: load recipients: %v", t.Domain, err)
+        return nil, false
+    }
+    if len(snaps) == 0 { return nil, false }
+    return snaps[0].Recipients, true
+}
+
+// recipientChanges compares list with the previous one and emits
+// policy.recipients.changed, with the names added and removed.
+func recipientChanges(res *ports.ScanResult, prev, list []ports.Recipient, ref string) {
+    var added, removed []string
+    for _, c := range recipients.Changes(prev, list) {
+        switch c.Kind {
+        case ports.ChangeAdded:
+            added = append(added, c.Name)
+        case ports.ChangeRemoved:
+            removed = append(removed, c.Name)
+        }
+    }
+    changed, severity := len(added)+len(removed) > 0, "info"
+    if len(added) > 0 { severity = "medium" }
+    res.Signals = append(res.Signals, scanners.NewSignal("policy.recipients.changed", changed, severity, 0.8, source, ref))
+    if len(added) > 0 { res.Signals = append(res.Signals, scanners.NewSignal("policy.recipients.added", added, "medium", 0.8, source, ref)) }
+    if len(removed) > 0 { res.Signals = append(res.Signals, scanners.NewSignal("policy.recipients.removed", removed, "info", 0.8, source, ref)) }
+}
@@ -0,0 +1,79 @@
+package recipients
+
+import (
+    "sort"
+    "strings"
+
+    "camille/internal/ports"
+)
+
+// Changes lists the recipients added and removed between two lists, and those whose
+// purpose or countries changed, by name.
+func Changes(old, new []ports.Recipient) []ports.RecipientChange {
+    before := index(old)
+    after := index(new)
+    var out []ports.RecipientChange
+    for key, b := range after {
+        a, ok := before[key]
+        switch {
+        case !ok:
+            out = append(out, ports.RecipientChange{Kind: ports.ChangeAdded, Name: b.Name, To: b})
+        case !strings.EqualFold(a.Purpose, b.Purpose) || strings.Join(a.Countries, ",") != strings.Join(b.Countries, ","):
+            out = append(out, ports.RecipientChange{Kind: ports.ChangeModified, Name: b.Name, From: a, To: b})
+        }
+    }
+    for key, a := range before {
+        if _, ok := after[key]; !ok { out = append(out, ports.RecipientChange{Kind: ports.ChangeRemoved, Name: a.Name, From: a}) }
+    }
+    sort.Slice(out, func(i, j int) bool {
+        if out[i].Kind != out[j].Kind { return out[i].Kind < out[j].Kind }
+        return out[i].Name < out[j].Name
+    })
+    return out
+}
+
+// index keys recipients by entity or name; a sub-processor listing wins over a
+// mention in prose.
+func index(rs []ports.Recipient) map[string]*ports.Recipient {
+    out := map[string]*ports.Recipient{}
+    for i := range rs {
+        r := &rs[i]
+        if prev, ok := out[r.Key()]; ok && (prev.Role == ports.RoleSubprocessor || r.Role != ports.RoleSubprocessor) { continue }
+        out[r.Key()] = r
+    }
+    return out
+}
+
+// Carry appends to cur the recipients of prev that came from a document type in
+// unread and are not listed again, so a page that was found but could not be
+// fetched keeps its recipients instead of reporting them all removed. A type
+// missing from unread, such as a page that is gone, carries nothing.
+func Carry(prev, cur []ports.Recipient, unread map[string]bool) []ports.Recipient {
+    listed := index(cur)
+    for _, r := range prev {
+        if !unread[r.DocType] { continue }
+        if _, ok := listed[r.Key()]; ok { continue }
+        cur = append(cur, r)
+        listed[r.Key()] = &cur[len(cur)-1]
+    }
+    return cur
+}
+
+// Merge joins the recipients of several documents, keeping the first mention of
+// each unless a later one is a sub-processor listing.
+func Merge(lists ...[]ports.Recipient) []ports.Recipient {
+    out := []ports.Recipient{}
+    at := map[string]int{}
+    for _, rs := range lists {
+        for _, r := range rs {
+            i, ok := at[r.Key()]
+            if !ok {
+                at[r.Key()] = len(out)
+                out = append(out, r)
+            } else if r.Role == ports.RoleSubprocessor && out[i].Role != ports.RoleSubprocessor {
+                out[i] = r
+            }
+        }
+    }
+    return out
+}
@@ -0,0 +1,51 @@
+package recipients
+
+import (
+    "testing"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+)
+
+func TestCarry(t *testing.T) {
+    prev := []ports.Recipient{
+        {Name: "Stripe", Role: ports.RoleSubprocessor, DocType: domain.PolicySubprocessors},
+        {Name: "Google Analytics", DocType: domain.PolicyPrivacy},
+        {Name: "Hotjar", DocType: domain.PolicyCookies},
+    }
+    cur := []ports.Recipient{{Name: "Google Analytics", DocType: domain.PolicyPrivacy}}
+    for _, tc := range []struct {
+        name   string
+        unread map[string]bool
+        want   []string
+    }{
+        {"all read", nil, []string{"Google Analytics"}},
+        {"page found but not fetched", map[string]bool{domain.PolicySubprocessors: true}, []string{"Google Analytics", "Stripe"}},
+        {"listed again is not doubled", map[string]bool{domain.PolicyPrivacy: true}, []string{"Google Analytics"}},
+        {"page removed", map[string]bool{}, []string{"Google Analytics"}},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            got := Carry(prev, append([]ports.Recipient(nil), cur...), tc.unread)
+            var names []string
+            for _, r := range got { names = append(names, r.Name) }
+            if len(names) != len(tc.want) { t.Fatalf("got %v, want %v", names, tc.want) }
+            for i := range names {
+                if names[i] != tc.want[i] { t.Fatalf("got %v, want %v", names, tc.want) }
+            }
+        })
+    }
+}
+
+// A document that is gone reports its recipients removed, scan after scan, rather
+// than carrying them forever.
+func TestCarryRemovedDocument(t *testing.T) {
+    prev := []ports.Recipient{
+        {Name: "Stripe", Role: ports.RoleSubprocessor, DocType: domain.PolicySubprocessors},
+        {Name: "Google Analytics", DocType: domain.PolicyPrivacy},
+    }
+    cur := []ports.Recipient{{Name: "Google Analytics", DocType: domain.PolicyPrivacy}}
+    list := Carry(prev, cur, map[string]bool{})
+    changes := Changes(prev, list)
+    if len(changes) != 1 || changes[0].Kind != ports.ChangeRemoved || changes[0].Name != "Stripe" { t.Fatalf("changes = %+v, want Stripe removed", changes) }
+    if again := Carry(list, list, map[string]bool{}); len(again) != 1 { t.Errorf("next scan carried %+v", again) }
+}
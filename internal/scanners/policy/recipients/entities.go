@@ -0,0 +1,106 @@
+package recipients
+
+// entity is a company commonly named as a recipient or sub-processor. Aliases are
+// the names policies use for it; Domain is looked up in the tracker directory for
+// its owner and categories.
+type entity struct {
+    Name     string
+    Owner    string
+    Domain   string
+    Category string
+    Aliases  []string
+}
+
+// Entity categories.
+const (
+    catHosting      = "Hosting"
+    catPayments     = "Payments"
+    catEmail        = "Email"
+    catAnalytics    = "Analytics"
+    catAdvertising  = "Advertising"
+    catSupport      = "Customer Support"
+    catCRM          = "CRM"
+    catMonitoring   = "Monitoring"
+    catSecurity     = "Security"
+    catProductivity = "Productivity"
+    catAI           = "AI"
+)
+
+var entities = []entity{
+    {"Amazon Web Services", "Amazon Technologies, Inc.", "amazonaws.com", catHosting, []string{"Amazon Web Services", "AWS", "Amazon Web Services, Inc.", "Amazon S3"}},
+    {"Google Cloud", "Google LLC", "cloud.google.com", catHosting, []string{"Google Cloud", "Google Cloud Platform", "GCP", "Google Cloud EMEA Limited", "Firebase"}},
+    {"Microsoft Azure", "Microsoft Corporation", "azure.com", catHosting, []string{"Microsoft Azure", "Azure"}},
+    {"Microsoft", "Microsoft Corporation", "microsoft.com", catProductivity, []string{"Microsoft", "Microsoft Corporation", "Microsoft 365", "Office 365", "Microsoft Ireland Operations Limited"}},
+    {"Google", "Google LLC", "google.com", catAdvertising, []string{"Google", "Google LLC", "Google Ireland Limited", "Google Workspace", "G Suite"}},
+    {"Google Analytics", "Google LLC", "google-analytics.com", catAnalytics, []string{"Google Analytics"}},
+    {"Google Ads", "Google LLC", "googleadservices.com", catAdvertising, []string{"Google Ads", "Google AdSense", "DoubleClick"}},
+    {"Meta", "Meta Platforms, Inc.", "facebook.com", catAdvertising, []string{"Meta", "Meta Platforms", "Meta Platforms, Inc.", "Meta Platforms Ireland Limited", "Facebook", "Instagram"}},
+    {"Cloudflare", "Cloudflare, Inc.", "cloudflare.com", catSecurity, []string{"Cloudflare", "Cloudflare, Inc."}},
+    {"Fastly", "Fastly, Inc.", "fastly.com", catHosting, []string{"Fastly"}},
+    {"Akamai", "Akamai Technologies, Inc.", "akamai.com", catHosting, []string{"Akamai", "Akamai Technologies"}},
+    {"DigitalOcean", "DigitalOcean, LLC", "digitalocean.com", catHosting, []string{"DigitalOcean", "Digital Ocean"}},
+    {"Heroku", "Salesforce, Inc.", "heroku.com", catHosting, []string{"Heroku"}},
+    {"Vercel", "Vercel Inc.", "vercel.com", catHosting, []string{"Vercel"}},
+    {"Netlify", "Netlify, Inc.", "netlify.com", catHosting, []string{"Netlify"}},
+    {"Hetzner", "Hetzner Online GmbH", "hetzner.com", catHosting, []string{"Hetzner", "Hetzner Online"}},
+    {"OVHcloud", "OVH SAS", "ovhcloud.com", catHosting, []string{"OVH", "OVHcloud"}},
+    {"MongoDB", "MongoDB, Inc.", "mongodb.com", catHosting, []string{"MongoDB", "MongoDB Atlas"}},
+    {"Snowflake", "Snowflake Inc.", "snowflake.com", catHosting, []string{"Snowflake"}},
+    {"Stripe", "Stripe, Inc.", "stripe.com", catPayments, []string{"Stripe", "Stripe, Inc.", "Stripe Payments Europe"}},
+    {"PayPal", "PayPal Holdings, Inc.", "paypal.com", catPayments, []string{"PayPal", "Braintree"}},
+    {"Adyen", "Adyen N.V.", "adyen.com", catPayments, []string{"Adyen"}},
+    {"Klarna", "Klarna Bank AB", "klarna.com", catPayments, []string{"Klarna"}},
+    {"Plaid", "Plaid Inc.", "plaid.com", catPayments, []string{"Plaid"}},
+    {"Chargebee", "Chargebee, Inc.", "chargebee.com", catPayments, []string{"Chargebee"}},
+    {"Twilio", "Twilio Inc.", "twilio.com", catEmail, []string{"Twilio", "Twilio Inc."}},
+    {"SendGrid", "Twilio Inc.", "sendgrid.com", catEmail, []string{"SendGrid", "Twilio SendGrid"}},
+    {"Mailchimp", "Intuit Inc.", "mailchimp.com", catEmail, []string{"Mailchimp", "MailChimp", "Mandrill"}},
+    {"Mailgun", "Mailgun Technologies, Inc.", "mailgun.com", catEmail, []string{"Mailgun"}},
+    {"Postmark", "ActiveCampaign, LLC", "postmarkapp.com", catEmail, []string{"Postmark"}},
+    {"Amazon SES", "Amazon Technologies, Inc.", "amazonaws.com", catEmail, []string{"Amazon SES", "Amazon Simple Email Service"}},
+    {"Brevo", "Sendinblue SAS", "brevo.com", catEmail, []string{"Brevo", "Sendinblue"}},
+    {"Klaviyo", "Klaviyo, Inc.", "klaviyo.com", catEmail, []string{"Klaviyo"}},
+    {"Braze", "Braze, Inc.", "braze.com", catEmail, []string{"Braze"}},
+    {"Customer.io", "Peaberry Software, Inc.", "customer.io", catEmail, []string{"Customer.io"}},
+    {"Salesforce", "Salesforce, Inc.", "salesforce.com", catCRM, []string{"Salesforce", "Salesforce.com", "Salesforce, Inc."}},
+    {"HubSpot", "HubSpot, Inc.", "hubspot.com", catCRM, []string{"HubSpot", "Hubspot"}},
+    {"Pipedrive", "Pipedrive OÜ", "pipedrive.com", catCRM, []string{"Pipedrive"}},
+    {"Zendesk", "Zendesk, Inc.", "zendesk.com", catSupport, []string{"Zendesk"}},
+    {"Intercom", "Intercom, Inc.", "intercom.com", catSupport, []string{"Intercom", "Intercom, Inc."}},
+    {"Freshworks", "Freshworks Inc.", "freshworks.com", catSupport, []string{"Freshworks", "Freshdesk"}},
+    {"Help Scout", "Help Scout PBC", "helpscout.com", catSupport, []string{"Help Scout", "HelpScout"}},
+    {"Segment", "Twilio Inc.", "segment.com", catAnalytics, []string{"Segment", "Twilio Segment"}},
+    {"Mixpanel", "Mixpanel, Inc.", "mixpanel.com", catAnalytics, []string{"Mixpanel"}},
+    {"Amplitude", "Amplitude, Inc.", "amplitude.com", catAnalytics, []string{"Amplitude"}},
+    {"Heap", "Heap Inc.", "heap.io", catAnalytics, []string{"Heap Analytics"}},
+    {"Hotjar", "Hotjar Ltd.", "hotjar.com", catAnalytics, []string{"Hotjar"}},
+    {"FullStory", "FullStory, Inc.", "fullstory.com", catAnalytics, []string{"FullStory", "Fullstory"}},
+    {"Microsoft Clarity", "Microsoft Corporation", "clarity.ms", catAnalytics, []string{"Microsoft Clarity"}},
+    {"Matomo", "InnoCraft Ltd", "matomo.org", catAnalytics, []string{"Matomo"}},
+    {"Adobe", "Adobe Inc.", "adobe.com", catAnalytics, []string{"Adobe", "Adobe Analytics", "Adobe Inc."}},
+    {"LinkedIn", "Microsoft Corporation", "linkedin.com", catAdvertising, []string{"LinkedIn"}},
+    {"TikTok", "ByteDance Ltd.", "tiktok.com", catAdvertising, []string{"TikTok"}},
+    {"X", "X Corp.", "twitter.com", catAdvertising, []string{"Twitter", "X Corp."}},
+    {"Pinterest", "Pinterest, Inc.", "pinterest.com", catAdvertising, []string{"Pinterest"}},
+    {"Snap", "Snap Inc.", "snapchat.com", catAdvertising, []string{"Snapchat", "Snap Inc."}},
+    {"Criteo", "Criteo SA", "criteo.com", catAdvertising, []string{"Criteo"}},
+    {"Datadog", "Datadog, Inc.", "datadoghq.com", catMonitoring, []string{"Datadog"}},
+    {"Sentry", "Functional Software, Inc.", "sentry.io", catMonitoring, []string{"Sentry", "Functional Software"}},
+    {"New Relic", "New Relic, Inc.", "newrelic.com", catMonitoring, []string{"New Relic"}},
+    {"Elastic", "Elasticsearch B.V.", "elastic.co", catMonitoring, []string{"Elastic", "Elasticsearch"}},
+    {"PagerDuty", "PagerDuty, Inc.", "pagerduty.com", catMonitoring, []string{"PagerDuty"}},
+    {"Okta", "Okta, Inc.", "okta.com", catSecurity, []string{"Okta", "Auth0"}},
+    {"OneTrust", "OneTrust LLC", "onetrust.com", catSecurity, []string{"OneTrust"}},
+    {"Cookiebot", "Usercentrics A/S", "cookiebot.com", catSecurity, []string{"Cookiebot", "Usercentrics"}},
+    {"Slack", "Slack Technologies, LLC", "slack.com", catProductivity, []string{"Slack", "Slack Technologies"}},
+    {"Atlassian", "Atlassian Pty Ltd", "atlassian.com", catProductivity, []string{"Atlassian", "Jira", "Confluence"}},
+    {"Zoom", "Zoom Video Communications, Inc.", "zoom.us", catProductivity, []string{"Zoom", "Zoom Video Communications"}},
+    {"DocuSign", "DocuSign, Inc.", "docusign.com", catProductivity, []string{"DocuSign", "Docusign"}},
+    {"Dropbox", "Dropbox, Inc.", "dropbox.com", catProductivity, []string{"Dropbox"}},
+    {"GitHub", "GitHub, Inc.", "github.com", catProductivity, []string{"GitHub"}},
+    {"Notion", "Notion Labs, Inc.", "notion.so", catProductivity, []string{"Notion Labs"}},
+    {"Algolia", "Algolia SAS", "algolia.com", catHosting, []string{"Algolia"}},
+    {"Shopify", "Shopify Inc.", "shopify.com", catPayments, []string{"Shopify"}},
+    {"OpenAI", "OpenAI, L.L.C.", "openai.com", catAI, []string{"OpenAI", "OpenAI, L.L.C."}},
+    {"Anthropic", "Anthropic PBC", "anthropic.com", catAI, []string{"Anthropic", "Anthropic PBC"}},
+}
@@ -0,0 +1,350 @@
+// Package recipients reads who a site shares personal data with: the rows of
+// sub-processor tables and lists, and known companies named in policy text. Names
+// are normalized to known entities, with owners and categories from the tracker
+// directory when one is loaded.
+package recipients
+
+import (
+    "regexp"
+    "sort"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/textutil"
+    "camille/internal/scanners/geo"
+)
+
+// maxQuote bounds quoted sentences around a name found in prose.
+const maxQuote = 400
+
+var (
+    // Table header cells naming the recipient, its purpose, its location and its website.
+    nameHeader     = regexp.MustCompile(`(?i)^(?:(?:sub-?\s?processor|third[- ]party|entity|company|vendor|provider|processor|recipient|service provider|partner)s?(?: name)?|name)$`)
+    purposeHeader  = regexp.MustCompile(`(?i)\b(purpose|service|function|description|activit|nature|processing|use)`)
+    locationHeader = regexp.MustCompile(`(?i)\b(location|countr|region|where|data cent|hosting|jurisdiction)`)
+    siteHeader     = regexp.MustCompile(`(?i)\b(website|url|domain|link)`)
+
+    host     = regexp.MustCompile(`(?i)\b(?:https?://)?((?:[a-z0-9-]+\.)+[a-z]{2,})\b`)
+    itemName = regexp.MustCompile(`^([^:–—(|]{2,80}?)\s*(?:\(([^)]*)\)\s*)?(?:(?:[:–—]|\s-\s)\s*(.*))?$`)
+    itemPart = regexp.MustCompile(`\s+[–—-]\s+|;\s+|\s+\|\s+`)
+
+    shareCue    = regexp.MustCompile(`(?i)\b(shar\w*|disclos\w*|transfer\w*|provid\w*|processors?|sub-?processors?|service providers?|partners?|vendors?|third[- ]part\w*|host\w*|stor\w*|powered by|uses?|using|via|through|integrat\w*|engage\w*)\b`)
+    placeCue    = regexp.MustCompile(`(?i)\b(located|hosted|stored|based|servers?|data cent(?:er|re)s?|processed in)\b`)
+    purposeTail = regexp.MustCompile(`^[^.;]{0,60}?\b(?:for|to)\s+((?:the\s+)?[a-z][^.;,()]{2,60})`)
+)
+
+// ambiguous aliases are ordinary words as well as company names; in prose they only
+// count away from the start of a sentence.
+var ambiguous = map[string]bool{
+    "Segment": true, "Slack": true, "Elastic": true, "Zoom": true, "Meta": true, "Stripe": true,
+    "Sentry": true, "Amplitude": true, "Snowflake": true, "Notion Labs": true, "Plaid": true,
+}
+
+var (
+    byAlias = map[string]*entity{}
+    prose   *regexp.Regexp
+)
+
+func init() {
+    var aliases []string
+    for i := range entities {
+        e := &entities[i]
+        byAlias[normalize(e.Name)] = e
+        for _, a := range e.Aliases {
+            byAlias[normalize(a)] = e
+            aliases = append(aliases, regexp.QuoteMeta(a))
+        }
+    }
+    sort.Slice(aliases, func(i, j int) bool { return len(aliases[i]) > len(aliases[j]) })
+    prose = regexp.MustCompile(`\b(` + strings.Join(aliases, "|") + `)(?:\W|$)`)
+}
+
+// Extract returns the recipients doc names. Sub-processor pages yield every table
+// row and list item; any table whose header names recipients yields its rows; prose
+// yields the known companies named in sentences about sharing, hosting or using
+// them. trackers may be nil.
+func Extract(doc ports.Document, typ string, trackers ports.TrackerDirectory) []ports.Recipient {
+    x := &extractor{doc: doc, typ: typ, trackers: trackers, byKey: map[string]int{}, role: ports.RoleRecipient}
+    if typ == domain.PolicySubprocessors { x.role = ports.RoleSubprocessor }
+    x.tables()
+    if typ == domain.PolicySubprocessors { x.lists() }
+    x.prose()
+    return x.out
+}
+
+type extractor struct {
+    doc      ports.Document
+    typ      string
+    role     string
+    trackers ports.TrackerDirectory
+    out      []ports.Recipient
+    byKey    map[string]int
+    used     [][2]int // line spans already read as rows or items
+}
+
+// line is one line of the document text and its offsets.
+type line struct {
+    start, end int
+    text       string
+}
+
+func (x *extractor) lines() []line {
+    var out []line
+    text := x.doc.Text
+    for start := 0; start < len(text); {
+        end := strings.IndexByte(text[start:], '\n')
+        if end < 0 { end = len(text) } else { end += start }
+        if s := strings.TrimSpace(text[start:end]); s != "" {
+            off := start + strings.Index(text[start:end], s)
+            out = append(out, line{off, off + len(s), s})
+        }
+        start = end + 1
+    }
+    return out
+}
+
+// tables reads runs of table rows (cells joined by " | "). A header row decides the
+// columns; without one only sub-processor pages are read, name first.
+func (x *extractor) tables() {
+    var run []line
+    flush := func() {
+        if len(run) > 0 { x.table(run) }
+        run = nil
+    }
+    for _, l := range x.lines() {
+        if strings.Contains(l.text, " | ") { run = append(run, l) } else { flush() }
+    }
+    flush()
+}
+
+type columns struct{ name, purpose, location, site int }
+
+func (x *extractor) table(rows []line) {
+    cols, ok := header(cells(rows[0].text))
+    if ok {
+        rows = rows[1:]
+    } else if x.typ != domain.PolicySubprocessors {
+        return
+    } else {
+        cols = columns{name: 0, purpose: -1, location: -1, site: -1}
+    }
+    for _, row := range rows {
+        cs := cells(row.text)
+        if cols.name >= len(cs) { continue }
+        r := ports.Recipient{Name: cs[cols.name]}
+        if cols.purpose >= 0 && cols.purpose < len(cs) { r.Purpose = cs[cols.purpose] }
+        if cols.location >= 0 && cols.location < len(cs) { r.Location = cs[cols.location] }
+        if cols.site >= 0 && cols.site < len(cs) { r.Domain = siteOf(cs[cols.site]) }
+        for i, c := range cs {
+            if i == cols.name || i == cols.purpose || i == cols.location || i == cols.site { continue }
+            switch {
+            case cols.location < 0 && r.Location == "" && len(geo.Countries(c)) > 0:
+                r.Location = c
+            case cols.purpose < 0 && len(c) > len(r.Purpose):
+                r.Purpose = c
+            }
+        }
+        if x.add(r, row) { x.used = append(x.used, [2]int{row.start, row.end}) }
+    }
+}
+
+// header maps the columns of a header row; it needs a name column and a purpose or
+// location column.
+func header(cs []string) (columns, bool) {
+    c := columns{name: -1, purpose: -1, location: -1, site: -1}
+    for i, h := range cs {
+        switch {
+        case c.name < 0 && nameHeader.MatchString(h):
+            c.name = i
+        case c.location < 0 && locationHeader.MatchString(h):
+            c.location = i
+        case c.site < 0 && siteHeader.MatchString(h):
+            c.site = i
+        case c.purpose < 0 && purposeHeader.MatchString(h):
+            c.purpose = i
+        }
+    }
+    return c, c.name >= 0 && (c.purpose >= 0 || c.location >= 0)
+}
+
+// lists reads list items of the form "Name – purpose – location", "Name (location):
+// purpose" or a bare name.
+func (x *extractor) lists() {
+    for _, l := range x.lines() {
+        if x.consumed(l.start) || strings.Contains(l.text, " | ") { continue }
+        if span, ok := x.doc.Locate(l.start); !ok || !strings.Contains(span.Path, "/li[") { continue }
+        r := ports.Recipient{Name: l.text}
+        if m := itemName.FindStringSubmatch(l.text); m != nil {
+            r.Name = m[1]
+            var rest []string
+            if m[2] != "" { rest = append(rest, m[2]) }
+            for _, part := range itemPart.Split(m[3], -1) {
+                if part = strings.TrimSpace(part); part != "" { rest = append(rest, part) }
+            }
+            var purpose []string
+            for _, part := range rest {
+                if r.Location == "" && len(geo.Countries(part)) > 0 && len(strings.Fields(part)) <= 6 {
+                    r.Location = part
+                } else {
+                    purpose = append(purpose, part)
+                }
+            }
+            r.Purpose = strings.Join(purpose, "; ")
+        }
+        if x.add(r, l) { x.used = append(x.used, [2]int{l.start, l.end}) }
+    }
+}
+
+// prose finds known companies in sentences that share data with, host on or use them.
+func (x *extractor) prose() {
+    text := x.doc.Text
+    for _, m := range prose.FindAllStringSubmatchIndex(text, -1) {
+        start, end := m[2], m[3]
+        if x.consumed(start) { continue }
+        s, e := textutil.Sentence(text, start, end, maxQuote)
+        quote := text[s:e]
+        if !shareCue.MatchString(quote) { continue }
+        if ambiguous[text[start:end]] && start == s { continue }
+        r := ports.Recipient{Name: text[start:end]}
+        if p := purposeTail.FindStringSubmatch(text[end:e]); p != nil { r.Purpose = strings.TrimSpace(p[1]) }
+        if placeCue.MatchString(quote) {
+            if names := geo.Names(quote); len(names) > 0 { r.Location = strings.Join(names, ", ") }
+        }
+        x.add(r, line{s, e, quote})
+    }
+}
+
+// add normalizes r and keeps it unless a recipient with the same key is already
+// listed, in which case it fills in that one's missing purpose and location.
+func (x *extractor) add(r ports.Recipient, at line) bool {
+    r.Name = cleanName(r.Name)
+    if !plausibleName(r.Name) { return false }
+    r.Purpose, r.Location = strings.TrimSpace(r.Purpose), strings.TrimSpace(r.Location)
+    r.Role, r.DocType = x.role, x.typ
+    r.Quote, r.SpanStart, r.SpanEnd = at.text, at.start, at.end
+    r.SectionURL = x.doc.URL
+    if sec, ok := x.doc.SectionAt(at.start); ok { r.SectionURL = x.doc.SectionURL(sec) }
+    r.Countries = geo.Countries(r.Location)
+    resolve(&r, x.trackers)
+    if i, ok := x.byKey[r.Key()]; ok {
+        prev := &x.out[i]
+        if prev.Purpose == "" { prev.Purpose = r.Purpose }
+        if prev.Location == "" { prev.Location, prev.Countries = r.Location, r.Countries }
+        return true
+    }
+    x.byKey[r.Key()] = len(x.out)
+    x.out = append(x.out, r)
+    return true
+}
+
+func (x *extractor) consumed(offset int) bool {
+    for _, u := range x.used {
+        if offset >= u[0] && offset < u[1] { return true }
+    }
+    return false
+}
+
+// resolve fills the known entity behind r's name or website, preferring the
+// tracker directory's owner and categories for its domain.
+func resolve(r *ports.Recipient, trackers ports.TrackerDirectory) {
+    e := lookup(r.Name)
+    if e == nil && r.Domain == "" {
+        if m := host.FindStringSubmatch(r.Name); m != nil { r.Domain = strings.ToLower(m[1]) }
+    }
+    if e != nil {
+        r.Entity, r.Owner, r.Domain, r.Categories = e.Name, e.Owner, e.Domain, []string{e.Category}
+    }
+    if trackers == nil { return }
+    guessed := false
+    if r.Domain == "" && isWord(r.Name) {
+        r.Domain, guessed = strings.ToLower(r.Name)+".com", true
+    }
+    if r.Domain == "" { return }
+    t, ok := trackers.Lookup(r.Domain)
+    if guessed && (!ok || !strings.EqualFold(t.DisplayName, r.Name)) {
+        r.Domain = ""
+        return
+    }
+    if !ok { return }
+    if r.Entity == "" { r.Entity = t.DisplayName }
+    if t.Owner != "" { r.Owner = t.Owner }
+    for _, c := range t.Categories {
+        if !contains(r.Categories, c) { r.Categories = append(r.Categories, c) }
+    }
+}
+
+// lookup matches a name, the part before or inside a parenthesis, or its longest
+// leading alias ("Amazon Web Services EMEA SARL").
+func lookup(name string) *entity {
+    candidates := []string{name}
+    if before, inside, ok := strings.Cut(name, "("); ok {
+        candidates = append(candidates, before, strings.TrimSuffix(inside, ")"))
+    }
+    for _, c := range candidates {
+        if e, ok := byAlias[normalize(c)]; ok { return e }
+    }
+    words := strings.Fields(normalize(name))
+    for n := len(words) - 1; n > 0; n-- {
+        if e, ok := byAlias[strings.Join(words[:n], " ")]; ok { return e }
+    }
+    return nil
+}
+
+// corporateSuffix matches legal form suffixes dropped when comparing names.
+var corporateSuffix = regexp.MustCompile(`(?i)[,\s]+(inc|llc|l\.l\.c|ltd|limited|gmbh|corp|corporation|co|plc|pbc|sas|sarl|s\.a|sa|s\.r\.l|srl|b\.v|bv|n\.v|nv|ab|a/s|oy|oü|pty|ag|ug)\.?$`)
+
+// normalize lower-cases a name and drops legal form suffixes and stray punctuation.
+func normalize(name string) string {
+    s := strings.ToLower(strings.TrimSpace(name))
+    for {
+        t := strings.TrimRight(corporateSuffix.ReplaceAllString(s, ""), " ,.")
+        if t == s { break }
+        s = t
+    }
+    return strings.Join(strings.Fields(strings.NewReplacer(",", " ", "®", "", "™", "").Replace(s)), " ")
+}
+
+// cleanName trims footnote markers, bullets and trailing punctuation from a name.
+func cleanName(s string) string {
+    s = strings.TrimSpace(strings.TrimLeft(s, "•*-–— \t"))
+    s = strings.TrimRight(s, " ,;:*†")
+    return strings.Join(strings.Fields(s), " ")
+}
+
+// plausibleName rejects header repeats, sentences and fragments.
+func plausibleName(s string) bool {
+    if len(s) < 2 || len(s) > 80 || len(strings.Fields(s)) > 8 { return false }
+    if nameHeader.MatchString(s) { return false }
+    r, _ := utf8.DecodeRuneInString(s)
+    return unicode.IsUpper(r) || unicode.IsDigit(r)
+}
+
+// isWord reports a single-word name that may be a brand's .com domain.
+func isWord(s string) bool {
+    if len(s) < 3 || len(s) > 30 { return false }
+    for _, r := range s {
+        if !unicode.IsLetter(r) && !unicode.IsDigit(r) { return false }
+    }
+    return true
+}
+
+func siteOf(cell string) string {
+    if m := host.FindStringSubmatch(cell); m != nil { return strings.TrimPrefix(strings.ToLower(m[1]), "www.") }
+    return ""
+}
+
+func cells(row string) []string {
+    parts := strings.Split(row, " | ")
+    for i := range parts { parts[i] = strings.TrimSpace(parts[i]) }
+    return parts
+}
+
+func contains(xs []string, s string) bool {
+    for _, x := range xs {
+        if x == s { return true }
+    }
+    return false
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+    "encoding/json"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/policy/retention"
+)
+
+// Retention clarity grades: every entry states a period in days, some do, none do,
+// or the policy has no retention statements at all. Unknown is for a policy in a
+// language the extractor has no vocabulary for.
+const (
+    claritySpecific = "specific"
+    clarityPartial  = "partial"
+    clarityVague    = "vague"
+    clarityNone     = "none"
+    clarityUnknown  = "unknown"
+)
+
+// extractRetention builds the retention schedule of the privacy policy and stores
+// it as evidence with the quoted sentences. Signals grade how specific it is, the
+// longest stated period, whether any period is open-ended and whether a legal basis
+// is given.
+func (s *Scanner) extractRetention(res *ports.ScanResult, docs []policyDoc) {
+    var doc *policyDoc
+    for i := range docs {
+        if docs[i].Type == domain.PolicyPrivacy && !docs[i].Doc.Unreadable { doc = &docs[i] }
+    }
+    if doc == nil { return }
+    const prefix = "policy.data.storage.retention."
+    entries, ok := retention.Extract(doc.Doc, doc.Type)
+    if !ok {
+        res.Signals = append(res.Signals, scanners.NewSignal(prefix+"clarity", clarityUnknown, "info", 0.5, source, doc.Evidence))
+        return
+    }
+    if entries == nil { entries = []ports.RetentionEntry{} }
+    raw, _ := json.Marshal(entries)
+    ev := scanners.NewEvidence("policy.retention", doc.Doc.URL, raw, map[string]any{"entries": entries})
+    res.Evidence = append(res.Evidence, ev)
+
+    refs := []string{ev.Hash, doc.Evidence}
+    withDays, maxDays, openEnded, basis := 0, 0, false, false
+    for _, e := range entries {
+        if e.Period == ports.RetentionDays { withDays++ } else { openEnded = true }
+        maxDays = max(maxDays, e.Days)
+        basis = basis || e.LegalBasis != ""
+    }
+    clarity, severity := clarityNone, "low"
+    switch {
+    case len(entries) == 0:
+    case withDays == len(entries):
+        clarity, severity = claritySpecific, "info"
+    case withDays > 0:
+        clarity, severity = clarityPartial, "info"
+    default:
+        clarity = clarityVague
+    }
+    res.Signals = append(res.Signals,
+        scanners.NewSignal(prefix+"entries", len(entries), "info", 0.7, source, refs...),
+        scanners.NewSignal(prefix+"clarity", clarity, severity, 0.7, source, refs...))
+    if len(entries) == 0 { return }
+    if maxDays > 0 { res.Signals = append(res.Signals, scanners.NewSignal(prefix+"max_days", maxDays, "info", 0.7, source, refs...)) }
+    res.Signals = append(res.Signals,
+        scanners.NewSignal(prefix+"open_ended", openEnded, "info", 0.7, source, refs...),
+        scanners.NewSignal(prefix+"basis_stated", basis, "info", 0.6, source, refs...))
+}
@@ -0,0 +1,306 @@
+// Package retention turns the retention statements in a policy into a schedule:
+// per sentence, which category of data is kept, for how long (normalized to days,
+// or open-ended), from what point and on what legal basis.
+package retention
+
+import (
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/textutil"
+)
+
+// Days per period unit; months and years are calendar averages rounded down.
+var unitDays = map[string]int{"day": 1, "week": 7, "month": 30, "year": 365}
+
+// vocabulary is how one language states retention, as regexp alternations.
+// Numbers and Units map each spelling to its value, "|" separating spellings;
+// unit values are the keys of unitDays and "hour".
+type vocabulary struct {
+    Cue        string // words that make a sentence a retention statement
+    Numbers    map[string]int
+    Articles   string // number words that double as articles ("a", "ein")
+    Units      map[string]string
+    Age        string // what follows an age ("13 years old")
+    Indefinite string
+    Negation   string // what, shortly before an indefinite term, denies it
+    AsNeeded   string // as-long-as-necessary terms
+    Article    string // what must precede an article for it to start a period, not a frequency ("once a year")
+    Every      string // what marks a frequency ("every 12 months")
+}
+
+// locale is a compiled vocabulary. Period group 1 is the number, group 2 the unit
+// and group 3 an age suffix.
+type locale struct {
+    cue, period, indefinite, negation, asNeeded, article, every *regexp.Regexp
+    numbers  map[string]int
+    units    map[string]string
+    articles map[string]bool
+}
+
+// word wraps alternations in letter boundaries; \b only knows ASCII letters.
+func word(alts string) string { return `(?:^|[^\p{L}\d])(?:` + alts + `)(?:$|[^\p{L}\d])` }
+
+func newLocale(v vocabulary) locale {
+    loc := locale{numbers: map[string]int{}, units: map[string]string{}, articles: map[string]bool{}}
+    var nums, units []string
+    for k, n := range v.Numbers {
+        for _, w := range strings.Split(k, "|") {
+            loc.numbers[w] = n
+            nums = append(nums, regexp.QuoteMeta(w))
+        }
+    }
+    for k, u := range v.Units {
+        for _, w := range strings.Split(k, "|") {
+            loc.units[w] = u
+            units = append(units, regexp.QuoteMeta(w))
+        }
+    }
+    for _, w := range strings.Split(v.Articles, "|") { loc.articles[w] = true }
+    loc.cue = regexp.MustCompile(`(?i)` + word(v.Cue))
+    loc.period = regexp.MustCompile(`(?i)(?:^|[^\p{L}\d])(\d{1,4}|` + longestFirst(nums) + `)\s*(?:\(\d{1,4}\)\s*)?[- ]?(` + longestFirst(units) + `)(?:$|[^\p{L}\d])(\s*(?:` + v.Age + `)(?:$|[^\p{L}]))?`)
+    loc.indefinite = regexp.MustCompile(`(?i)` + word(v.Indefinite))
+    loc.negation = regexp.MustCompile(`(?i)` + word(v.Negation))
+    loc.asNeeded = regexp.MustCompile(`(?i)` + word(v.AsNeeded))
+    loc.article = regexp.MustCompile(`(?i)(?:^|[^\p{L}])(?:` + v.Article + `)\s*$`)
+    loc.every = regexp.MustCompile(`(?i)(?:^|[^\p{L}])(?:` + v.Every + `)\s+$`)
+    return loc
+}
+
+// longestFirst joins alternatives longest first, so "twenty-four" is not read as "twenty".
+func longestFirst(xs []string) string {
+    sort.Slice(xs, func(i, j int) bool { return len(xs[i]) > len(xs[j]) || len(xs[i]) == len(xs[j]) && xs[i] < xs[j] })
+    return strings.Join(xs, "|")
+}
+
+// locales are keyed by language code. A document in any other language yields no
+// schedule: its retention statements cannot be told from their absence.
+var locales = map[string]locale{
+    "en": newLocale(vocabulary{
+        Cue:        `retain\w*|retention|keep|keeps|kept|stored?|stores|storing|hold|holds|held|delete[ds]?|deletion|erase[ds]?|purge[ds]?|anonymi[sz](?:e[ds]?|ation)|destroy\w*`,
+        Numbers:    map[string]int{"a|an|one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+            "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15, "eighteen": 18, "twenty": 20, "twenty-four": 24,
+            "thirty": 30, "thirty-six": 36, "sixty": 60, "ninety": 90},
+        Articles:   `a|an`,
+        Units:      map[string]string{"hour|hours": "hour", "day|days": "day", "week|weeks": "week", "month|months": "month", "year|years": "year"},
+        Age:        `old|of age`,
+        Indefinite: `indefinitely|permanently|forever|in perpetuity|no (?:fixed|set|specific|defined) (?:retention )?period|without (?:a |any )?time limit`,
+        Negation:   `not|never|no longer than`,
+        AsNeeded:   `as long as (?:is |it is |we )?(?:reasonably |strictly )?(?:necessary|needed|required)|for (?:the duration|the life(?:time)?) of your (?:account|relationship)|while your account (?:is|remains) (?:active|open)|until you (?:delete|close) your account|for as long as (?:you|your account)`,
+        Article:    `for|within|after|up to|of|than`,
+        Every:      `every|each|per`,
+    }),
+    "de": newLocale(vocabulary{
+        Cue:        `speicher\p{L}*|gespeichert|aufbewahr\p{L}*|aufzubewahren|bewahren|lösch\p{L}*|gelöscht|vernicht\p{L}*|anonymisier\p{L}*|archivier\p{L}*|vorgehalten|aufgehoben`,
+        Numbers:    map[string]int{"ein|eine|einem|einen|einer|eins": 1, "zwei": 2, "drei": 3, "vier": 4, "fünf": 5, "sechs": 6, "sieben": 7, "acht": 8, "neun": 9, "zehn": 10,
+            "elf": 11, "zwölf": 12, "dreizehn": 13, "vierzehn": 14, "fünfzehn": 15, "achtzehn": 18, "zwanzig": 20, "vierundzwanzig": 24,
+            "dreißig": 30, "sechsunddreißig": 36, "sechzig": 60, "neunzig": 90},
+        Articles:   `ein|eine|einem|einen|einer`,
+        Units:      map[string]string{"stunde|stunden": "hour", "tag|tage|tagen|tages": "day", "woche|wochen": "week", "monat|monate|monaten|monats": "month", "jahr|jahre|jahren|jahres": "year"},
+        Age:        `alt|alte|alten|alter`,
+        Indefinite: `unbegrenzt|unbefristet|dauerhaft|auf unbestimmte zeit|für immer|ohne zeitliche (?:begrenzung|beschränkung)`,
+        Negation:   `nicht|nie|niemals|keine?|nicht länger als`,
+        AsNeeded:   `solange[^.]{0,60}?(?:erforderlich|notwendig|nötig)|für die dauer (?:ihres|deines|des) (?:kontos|nutzerkontos|vertrags|vertragsverhältnisses)|solange (?:ihr|dein) (?:konto|nutzerkonto) (?:besteht|aktiv ist)|bis (?:sie|du) (?:ihr|dein) (?:konto|nutzerkonto) (?:löschen|löschst|schließen|schließt)`,
+        Article:    `für|innerhalb|nach|bis zu|von|als|binnen`,
+        Every:      `alle|jede[nrms]?|pro`,
+    }),
+    "fr": newLocale(vocabulary{
+        Cue:        `conserv\p{L}*|stock\p{L}*|gard\p{L}*|supprim\p{L}*|suppression|effac\p{L}*|détrui\p{L}*|destruction|anonymis\p{L}*|archiv\p{L}*`,
+        Numbers:    map[string]int{"un|une": 1, "deux": 2, "trois": 3, "quatre": 4, "cinq": 5, "six": 6, "sept": 7, "huit": 8, "neuf": 9, "dix": 10,
+            "onze": 11, "douze": 12, "treize": 13, "quatorze": 14, "quinze": 15, "dix-huit": 18, "vingt": 20, "vingt-quatre": 24,
+            "trente": 30, "trente-six": 36, "soixante": 60, "quatre-vingt-dix": 90},
+        Articles:   `un|une`,
+        Units:      map[string]string{"heure|heures": "hour", "jour|jours": "day", "semaine|semaines": "week", "mois": "month", "an|ans|année|années": "year"},
+        Age:        `révolus|d['’]âge`,
+        Indefinite: `indéfiniment|pour une durée indéterminée|sans limit(?:e|ation) de durée|de (?:manière|façon) permanente|à titre permanent|pour toujours`,
+        Negation:   `pas|jamais|aucun\p{L}*`,
+        AsNeeded:   `aussi longtemps que[^.]{0,40}?(?:nécessaire|requis)|(?:pendant |pour )?la durée (?:strictement )?nécessaire|tant que (?:votre|ton) compte (?:est|reste) (?:actif|ouvert)|pendant toute la durée de (?:votre|la) (?:relation|compte|contrat)|jusqu['’]à la (?:suppression|clôture) de votre compte`,
+        Article:    `pendant|durant|pour|dans|après|jusqu['’]à|d['’]|de|que|au-delà de`,
+        Every:      `chaque|tous les|toutes les|par`,
+    }),
+    "es": newLocale(vocabulary{
+        Cue:        `conserv\p{L}*|almacen\p{L}*|guard\p{L}*|manten\p{L}*|mantendr\p{L}*|mantien\p{L}*|reten\p{L}*|suprim\p{L}*|elimin\p{L}*|borr\p{L}*|destru\p{L}*|anonimiz\p{L}*`,
+        Numbers:    map[string]int{"un|uno|una": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5, "seis": 6, "siete": 7, "ocho": 8, "nueve": 9, "diez": 10,
+            "once": 11, "doce": 12, "trece": 13, "catorce": 14, "quince": 15, "dieciocho": 18, "veinte": 20, "veinticuatro": 24,
+            "treinta": 30, "treinta y seis": 36, "sesenta": 60, "noventa": 90},
+        Articles:   `un|una`,
+        Units:      map[string]string{"hora|horas": "hour", "día|días|dia|dias": "day", "semana|semanas": "week", "mes|meses": "month", "año|años": "year"},
+        Age:        `de edad`,
+        Indefinite: `indefinidamente|de (?:forma|manera) indefinida|por tiempo indefinido|permanentemente|sin límite de tiempo|para siempre`,
+        Negation:   `no|nunca|jamás`,
+        AsNeeded:   `(?:durante )?el tiempo (?:que sea |estrictamente )?(?:necesario|imprescindible)|mientras (?:sea|resulte|siga siendo) (?:necesari[oa]|precis[oa])|mientras (?:tu|su) cuenta (?:esté|permanezca) (?:activa|abierta)|hasta que (?:elimines|cierres|elimine|cierre) (?:tu|su) cuenta`,
+        Article:    `durante|por|en|tras|después de|hasta|de|que|máximo de`,
+        Every:      `cada|todos los|todas las|al`,
+    }),
+    "nl": newLocale(vocabulary{
+        Cue:        `bewaar\p{L}*|bewaren|bewaard|opgeslagen|opslaan|opslag|verwijder\p{L}*|gewist|wissen|vernietig\p{L}*|anonimiseer\p{L}*|geanonimiseerd`,
+        Numbers:    map[string]int{"een|één": 1, "twee": 2, "drie": 3, "vier": 4, "vijf": 5, "zes": 6, "zeven": 7, "acht": 8, "negen": 9, "tien": 10,
+            "elf": 11, "twaalf": 12, "dertien": 13, "veertien": 14, "vijftien": 15, "achttien": 18, "twintig": 20, "vierentwintig": 24,
+            "dertig": 30, "zesendertig": 36, "zestig": 60, "negentig": 90},
+        Articles:   `een`,
+        Units:      map[string]string{"uur|uren": "hour", "dag|dagen": "day", "week|weken": "week", "maand|maanden": "month", "jaar|jaren": "year"},
+        Age:        `oud`,
+        Indefinite: `onbeperkt|voor onbepaalde tijd|permanent|voor altijd|zonder (?:een )?(?:vaste )?termijn`,
+        Negation:   `niet|nooit|geen`,
+        AsNeeded:   `zo ?lang (?:als )?[^.]{0,40}?(?:nodig|noodzakelijk|vereist)|zolang (?:je|uw) account (?:actief|open) is|tot (?:je|u) (?:je|uw) account (?:verwijdert|opzegt)`,
+        Article:    `gedurende|voor|binnen|na|tot|van|dan|maximaal`,
+        Every:      `elke|ieder|iedere|per|om de`,
+    }),
+    "it": newLocale(vocabulary{
+        Cue:        `conserv\p{L}*|memorizz\p{L}*|archivi\p{L}*|manten\p{L}*|mantien\p{L}*|cancell\p{L}*|elimin\p{L}*|distru\p{L}*|anonimizz\p{L}*`,
+        Numbers:    map[string]int{"un|uno|una": 1, "due": 2, "tre": 3, "quattro": 4, "cinque": 5, "sei": 6, "sette": 7, "otto": 8, "nove": 9, "dieci": 10,
+            "undici": 11, "dodici": 12, "tredici": 13, "quattordici": 14, "quindici": 15, "diciotto": 18, "venti": 20, "ventiquattro": 24,
+            "trenta": 30, "trentasei": 36, "sessanta": 60, "novanta": 90},
+        Articles:   `un|uno|una`,
+        Units:      map[string]string{"ora|ore": "hour", "giorno|giorni": "day", "settimana|settimane": "week", "mese|mesi": "month", "anno|anni": "year"},
+        Age:        `di età`,
+        Indefinite: `a tempo indeterminato|indefinitamente|permanentemente|per sempre|senza limiti di tempo`,
+        Negation:   `non|mai`,
+        AsNeeded:   `per il tempo (?:strettamente )?necessario|finché (?:sarà |sia |è )?(?:necessari[oa]|richiest[oa])|per (?:tutta )?la durata del (?:tuo |suo )?(?:account|rapporto|contratto)|fino alla (?:cancellazione|chiusura) del (?:tuo |suo )?account`,
+        Article:    `per|entro|dopo|fino a|di|oltre|massimo di`,
+        Every:      `ogni|ciascun\p{L}*`,
+    }),
+    "pt": newLocale(vocabulary{
+        Cue:        `conserv\p{L}*|armazen\p{L}*|guard\p{L}*|manter|mantid\p{L}*|mantemos|ret(?:er|emos|ido|idos|ida|idas|enção)|exclu\p{L}*|elimin\p{L}*|apag\p{L}*|destru\p{L}*|anonimiz\p{L}*|descart\p{L}*`,
+        Numbers:    map[string]int{"um|uma": 1, "dois|duas": 2, "três": 3, "quatro": 4, "cinco": 5, "seis": 6, "sete": 7, "oito": 8, "nove": 9, "dez": 10,
+            "onze": 11, "doze": 12, "treze": 13, "catorze|quatorze": 14, "quinze": 15, "dezoito": 18, "vinte": 20, "vinte e quatro": 24,
+            "trinta": 30, "trinta e seis": 36, "sessenta": 60, "noventa": 90},
+        Articles:   `um|uma`,
+        Units:      map[string]string{"hora|horas": "hour", "dia|dias": "day", "semana|semanas": "week", "mês|meses": "month", "ano|anos": "year"},
+        Age:        `de idade`,
+        Indefinite: `indefinidamente|por tempo indeterminado|permanentemente|para sempre|sem prazo (?:determinado|definido)`,
+        Negation:   `não|nunca|jamais`,
+        AsNeeded:   `pelo (?:tempo|período) (?:estritamente )?necessário|enquanto (?:for|forem|seja|sejam) necessári[oa]s?|enquanto (?:a sua|sua) conta (?:estiver|permanecer) ativa|até que (?:você )?(?:exclua|encerre) (?:a sua|sua) conta`,
+        Article:    `durante|por|em|após|depois de|até|de|que|máximo de|prazo de`,
+        Every:      `cada|todos os|todas as|a cada`,
+    }),
+}
+
+// pattern names what a regexp recognizes.
+type pattern struct {
+    name string
+    re   *regexp.Regexp
+}
+
+// categories are tried in order, so specific kinds of data win over general ones.
+var categories = []pattern{
+    {"backups", regexp.MustCompile(`(?i)\bback-?ups?\b`)},
+    {"job_applications", regexp.MustCompile(`(?i)\b(job applica\w*|applicants?|candidates?|recruit\w*|CVs?|r[ée]sum[ée]s?)\b`)},
+    {"payment", regexp.MustCompile(`(?i)\b(payment|billing|transactions?|invoices?|financial|purchase|orders?|tax records?|accounting records?)\b`)},
+    {"logs", regexp.MustCompile(`(?i)\b(logs?|log files|IP address(?:es)?)\b`)},
+    {"cookies", regexp.MustCompile(`(?i)\bcookies?\b`)},
+    {"marketing", regexp.MustCompile(`(?i)\b(marketing|newsletters?|advertising|promotional)\b`)},
+    {"location", regexp.MustCompile(`(?i)\b(location|geolocation)\b`)},
+    {"recordings", regexp.MustCompile(`(?i)\b(call recordings?|recordings?|CCTV|video footage)\b`)},
+    {"support", regexp.MustCompile(`(?i)\b(support|customer service|correspondence|communications?|enquir\w*|inquir\w*|chat)\b`)},
+    {"usage", regexp.MustCompile(`(?i)\b(usage data|analytics|device (?:data|information)|telemetry)\b`)},
+    {"health", regexp.MustCompile(`(?i)\b(biometric|health|medical)\b`)},
+    {"account", regexp.MustCompile(`(?i)\b(account|profile|registration)\b`)},
+}
+
+// generalCategory is used when neither the sentence nor its heading names a kind of data.
+const generalCategory = "personal_data"
+
+// bases are legal grounds for keeping data, most specific first.
+var bases = []pattern{
+    {"legal_obligation", regexp.MustCompile(`(?i)\b(legal (?:obligations?|requirements?)|required (?:by|under) (?:applicable )?laws?|comply with (?:applicable |our )?(?:laws?|legal|tax|accounting|regulatory)|statutory|tax (?:laws?|purposes|regulations)|accounting (?:laws?|rules|purposes)|regulatory (?:obligations?|requirements?))\b`)},
+    {"legal_claims", regexp.MustCompile(`(?i)\b(legal claims?|limitation periods?|disputes?|litigation|defend\w* (?:against )?(?:legal )?claims)\b`)},
+    {"legitimate_interests", regexp.MustCompile(`(?i)\blegitimate interests?\b`)},
+    {"contract", regexp.MustCompile(`(?i)\b(perform(?:ance of)? (?:the|our|a|your) (?:contract|agreement)|contractual)\b`)},
+    {"consent", regexp.MustCompile(`(?i)\b(your consent|withdraw (?:your )?consent|until you (?:withdraw|unsubscribe|opt out))\b`)},
+}
+
+// triggers are the events a period counts from.
+var triggers = []pattern{
+    {"account_closure", regexp.MustCompile(`(?i)\b(after (?:you )?(?:close|delete|terminat\w*|deactivat\w*) (?:your )?account|account (?:closure|deletion|termination|is closed|is deleted))\b`)},
+    {"last_activity", regexp.MustCompile(`(?i)\b((?:last|most recent) (?:activity|login|log-in|interaction|use|contact)|inactiv\w*)\b`)},
+    {"contract_end", regexp.MustCompile(`(?i)\b(end of (?:the |our |your )?(?:contract|relationship|subscription|agreement)|termination of (?:the |your |our )?(?:contract|subscription|agreement))\b`)},
+    {"transaction", regexp.MustCompile(`(?i)\bafter (?:the |each |your )?(?:transaction|purchase|order)\b`)},
+    {"collection", regexp.MustCompile(`(?i)\b((?:from|after) (?:the date of )?collection|from the date (?:we|it was|they were) (?:collected|received))\b`)},
+}
+
+// Extract returns one entry per distinct retention statement in doc: a sentence
+// with a retention cue and a period, an indefinite term or an as-long-as-necessary
+// term. Repeats of the same category, period and basis are kept once. It reports
+// false when doc's language has no vocabulary. Categories, bases and triggers are
+// recognized in English only; elsewhere an entry has the general category.
+func Extract(doc ports.Document, typ string) ([]ports.RetentionEntry, bool) {
+    loc, ok := locales[doc.Language]
+    if !ok { return nil, false }
+    text := doc.Text
+    var out []ports.RetentionEntry
+    seen := map[string]bool{}
+    for _, sp := range textutil.Sentences(text) {
+        s := text[sp[0]:sp[1]]
+        if !loc.cue.MatchString(s) { continue }
+        e := ports.RetentionEntry{Quote: s, SpanStart: sp[0], SpanEnd: sp[1], DocType: typ, SectionURL: doc.URL}
+        switch days := loc.longest(s); {
+        case days > 0:
+            e.Period, e.Days = ports.RetentionDays, days
+        case loc.indefiniteTerm(s):
+            e.Period = ports.RetentionIndefinite
+        case loc.asNeeded.MatchString(s):
+            e.Period = ports.RetentionAsNeeded
+        default:
+            continue
+        }
+        heading := ""
+        if sec, ok := doc.SectionAt(sp[0]); ok {
+            heading = sec.Heading
+            e.Section, e.SectionURL = sec.Heading, doc.SectionURL(sec)
+        }
+        e.Category = category(s, heading)
+        e.LegalBasis = first(bases, s)
+        e.Trigger = first(triggers, s)
+        key := strings.Join([]string{e.Category, e.Period, strconv.Itoa(e.Days), e.LegalBasis, e.Trigger}, "|")
+        if seen[key] { continue }
+        seen[key] = true
+        out = append(out, e)
+    }
+    return out, true
+}
+
+// longest is the longest period stated in s, in days; ages ("13 years old") and
+// frequencies ("reviewed once a year") do not count. Periods under a day round up to one.
+func (loc locale) longest(s string) int {
+    best := 0
+    for _, m := range loc.period.FindAllStringSubmatchIndex(s, -1) {
+        if m[6] >= 0 { continue }
+        before, num := s[textutil.ClampStart(s, m[2]-16):m[2]], strings.ToLower(s[m[2]:m[3]])
+        if loc.every.MatchString(before) { continue }
+        if loc.articles[num] && !loc.article.MatchString(before) { continue }
+        n, ok := loc.numbers[num]
+        if !ok { n = textutil.Atoi(num) }
+        unit := loc.units[strings.ToLower(s[m[4]:m[5]])]
+        days := n * unitDays[unit]
+        if unit == "hour" { days = (n + 23) / 24 }
+        best = max(best, days)
+    }
+    return best
+}
+
+// indefiniteTerm reports an indefinite term that is not negated ("we do not keep
+// data indefinitely").
+func (loc locale) indefiniteTerm(s string) bool {
+    at := loc.indefinite.FindStringIndex(s)
+    return at != nil && !loc.negation.MatchString(s[textutil.ClampStart(s, at[0]-60):at[0]])
+}
+
+func category(s, heading string) string {
+    for _, text := range []string{s, heading} {
+        for _, c := range categories {
+            if c.re.MatchString(text) { return c.name }
+        }
+    }
+    return generalCategory
+}
+
+func first(table []pattern, s string) string {
+    for _, t := range table {
+        if t.re.MatchString(s) { return t.name }
+    }
+    return ""
+}
@@ -0,0 +1,53 @@
+package retention
+
+import (
+    "testing"
+
+    "camille/internal/ports"
+)
+
+func TestExtractPeriods(t *testing.T) {
+    for _, tc := range []struct {
+        lang, text string
+        period     string // empty when the sentence is no retention statement
+        days       int
+    }{
+        {"en", "We retain invoices for ten years.", ports.RetentionDays, 3650},
+        {"en", "Logs are deleted after 36 hours.", ports.RetentionDays, 2},
+        {"en", "We keep your data for a year.", ports.RetentionDays, 365},
+        {"en", "We review what we keep once a year.", "", 0},
+        {"en", "We delete accounts of users under 13 years old.", "", 0},
+        {"en", "We do not keep your data indefinitely.", "", 0},
+        {"en", "We keep your data for as long as necessary.", ports.RetentionAsNeeded, 0},
+        {"de", "Rechnungsdaten speichern wir zehn Jahre, wie es das Handelsrecht verlangt.", ports.RetentionDays, 3650},
+        {"de", "Server-Protokolle werden nach 14 Tagen gelöscht.", ports.RetentionDays, 14},
+        {"de", "Wir löschen alle zwei Jahre alte Sicherungen.", "", 0},
+        {"de", "Wir speichern Ihre Daten, solange dies für die Vertragserfüllung erforderlich ist.", ports.RetentionAsNeeded, 0},
+        {"fr", "Nous conservons les données de votre compte pendant trois ans après votre dernière connexion.", ports.RetentionDays, 1095},
+        {"fr", "Les journaux sont supprimés au bout d'un an.", ports.RetentionDays, 365},
+        {"fr", "Nous ne conservons pas vos données indéfiniment.", "", 0},
+        {"es", "Conservaremos sus datos de forma indefinida mientras sean útiles para fines estadísticos.", ports.RetentionIndefinite, 0},
+        {"es", "Eliminamos los registros tras seis meses.", ports.RetentionDays, 180},
+        {"nl", "Wij bewaren uw klantgegevens twee jaar na uw laatste aankoop.", ports.RetentionDays, 730},
+        {"it", "Conserviamo i dati degli ordini per 24 mesi, dopodiché vengono cancellati.", ports.RetentionDays, 720},
+        {"pt", "Mantemos os seus dados pelo tempo necessário para cumprir estas finalidades.", ports.RetentionAsNeeded, 0},
+        {"pt", "Os registos são apagados após noventa dias.", ports.RetentionDays, 90},
+    } {
+        t.Run(tc.lang+": "+tc.text, func(t *testing.T) {
+            entries, ok := Extract(ports.Document{Text: tc.text, Language: tc.lang}, "privacy")
+            if !ok { t.Fatalf("no vocabulary for %s", tc.lang) }
+            if tc.period == "" {
+                if len(entries) != 0 { t.Errorf("entries = %+v, want none", entries) }
+                return
+            }
+            if len(entries) != 1 { t.Fatalf("entries = %+v, want one", entries) }
+            if e := entries[0]; e.Period != tc.period || e.Days != tc.days { t.Errorf("period = %s/%d, want %s/%d", e.Period, e.Days, tc.period, tc.days) }
+        })
+    }
+}
+
+func TestExtractUnknownLanguage(t *testing.T) {
+    for _, lang := range []string{"", "pl"} {
+        if entries, ok := Extract(ports.Document{Text: "Przechowujemy dane przez dwa lata.", Language: lang}, "privacy"); ok { t.Errorf("%q: extracted %+v", lang, entries) }
+    }
+}
@@ -0,0 +1,125 @@
+package rules
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners/policy/textutil"
+)
+
+// maxQuote bounds a quote; longer sentences are cut to a window around the match.
+const maxQuote = 400
+
+// Fact is one rule hit. Quote is exactly Document.Text[SpanStart:SpanEnd].
+type Fact struct {
+    RuleID     string  `json:"rule_id"`
+    Code       string  `json:"code"`
+    Value      any     `json:"value"`
+    Severity   string  `json:"severity"`
+    Confidence float64 `json:"confidence"`
+    Negated    bool    `json:"negated,omitempty"`
+    Quote      string  `json:"quote"`
+    SpanStart  int     `json:"span_start"`
+    SpanEnd    int     `json:"span_end"`
+    Match      string  `json:"match"`
+    Similarity float64 `json:"similarity,omitempty"` // quote match score for AI facts
+    DocType    string  `json:"doc_type,omitempty"`
+    Section    string  `json:"section,omitempty"`
+    SectionURL string  `json:"section_url"`
+}
+
+// Apply runs every rule that targets typ and the document's language.
+func (c *Catalog) Apply(doc ports.Document, typ string) []Fact {
+    var out []Fact
+    for _, r := range c.Rules {
+        if !r.applies(typ, doc.Language) { continue }
+        out = append(out, r.apply(doc, typ)...)
+    }
+    return out
+}
+
+func (r *Rule) applies(typ, lang string) bool {
+    return (len(r.Types) == 0 || contains(r.Types, typ)) && (len(r.Languages) == 0 || lang == "" || contains(r.Languages, lang))
+}
+
+func (r *Rule) apply(doc ports.Document, typ string) []Fact {
+    text := doc.Text
+    seen := map[[2]int]bool{}
+    var out []Fact
+    for _, re := range r.patterns {
+        for _, m := range re.FindAllStringIndex(text, -1) {
+            if inHeading(doc, m[0]) || !r.near(text, m[0], m[1]) { continue }
+            value, negated := r.Value, false
+            if r.negated(text, m[0], m[1]) {
+                if r.Negation.Value == nil { continue }
+                value, negated = r.Negation.Value, true
+            }
+            start, end := textutil.Sentence(text, m[0], m[1], maxQuote)
+            if seen[[2]int{start, end}] { continue }
+            seen[[2]int{start, end}] = true
+            f := Fact{
+                RuleID: r.ID, Code: r.Signal, Value: value, Severity: r.Severity, Confidence: r.Confidence,
+                Negated: negated, Quote: text[start:end], SpanStart: start, SpanEnd: end,
+                Match: text[m[0]:m[1]], DocType: typ, SectionURL: doc.URL,
+            }
+            if s, ok := doc.SectionAt(m[0]); ok {
+                f.Section = s.Heading
+                f.SectionURL = doc.SectionURL(s)
+            }
+            out = append(out, f)
+        }
+    }
+    return out
+}
+
+// inHeading reports whether offset falls in a heading's own text: headings name a
+// topic ("Sale of personal information") rather than state a practice.
+func inHeading(doc ports.Document, offset int) bool {
+    for _, s := range doc.Sections {
+        end := s.Start + len(s.Heading)
+        if offset >= s.Start && offset < end && end <= len(doc.Text) && doc.Text[s.Start:end] == s.Heading { return true }
+    }
+    return false
+}
+
+// near reports whether every proximity group matches within its window of [start, end).
+func (r *Rule) near(text string, start, end int) bool {
+    for _, n := range r.Near {
+        from, to := textutil.ClampStart(text, start-n.Within), textutil.ClampEnd(text, end+n.Within)
+        if n.Sentence {
+            s, e := textutil.Sentence(text, start, end, maxQuote)
+            from, to = max(from, s), min(to, e)
+        }
+        if !anyMatch(n.patterns, text[from:to]) { return false }
+    }
+    return true
+}
+
+// negated looks for a negation cue in the same sentence, shortly before the match
+// or, when the rule allows it, shortly after.
+func (r *Rule) negated(text string, start, end int) bool {
+    if r.Negation == nil { return false }
+    from, to := textutil.ClampStart(text, start-r.Negation.Within), end
+    s, e := textutil.Sentence(text, start, end, maxQuote)
+    if s > from { from = s }
+    if r.Negation.After > 0 { to = min(textutil.ClampEnd(text, end+r.Negation.After), e) }
+    return anyMatch(r.Negation.patterns, text[from:to])
+}
+
+func anyMatch(res []*regexp.Regexp, s string) bool {
+    for _, re := range res {
+        if re.MatchString(s) { return true }
+    }
+    return false
+}
+
+func contains(xs []string, x string) bool {
+    for _, v := range xs {
+        if strings.EqualFold(v, x) { return true }
+    }
+    return false
+}
+
+func sameValue(a, b any) bool { return fmt.Sprint(a) == fmt.Sprint(b) }
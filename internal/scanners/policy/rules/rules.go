@@ -0,0 +1,185 @@
+// Package rules is the deterministic policy extractor: a versioned YAML catalog of
+// patterns, proximity constraints and negations that turns policy text into facts
+// with exact spans, no model required.
+package rules
+
+import (
+    "embed"
+    "errors"
+    "fmt"
+    "io/fs"
+    "os"
+    "path"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+//go:embed catalog.yaml
+var builtin []byte
+
+// builtinLanguages holds the per-language rule files, lang/<code>.yaml.
+//
+//go:embed lang/*.yaml
+var builtinLanguages embed.FS
+
+// Catalog is a loaded, compiled rule set.
+type Catalog struct {
+    Version string `yaml:"version"`
+    // Absent holds the value a signal takes when a readable policy yields no fact for it.
+    Absent map[string]any `yaml:"absent"`
+    Rules  []*Rule        `yaml:"rules"`
+}
+
+// Rule maps text matches to a signal value. A match counts only when every Near
+// group also matches within its window; a negation cue shortly before the match
+// either flips the fact to Negation.Value or, with no value, suppresses it.
+type Rule struct {
+    ID         string    `yaml:"id"`
+    Signal     string    `yaml:"signal"`
+    Value      any       `yaml:"value"` // defaults to true
+    Severity   string    `yaml:"severity"`
+    Confidence float64   `yaml:"confidence"`
+    Types      []string  `yaml:"types"`     // policy document types; empty means all
+    Languages  []string  `yaml:"languages"` // document languages; empty means all
+    Patterns   []string  `yaml:"patterns"`
+    Near       []Near    `yaml:"near"`
+    Negation   *Negation `yaml:"negation"`
+    Examples   []Example `yaml:"examples"`
+
+    patterns []*regexp.Regexp
+}
+
+// Near requires one of Patterns within Within bytes of the main match; with
+// Sentence set, only within the main match's sentence.
+type Near struct {
+    Patterns []string `yaml:"patterns"`
+    Within   int      `yaml:"within"`
+    Sentence bool     `yaml:"sentence"`
+
+    patterns []*regexp.Regexp
+}
+
+// Negation cues are looked for in the Within bytes before the main match and,
+// for languages that negate after the verb ("wir verkaufen … nicht"), the After
+// bytes following it. Both windows stop at the sentence.
+type Negation struct {
+    Patterns []string `yaml:"patterns"`
+    Within   int      `yaml:"within"`
+    After    int      `yaml:"after"`
+    Value    any      `yaml:"value"`
+
+    patterns []*regexp.Regexp
+}
+
+// Example is a golden case checked whenever the catalog loads: Text must yield a
+// fact with Value, or no fact at all when None is set.
+type Example struct {
+    Text  string `yaml:"text"`
+    Value any    `yaml:"value"`
+    None  bool   `yaml:"none"`
+}
+
+// Load reads a catalog from path, or the built-in catalog when path is empty,
+// with the per-language rule files in the lang directory beside it. A catalog
+// without a lang directory gets the built-in language files.
+func Load(path string) (*Catalog, error) {
+    data, langs := builtin, fs.FS(builtinLanguages)
+    if path != "" {
+        var err error
+        if data, err = os.ReadFile(path); err != nil { return nil, err }
+        dir := filepath.Dir(path)
+        if fi, err := os.Stat(filepath.Join(dir, "lang")); err == nil && fi.IsDir() { langs = os.DirFS(dir) }
+    }
+    files, err := readLanguages(langs)
+    if err != nil { return nil, err }
+    return Parse(data, files)
+}
+
+// readLanguages reads lang/*.yaml from fsys, keyed by language code.
+func readLanguages(fsys fs.FS) (map[string][]byte, error) {
+    names, err := fs.Glob(fsys, "lang/*.yaml")
+    if err != nil { return nil, err }
+    out := map[string][]byte{}
+    for _, name := range names {
+        raw, err := fs.ReadFile(fsys, name)
+        if err != nil { return nil, err }
+        out[strings.TrimSuffix(path.Base(name), ".yaml")] = raw
+    }
+    return out, nil
+}
+
+// Parse decodes, compiles and verifies a catalog and its per-language rule files,
+// keyed by language code; any failing example is an error. Rules in a language
+// file apply to that language unless they list their own. Language files share
+// the catalog's version.
+func Parse(data []byte, languages map[string][]byte) (*Catalog, error) {
+    var c Catalog
+    if err := yaml.Unmarshal(data, &c); err != nil { return nil, fmt.Errorf("rules: %w", err) }
+    if c.Version == "" { return nil, errors.New("rules: catalog has no version") }
+    codes := make([]string, 0, len(languages))
+    for lang := range languages { codes = append(codes, lang) }
+    sort.Strings(codes)
+    for _, lang := range codes {
+        var file struct{ Rules []*Rule `yaml:"rules"` }
+        if err := yaml.Unmarshal(languages[lang], &file); err != nil { return nil, fmt.Errorf("rules: lang/%s.yaml: %w", lang, err) }
+        for _, r := range file.Rules {
+            if len(r.Languages) == 0 { r.Languages = []string{lang} }
+        }
+        c.Rules = append(c.Rules, file.Rules...)
+    }
+    if err := c.compile(); err != nil { return nil, err }
+    if err := c.Verify(); err != nil { return nil, err }
+    return &c, nil
+}
+
+func (c *Catalog) compile() error {
+    seen := map[string]bool{}
+    var errs []error
+    for _, r := range c.Rules {
+        if r.ID == "" || r.Signal == "" || len(r.Patterns) == 0 {
+            errs = append(errs, fmt.Errorf("rules: rule %q needs id, signal and patterns", r.ID))
+            continue
+        }
+        if seen[r.ID] { errs = append(errs, fmt.Errorf("rules: duplicate rule id %q", r.ID)) }
+        seen[r.ID] = true
+        if r.Value == nil { r.Value = true }
+        if r.Severity == "" { r.Severity = "info" }
+        if r.Confidence == 0 { r.Confidence = 0.7 }
+        var err error
+        if r.patterns, err = compileAll(r.Patterns); err != nil { errs = append(errs, fmt.Errorf("rules: %s: %w", r.ID, err)) }
+        for i := range r.Near {
+            if r.Near[i].Within == 0 { r.Near[i].Within = 120 }
+            if r.Near[i].patterns, err = compileAll(r.Near[i].Patterns); err != nil { errs = append(errs, fmt.Errorf("rules: %s: %w", r.ID, err)) }
+        }
+        if r.Negation != nil {
+            if r.Negation.Within == 0 { r.Negation.Within = 40 }
+            if r.Negation.patterns, err = compileAll(r.Negation.Patterns); err != nil { errs = append(errs, fmt.Errorf("rules: %s: %w", r.ID, err)) }
+        }
+    }
+    return errors.Join(errs...)
+}
+
+// compileAll compiles case-insensitive patterns.
+func compileAll(patterns []string) ([]*regexp.Regexp, error) {
+    out := make([]*regexp.Regexp, 0, len(patterns))
+    for _, p := range patterns {
+        re, err := regexp.Compile("(?i)" + p)
+        if err != nil { return nil, err }
+        out = append(out, re)
+    }
+    return out, nil
+}
+
+// Codes lists the distinct signal codes the catalog can emit.
+func (c *Catalog) Codes() []string {
+    seen := map[string]bool{}
+    var out []string
+    for _, r := range c.Rules {
+        if !seen[r.Signal] { seen[r.Signal] = true; out = append(out, r.Signal) }
+    }
+    return out
+}
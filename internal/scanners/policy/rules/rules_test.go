@@ -0,0 +1,87 @@
+package rules
+
+import (
+    "fmt"
+    "io/fs"
+    "testing"
+
+    "camille/internal/ports"
+)
+
+// TestCatalogs loads the built-in catalog with each language file on its own and
+// then together, so a broken golden example fails here rather than at startup.
+func TestCatalogs(t *testing.T) {
+    files, err := readLanguages(fs.FS(builtinLanguages))
+    if err != nil { t.Fatal(err) }
+    if _, err := Parse(builtin, nil); err != nil { t.Errorf("catalog.yaml: %v", err) }
+    for lang, raw := range files {
+        if _, err := Parse(builtin, map[string][]byte{lang: raw}); err != nil { t.Errorf("lang/%s.yaml: %v", lang, err) }
+    }
+    c, err := Load("")
+    if err != nil { t.Fatalf("Load: %v", err) }
+    if len(c.Rules) == 0 || len(c.Codes()) == 0 { t.Error("built-in catalog has no rules") }
+}
+
+// rule compiles a single rule the way Parse does.
+func rule(t *testing.T, r *Rule) *Rule {
+    t.Helper()
+    r.ID, r.Signal = "test", "policy.test"
+    c := Catalog{Rules: []*Rule{r}}
+    if err := c.compile(); err != nil { t.Fatal(err) }
+    return r
+}
+
+func values(facts []Fact) string {
+    var out []any
+    for _, f := range facts { out = append(out, f.Value) }
+    return fmt.Sprint(out)
+}
+
+func TestNearWindow(t *testing.T) {
+    r := rule(t, &Rule{Patterns: []string{`\bsell\b`}, Near: []Near{{Patterns: []string{`\bdata\b`}, Within: 20}}})
+    s := rule(t, &Rule{Patterns: []string{`\bsell\b`}, Near: []Near{{Patterns: []string{`\bdata\b`}, Within: 80, Sentence: true}}})
+    for _, tc := range []struct {
+        text           string
+        within, inSent bool
+    }{
+        {"We sell your data.", true, true},
+        {"Your data: we sell it.", true, true},
+        {"We sell shoes, hats, scarves and gloves; we also keep data.", false, true},
+        {"Our data is safe. We sell shoes.", true, false},
+        {"We sell shoes.\nData is kept for a year.", true, false},
+    } {
+        doc := ports.Document{Text: tc.text}
+        if got := len(r.apply(doc, "")) > 0; got != tc.within { t.Errorf("within 20: %q matched %v, want %v", tc.text, got, tc.within) }
+        if got := len(s.apply(doc, "")) > 0; got != tc.inSent { t.Errorf("same sentence: %q matched %v, want %v", tc.text, got, tc.inSent) }
+    }
+}
+
+func TestNegationWindow(t *testing.T) {
+    before := rule(t, &Rule{Patterns: []string{`\bsell\b`}, Negation: &Negation{Patterns: []string{`\bnot\b`, `\bnever\b`}, Within: 15, Value: false}})
+    after := rule(t, &Rule{Patterns: []string{`\bverkaufen\b`}, Negation: &Negation{Patterns: []string{`\bnicht\b`}, Within: 15, After: 30, Value: false}})
+    drop := rule(t, &Rule{Patterns: []string{`\bsell\b`}, Negation: &Negation{Patterns: []string{`\bnot\b`}, Within: 15}})
+    for _, tc := range []struct {
+        r    *Rule
+        text string
+        want string
+    }{
+        {before, "We do not sell your data.", "[false]"},
+        {before, "We never sell your data.", "[false]"},
+        {before, "We do not share it with anyone, but we sell your data.", "[true]"},
+        {before, "We do not track you. We sell your data.", "[true]"},
+        {before, "We sell your data, not your photos.", "[true]"},
+        {after, "Wir verkaufen Ihre Daten nicht.", "[false]"},
+        {after, "Wir verkaufen Ihre Daten. Nicht alle Daten sind betroffen.", "[true]"},
+        {drop, "We do not sell your data.", "[]"},
+    } {
+        if got := values(tc.r.apply(ports.Document{Text: tc.text}, "")); got != tc.want { t.Errorf("%q: got %s, want %s", tc.text, got, tc.want) }
+    }
+}
+
+func TestQuoteSpan(t *testing.T) {
+    r := rule(t, &Rule{Patterns: []string{`\bsell\b`}})
+    text := "Intro line\nFirst sentence. We sell your data to partners! Last one."
+    facts := r.apply(ports.Document{Text: text}, "")
+    if len(facts) != 1 { t.Fatalf("facts = %v", facts) }
+    if f := facts[0]; f.Quote != "We sell your data to partners!" || text[f.SpanStart:f.SpanEnd] != f.Quote { t.Errorf("quote = %q at %d:%d", f.Quote, f.SpanStart, f.SpanEnd) }
+}
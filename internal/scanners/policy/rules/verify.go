@@ -0,0 +1,40 @@
+package rules
+
+import (
+    "errors"
+    "fmt"
+
+    "camille/internal/ports"
+)
+
+// Verify runs every rule's examples against that rule alone and reports each
+// mismatch. Parse calls it, so a catalog with a broken golden case never loads.
+func (c *Catalog) Verify() error {
+    var errs []error
+    for _, r := range c.Rules {
+        if len(r.Examples) == 0 { errs = append(errs, fmt.Errorf("rules: %s has no examples", r.ID)) }
+        for i, ex := range r.Examples {
+            doc := ports.Document{Text: ex.Text}
+            facts := r.apply(doc, "")
+            switch {
+            case ex.None && len(facts) > 0:
+                errs = append(errs, fmt.Errorf("rules: %s example %d: want no fact, got %v from %q", r.ID, i+1, facts[0].Value, facts[0].Match))
+            case !ex.None && len(facts) == 0:
+                errs = append(errs, fmt.Errorf("rules: %s example %d: no fact for %q", r.ID, i+1, ex.Text))
+            case !ex.None && !sameValue(facts[0].Value, expected(r, ex)):
+                errs = append(errs, fmt.Errorf("rules: %s example %d: want %v, got %v", r.ID, i+1, expected(r, ex), facts[0].Value))
+            }
+            for _, f := range facts {
+                if ex.Text[f.SpanStart:f.SpanEnd] != f.Quote {
+                    errs = append(errs, fmt.Errorf("rules: %s example %d: quote does not match its span", r.ID, i+1))
+                }
+            }
+        }
+    }
+    return errors.Join(errs...)
+}
+
+func expected(r *Rule, ex Example) any {
+    if ex.Value == nil { return r.Value }
+    return ex.Value
+}
@@ -0,0 +1,183 @@
+// Package policy locates a site's privacy policy, terms of service and cookie policy
+// and extracts their readable text.
+package policy
+
+import (
+    "context"
+    "encoding/json"
+    "log"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+    "camille/internal/scanners/policy/chunks"
+    "camille/internal/scanners/policy/discovery"
+    "camille/internal/scanners/policy/language"
+    "camille/internal/scanners/policy/rules"
+)
+
+const source = "policy"
+
+// maxDocument caps policy document downloads; PDFs routinely exceed the fetcher default.
+const maxDocument = 10 << 20
+
+// Scanner emits policy.<type>.found and policy.<type>.url signals, stores each
+// discovered document's extracted text as evidence, reads its stated metadata and
+// extracts facts from it with the rule catalog and, when configured, the AI extractor.
+type Scanner struct {
+    Discoverer *discovery.Discoverer
+    Fetcher    ports.Fetcher
+    Extractor  ports.DocumentExtractor
+    Rules      *rules.Catalog
+    AI         ports.AIExtractor // optional
+    // QuoteThreshold is the similarity an AI quote needs to be kept; 0 means quotes.DefaultThreshold.
+    QuoteThreshold float64
+    // Chunking sets the token budget for AI chunks; zero uses chunks.DefaultOptions.
+    Chunking chunks.Options
+    // Budget meters AI calls; nil neither limits nor records them.
+    Budget *Budget
+    // Versions keeps every distinct text of each document for change detection; optional.
+    Versions ports.PolicyVersionRepository
+    // Resolver checks that DSR email domains accept mail; optional.
+    Resolver ports.Resolver
+    // Trackers attributes named recipients to their owners; optional.
+    Trackers ports.TrackerDirectory
+    // Recipients holds earlier scans' recipient lists for change tracking; optional.
+    Recipients ports.RecipientRepository
+}
+
+func New(fetcher ports.Fetcher, extractor ports.DocumentExtractor, catalog *rules.Catalog, ai ports.AIExtractor) *Scanner {
+    return &Scanner{Discoverer: discovery.New(fetcher), Fetcher: fetcher, Extractor: extractor, Rules: catalog, AI: ai}
+}
+
+// policyDoc is an extracted document of a policy type and the hash of its evidence row.
+type policyDoc struct {
+    Type     string
+    Doc      ports.Document
+    Evidence string
+}
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    cands, err := s.Discoverer.Discover(ctx, t)
+    if err != nil { return res, err }
+
+    raw, _ := json.Marshal(cands)
+    ev := scanners.NewEvidence("policy.discovery", scanners.LandingURL(t), raw, map[string]any{"candidates": cands})
+    res.Evidence = append(res.Evidence, ev)
+
+    var docs []policyDoc
+    var privacyPage ports.FetchResponse
+    found := false
+    unfetched := map[string]bool{}
+    for _, typ := range domain.PolicyTypes {
+        best, ok := discovery.Best(cands, typ)
+        discovered := ev.Hash
+        if !ok && typ == domain.PolicySubprocessors && privacyPage.Body != nil {
+            // Sub-processor lists are mostly linked from the privacy policy, not the landing page.
+            if linked := s.Discoverer.Follow(ctx, t, privacyPage, typ); len(linked) > 0 {
+                raw, _ := json.Marshal(linked)
+                lev := scanners.NewEvidence("policy.discovery", privacyPage.URL, raw, map[string]any{"candidates": linked})
+                res.Evidence = append(res.Evidence, lev)
+                best, ok, discovered = linked[0], true, lev.Hash
+            }
+        }
+        if typ == domain.PolicyPrivacy { found = ok }
+        severity := "info"
+        if !ok && typ == domain.PolicyPrivacy { severity = "high" }
+        res.Signals = append(res.Signals, scanners.NewSignal("policy."+typ+".found", ok, severity, 0.8, source, discovered))
+        if !ok { continue }
+        confidence := 0.5
+        if best.Score >= 6 { confidence = 0.9 } else if best.Score >= 3 { confidence = 0.7 }
+        refs := []string{discovered}
+        if doc, page, err := s.document(ctx, best.FinalURL); err == nil {
+            if typ == domain.PolicyPrivacy { privacyPage = page }
+            d := addDocument(&res, typ, doc)
+            refs = append(refs, d.Evidence)
+            docs = append(docs, d)
+            s.recordVersion(ctx, t, &res, d)
+        } else if ctx.Err() != nil {
+            return res, ctx.Err()
+        } else {
+            unfetched[typ] = true
+        }
+        res.Signals = append(res.Signals, scanners.NewSignal("policy."+typ+".url", best.FinalURL, "info", confidence, source, refs...))
+    }
+    s.extractMetadata(ctx, &res, docs)
+    s.extractRetention(&res, docs)
+    s.extractRecipients(ctx, t, &res, docs, unfetched)
+    if found && s.Rules != nil { s.extractFacts(ctx, t, &res, docs) }
+    return res, nil
+}
+
+// Analyze runs fact extraction over documents already in hand, keyed by policy
+// type, as Scan does after discovery and fetching. The eval harness uses it to
+// score stored gold-set documents offline.
+func (s *Scanner) Analyze(ctx context.Context, t ports.ScanTarget, byType map[string]ports.Document) ports.ScanResult {
+    var res ports.ScanResult
+    var docs []policyDoc
+    for _, typ := range domain.PolicyTypes {
+        if doc, ok := byType[typ]; ok { docs = append(docs, addDocument(&res, typ, doc)) }
+    }
+    s.extractMetadata(ctx, &res, docs)
+    s.extractRetention(&res, docs)
+    s.extractRecipients(ctx, t, &res, docs, nil)
+    if _, ok := byType[domain.PolicyPrivacy]; ok && s.Rules != nil { s.extractFacts(ctx, t, &res, docs) }
+    return res
+}
+
+// addDocument settles a document's language, which selects the rules and labels
+// extractors use, and stores it as evidence with its readable and language signals.
+func addDocument(res *ports.ScanResult, typ string, doc ports.Document) policyDoc {
+    doc.Language = language.Resolve(doc.Language, doc.Text)
+    dev := documentEvidence(typ, doc)
+    res.Evidence = append(res.Evidence, dev)
+    // Image-only documents (scanned PDFs) have no text to judge, which is not the same as an empty policy.
+    var readable any = true
+    if doc.Unreadable { readable = "unknown" }
+    res.Signals = append(res.Signals, scanners.NewSignal("policy."+typ+".readable", readable, "info", 0.9, source, dev.Hash))
+    if doc.Language != "" && !doc.Unreadable {
+        res.Signals = append(res.Signals, scanners.NewSignal("policy."+typ+".language", doc.Language, "info", 0.8, source, dev.Hash))
+    }
+    return policyDoc{Type: typ, Doc: doc, Evidence: dev.Hash}
+}
+
+// recordVersion stores a readable document as a version and emits
+// policy.<type>.changed: true when its text differs from the one seen last.
+func (s *Scanner) recordVersion(ctx context.Context, t ports.ScanTarget, res *ports.ScanResult, d policyDoc) {
+    if s.Versions == nil || d.Doc.Unreadable || t.DomainID == "" { return }
+    _, changed, err := s.Versions.RecordPolicyVersion(ctx, t.DomainID, t.ScanID, d.Type, d.Doc)
+    if err != nil {
+        log.Printf("policy %s: record %s version: %v", t.Domain, d.Type, err)
+        return
+    }
+    severity := "info"
+    if changed { severity = "medium" }
+    res.Signals = append(res.Signals, scanners.NewSignal("policy."+d.Type+".changed", changed, severity, 0.9, source, d.Evidence))
+}
+
+// document fetches and extracts a policy document, returning the response with it.
+func (s *Scanner) document(ctx context.Context, url string) (ports.Document, ports.FetchResponse, error) {
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: url, SameSite: true, MaxBody: maxDocument})
+    if err != nil { return ports.Document{}, resp, err }
+    doc, err := s.Extractor.Extract(ctx, ports.RawDocument{URL: resp.URL, ContentType: resp.Header.Get("Content-Type"), Body: resp.Body})
+    return doc, resp, err
+}
+
+// documentEvidence stores the extracted text with its structure; unchanged policies dedupe by hash.
+func documentEvidence(typ string, doc ports.Document) domain.Evidence {
+    ev := scanners.NewEvidence("policy.document", doc.URL, []byte(doc.Text), map[string]any{
+        "type":       typ,
+        "title":      doc.Title,
+        "language":   doc.Language,
+        "doc_hash":   doc.Hash,
+        "unreadable": doc.Unreadable,
+        "text":       doc.Text,
+        "sections":   doc.Sections,
+        "spans":      doc.Spans,
+    })
+    ev.Meta = map[string]any{"doc_hash": doc.Hash, "content_type": doc.ContentType}
+    return ev
+}
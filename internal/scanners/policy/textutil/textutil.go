@@ -0,0 +1,95 @@
+// Package textutil holds the small text helpers the policy packages share:
+// sentence spans over extracted document text, rune-safe window bounds and
+// ASCII number parsing.
+package textutil
+
+import (
+    "unicode"
+    "unicode/utf8"
+)
+
+// Sentence widens [start, end) to its enclosing sentence or line, terminator
+// included and surrounding whitespace trimmed. A sentence longer than limit is
+// cut to a window of limit bytes centred on the match.
+func Sentence(text string, start, end, limit int) (int, int) {
+    s := start
+    for s > 0 && start-s < limit && !sentenceEnd(text, s-1) { s-- }
+    e := end
+    for e < len(text) && e-end < limit && !sentenceEnd(text, e) { e++ }
+    if e < len(text) && text[e] != '\n' { e++ } // keep the terminator
+    if e-s > limit {
+        half := (limit - (end - start)) / 2
+        s = max(s, ClampStart(text, start-half))
+        e = min(e, ClampEnd(text, end+half))
+    }
+    for s < e && IsSpace(text[s]) { s++ }
+    for e > s && IsSpace(text[e-1]) { e-- }
+    return s, e
+}
+
+// sentenceEnd reports whether text[i] terminates a sentence: a line break, or
+// '.', '!' or '?' followed by whitespace or the end of text.
+func sentenceEnd(text string, i int) bool {
+    switch text[i] {
+    case '\n':
+        return true
+    case '.', '!', '?':
+        return i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\n'
+    }
+    return false
+}
+
+// Sentences splits text into sentences and lines, as byte spans with surrounding
+// whitespace trimmed. Unlike Sentence, a sentence only ends at . ! or ? followed by
+// a space and a capital, digit or opening quote or parenthesis, so "e.g. our
+// partners" stays whole.
+func Sentences(text string) [][2]int {
+    var out [][2]int
+    start := 0
+    flush := func(end int) {
+        s, e := start, end
+        for s < e && IsSpace(text[s]) { s++ }
+        for e > s && IsSpace(text[e-1]) { e-- }
+        if s < e { out = append(out, [2]int{s, e}) }
+        start = end
+    }
+    for i := 0; i < len(text); i++ {
+        switch text[i] {
+        case '\n':
+            flush(i + 1)
+        case '.', '!', '?':
+            if i+2 < len(text) && text[i+1] == ' ' {
+                r, _ := utf8.DecodeRuneInString(text[i+2:])
+                if unicode.IsUpper(r) || unicode.IsDigit(r) || r == '"' || r == '“' || r == '(' { flush(i + 1) }
+            }
+        }
+    }
+    flush(len(text))
+    return out
+}
+
+// ClampStart bounds i to text for use as the start of a window, moving it forward
+// off a rune continuation byte.
+func ClampStart(text string, i int) int {
+    if i <= 0 { return 0 }
+    for i < len(text) && !utf8.RuneStart(text[i]) { i++ }
+    return i
+}
+
+// ClampEnd bounds i to text for use as the end of a window, moving it back off a
+// rune continuation byte.
+func ClampEnd(text string, i int) int {
+    if i >= len(text) { return len(text) }
+    for i > 0 && !utf8.RuneStart(text[i]) { i-- }
+    return i
+}
+
+// IsSpace reports ASCII whitespace; extracted text has no other kind.
+func IsSpace(b byte) bool { return b == ' ' || b == '\n' || b == '\t' || b == '\r' }
+
+// Atoi parses a run of ASCII digits already matched by a pattern.
+func Atoi(s string) int {
+    n := 0
+    for _, r := range s { n = n*10 + int(r-'0') }
+    return n
+}
@@ -0,0 +1,89 @@
+// Package scanners holds site scanners implementing ports.SiteScanner and the
+// helpers they share for building evidence-backed signals.
+package scanners
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "net"
+    "net/url"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "golang.org/x/net/publicsuffix"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+)
+
+// Hash returns the hex sha256 of raw artifact bytes; it is the evidence key signals refer to.
+func Hash(raw []byte) string {
+    sum := sha256.Sum256(raw)
+    return hex.EncodeToString(sum[:])
+}
+
+// NewEvidence builds an evidence row keyed by the hash of raw.
+func NewEvidence(sourceType, sourceURL string, raw []byte, payload any) domain.Evidence {
+    ev := domain.Evidence{
+        SourceType:  sourceType,
+        RetrievedAt: time.Now().UTC(),
+        Hash:        Hash(raw),
+        Payload:     payload,
+    }
+    if sourceURL != "" { ev.SourceURL = &sourceURL }
+    return ev
+}
+
+// NewSignal builds a signal attributed to source and backed by the given evidence hashes.
+func NewSignal(code string, value any, severity string, confidence float64, source string, evidence ...string) domain.Signal {
+    return domain.Signal{
+        Code:         code,
+        Value:        value,
+        Severity:     severity,
+        Confidence:   confidence,
+        Source:       source,
+        RetrievedAt:  time.Now().UTC(),
+        EvidenceRefs: evidence,
+    }
+}
+
+// Snippet returns at most n bytes of b, cut on a rune boundary.
+func Snippet(b []byte, n int) string {
+    if len(b) <= n { return string(b) }
+    b = b[:n]
+    for len(b) > 0 && !utf8.Valid(b) { b = b[:len(b)-1] }
+    return string(b) + "…"
+}
+
+// DNSEvidence records the raw answer to a DNS question; the source URL uses the RFC 4501 form.
+func DNSEvidence(name, rrtype string, records []string) domain.Evidence {
+    raw := []byte(name + " " + rrtype + "\n" + strings.Join(records, "\n"))
+    if records == nil { records = []string{} }
+    return NewEvidence("dns", "dns:"+name+"?type="+rrtype, raw, map[string]any{
+        "name":    name,
+        "type":    rrtype,
+        "records": records,
+    })
+}
+
+// SeverityIf returns severity when cond holds and "info" otherwise.
+func SeverityIf(cond bool, severity string) string {
+    if cond { return severity }
+    return "info"
+}
+
+// LandingURL is the page a scan fetches first: the submitted URL, or the origin root.
+func LandingURL(t ports.ScanTarget) string {
+    if u, err := url.Parse(t.URL); err == nil && u.Host != "" { return t.URL }
+    return t.Origin() + "/"
+}
+
+// Registrable returns the eTLD+1 of host, or host itself when it has none.
+func Registrable(host string) string {
+    host = strings.TrimSuffix(strings.ToLower(host), ".")
+    if net.ParseIP(host) != nil { return host }
+    r, err := publicsuffix.EffectiveTLDPlusOne(host)
+    if err != nil { return host }
+    return r
+}
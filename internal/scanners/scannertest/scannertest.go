@@ -0,0 +1,36 @@
+// Package scannertest runs site scanners against a fixed target and checks the
+// signals they report, for scanner tests.
+package scannertest
+
+import (
+    "context"
+    "fmt"
+    "testing"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+)
+
+// Domain is the registrable domain every scan targets; fixtures serve it.
+const Domain = "example.com"
+
+// Scan runs s against Domain and indexes the signals by code; a scan error fails the test.
+func Scan(t testing.TB, s ports.SiteScanner) map[string]domain.Signal {
+    t.Helper()
+    res, err := s.Scan(context.Background(), ports.ScanTarget{Domain: Domain})
+    if err != nil { t.Fatalf("Scan: %v", err) }
+    out := map[string]domain.Signal{}
+    for _, sig := range res.Signals { out[sig.Code] = sig }
+    return out
+}
+
+// Want reports an error unless sigs has code with value, compared as printed.
+func Want(t testing.TB, sigs map[string]domain.Signal, code string, value any) {
+    t.Helper()
+    s, ok := sigs[code]
+    if !ok {
+        t.Errorf("%s: missing", code)
+        return
+    }
+    if fmt.Sprint(s.Value) != fmt.Sprint(value) { t.Errorf("%s = %v, want %v", code, s.Value, value) }
+}
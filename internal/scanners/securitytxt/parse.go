@@ -0,0 +1,139 @@
+package securitytxt
+
+import (
+    "bytes"
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+)
+
+// File is a parsed security.txt (RFC 9116).
+type File struct {
+    Fields   map[string][]string // canonical field name -> values, in file order
+    Signed   bool                // OpenPGP cleartext signature framing present
+    Expires  time.Time           // zero if missing or unparsable
+    Errors   []string            // RFC violations
+    Warnings []string            // recommendations not followed
+}
+
+// Known field names, canonical casing.
+var knownFields = map[string]string{
+    "acknowledgments":     "Acknowledgments",
+    "canonical":           "Canonical",
+    "contact":             "Contact",
+    "csaf":                "CSAF",
+    "encryption":          "Encryption",
+    "expires":             "Expires",
+    "hiring":              "Hiring",
+    "policy":              "Policy",
+    "preferred-languages": "Preferred-Languages",
+}
+
+// Parse reads the plaintext body of a security.txt. For signed files pass the
+// clear-signed plaintext and set signed.
+func Parse(body []byte, signed bool, now time.Time) *File {
+    f := &File{Fields: map[string][]string{}, Signed: signed}
+    body = bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n"))
+    for i, raw := range strings.Split(string(body), "\n") {
+        line := strings.TrimSpace(raw)
+        if line == "" || strings.HasPrefix(line, "#") { continue }
+        name, value, ok := strings.Cut(line, ":")
+        if !ok {
+            f.Errors = append(f.Errors, fmt.Sprintf("line %d: not a field", i+1))
+            continue
+        }
+        name = strings.TrimSpace(name)
+        value = strings.TrimSpace(value)
+        if canon, known := knownFields[strings.ToLower(name)]; known {
+            name = canon
+        }
+        f.Fields[name] = append(f.Fields[name], value)
+    }
+    f.validate(now)
+    return f
+}
+
+func (f *File) validate(now time.Time) {
+    contacts := f.Fields["Contact"]
+    if len(contacts) == 0 {
+        f.Errors = append(f.Errors, "Contact field is required")
+    }
+    for _, c := range contacts {
+        if err := checkURI(c, "mailto", "tel", "https"); err != nil {
+            f.Errors = append(f.Errors, "Contact: "+err.Error())
+        }
+    }
+
+    expires := f.Fields["Expires"]
+    switch {
+    case len(expires) == 0:
+        f.Errors = append(f.Errors, "Expires field is required")
+    case len(expires) > 1:
+        f.Errors = append(f.Errors, "Expires must appear once")
+    }
+    if len(expires) > 0 {
+        t, err := time.Parse(time.RFC3339, expires[0])
+        if err != nil {
+            f.Errors = append(f.Errors, "Expires: not an RFC 3339 date-time")
+        } else {
+            f.Expires = t
+            if t.After(now.AddDate(1, 0, 0)) {
+                f.Warnings = append(f.Warnings, "Expires is more than a year in the future")
+            }
+        }
+    }
+
+    if len(f.Fields["Preferred-Languages"]) > 1 {
+        f.Errors = append(f.Errors, "Preferred-Languages must appear at most once")
+    }
+    for _, name := range []string{"Acknowledgments", "Canonical", "CSAF", "Encryption", "Hiring", "Policy"} {
+        schemes := []string{"https"}
+        if name == "Encryption" { schemes = append(schemes, "dns", "openpgp4fpr") }
+        for _, v := range f.Fields[name] {
+            if err := checkURI(v, schemes...); err != nil {
+                f.Errors = append(f.Errors, name+": "+err.Error())
+            }
+        }
+    }
+    if !f.Signed {
+        f.Warnings = append(f.Warnings, "file is not digitally signed")
+    }
+}
+
+// Expired reports whether the Expires date is in the past.
+func (f *File) Expired(now time.Time) bool {
+    return !f.Expires.IsZero() && now.After(f.Expires)
+}
+
+// Valid reports whether the file has every required field and no RFC errors.
+func (f *File) Valid() bool { return len(f.Errors) == 0 }
+
+// CanonicalMatches reports whether fetchedURL is listed in Canonical. ok is false when no Canonical is set.
+func (f *File) CanonicalMatches(fetchedURL string) (match bool, ok bool) {
+    canon := f.Fields["Canonical"]
+    if len(canon) == 0 { return false, false }
+    for _, c := range canon {
+        if sameURL(c, fetchedURL) { return true, true }
+    }
+    return false, true
+}
+
+func checkURI(v string, schemes ...string) error {
+    u, err := url.Parse(v)
+    if err != nil || u.Scheme == "" {
+        return fmt.Errorf("%q is not a URI", v)
+    }
+    for _, s := range schemes {
+        if strings.EqualFold(u.Scheme, s) { return nil }
+    }
+    return fmt.Errorf("%q uses unsupported scheme %q", v, u.Scheme)
+}
+
+func sameURL(a, b string) bool {
+    ua, err1 := url.Parse(a)
+    ub, err2 := url.Parse(b)
+    if err1 != nil || err2 != nil { return a == b }
+    return strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host) &&
+        strings.TrimSuffix(ua.Path, "/") == strings.TrimSuffix(ub.Path, "/")
+}
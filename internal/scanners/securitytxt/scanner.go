@@ -0,0 +1,163 @@
+// Package securitytxt discovers and validates a site's security.txt (RFC 9116).
+package securitytxt
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "mime"
+    "strings"
+    "time"
+
+    "github.com/ProtonMail/go-crypto/openpgp"
+    "github.com/ProtonMail/go-crypto/openpgp/clearsign"
+    pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "securitytxt"
+
+// Paths are tried in order; the first valid response wins.
+var Paths = []struct{ Path, Location string }{
+    {"/.well-known/security.txt", "well-known"},
+    {"/security.txt", "root"},
+}
+
+// Signature verification outcomes reported as security.txt.signature.
+const (
+    SignatureValid   = "valid"
+    SignatureInvalid = "invalid"
+    SignatureUnknown = "unknown" // no usable key could be fetched
+)
+
+// Scanner emits security.txt.* signals.
+type Scanner struct {
+    Fetcher ports.Fetcher
+    Now     func() time.Time
+}
+
+func New(fetcher ports.Fetcher) *Scanner { return &Scanner{Fetcher: fetcher, Now: time.Now} }
+
+func (s *Scanner) Name() string { return source }
+
+type attempt struct {
+    URL    string `json:"url"`
+    Status int    `json:"status,omitempty"`
+    Error  string `json:"error,omitempty"`
+}
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    now := s.Now()
+    origin := t.Origin()
+
+    var attempts []attempt
+    for _, p := range Paths {
+        u := origin + p.Path
+        resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: u, Header: map[string][]string{"Accept": {"text/plain"}}})
+        if err != nil {
+            attempts = append(attempts, attempt{URL: u, Error: err.Error()})
+            if ctx.Err() != nil { return res, ctx.Err() }
+            continue
+        }
+        attempts = append(attempts, attempt{URL: u, Status: resp.StatusCode})
+        if resp.StatusCode != 200 || looksLikeHTML(resp.Body) { continue }
+        return s.evaluate(ctx, resp, p.Location, now), nil
+    }
+
+    raw, _ := json.Marshal(attempts)
+    ev := scanners.NewEvidence("security.txt", origin+Paths[0].Path, raw, map[string]any{"attempts": attempts})
+    res.Evidence = append(res.Evidence, ev)
+    res.Signals = append(res.Signals, scanners.NewSignal("security.txt.present", false, "low", 1, source, ev.Hash))
+    return res, nil
+}
+
+func (s *Scanner) evaluate(ctx context.Context, resp ports.FetchResponse, location string, now time.Time) ports.ScanResult {
+    var res ports.ScanResult
+    body := resp.Body
+    signed := bytes.HasPrefix(bytes.TrimSpace(body), []byte("-----BEGIN PGP SIGNED MESSAGE-----"))
+    var block *clearsign.Block
+    if signed {
+        block, _ = clearsign.Decode(body)
+        if block != nil { body = block.Plaintext }
+    }
+
+    f := Parse(body, signed, now)
+    if ct := resp.Header.Get("Content-Type"); ct != "" {
+        if mt, _, err := mime.ParseMediaType(ct); err != nil || mt != "text/plain" {
+            f.Errors = append(f.Errors, fmt.Sprintf("Content-Type is %q, want text/plain", ct))
+        }
+    }
+    if signed && block == nil {
+        f.Errors = append(f.Errors, "malformed OpenPGP cleartext signature")
+    }
+
+    signature := ""
+    if signed {
+        signature = s.verify(ctx, block, f.Fields["Encryption"])
+    }
+
+    payload := map[string]any{
+        "url":      resp.URL,
+        "snippet":  scanners.Snippet(resp.Body, 1024),
+        "fields":   f.Fields,
+        "errors":   f.Errors,
+        "warnings": f.Warnings,
+    }
+    if !f.Expires.IsZero() { payload["expires"] = f.Expires.UTC().Format(time.RFC3339) }
+    if signature != "" { payload["signature"] = signature }
+    ev := scanners.NewEvidence("security.txt", resp.URL, resp.Body, payload)
+    res.Evidence = append(res.Evidence, ev)
+
+    sig := func(code string, value any, severity string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, 1, source, ev.Hash))
+    }
+    sig("security.txt.present", true, "info")
+    sig("security.txt.location", location, "info")
+    sig("security.txt.valid", f.Valid(), scanners.SeverityIf(!f.Valid(), "low"))
+    sig("security.txt.contact.present", len(f.Fields["Contact"]) > 0, scanners.SeverityIf(len(f.Fields["Contact"]) == 0, "medium"))
+    sig("security.txt.expires.present", !f.Expires.IsZero(), scanners.SeverityIf(f.Expires.IsZero(), "low"))
+    if !f.Expires.IsZero() {
+        sig("security.txt.expired", f.Expired(now), scanners.SeverityIf(f.Expired(now), "medium"))
+    }
+    if match, ok := f.CanonicalMatches(resp.URL); ok {
+        sig("security.txt.canonical.match", match, scanners.SeverityIf(!match, "low"))
+    }
+    sig("security.txt.signed", signed, "info")
+    if signed {
+        sig("security.txt.signature", signature, scanners.SeverityIf(signature == SignatureInvalid, "medium"))
+    }
+    return res
+}
+
+// verify checks the clear-signature against keys published at https Encryption URIs.
+func (s *Scanner) verify(ctx context.Context, block *clearsign.Block, keyURIs []string) string {
+    if block == nil { return SignatureInvalid }
+    var keyring openpgp.EntityList
+    for _, u := range keyURIs {
+        if !strings.HasPrefix(strings.ToLower(u), "https://") { continue }
+        resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: u})
+        if err != nil || resp.StatusCode != 200 { continue }
+        keys, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(resp.Body))
+        if err != nil {
+            keys, err = openpgp.ReadKeyRing(bytes.NewReader(resp.Body))
+        }
+        if err == nil { keyring = append(keyring, keys...) }
+    }
+    if len(keyring) == 0 { return SignatureUnknown }
+    if _, err := block.VerifySignature(keyring, nil); err != nil {
+        if errors.Is(err, pgperrors.ErrUnknownIssuer) { return SignatureUnknown }
+        return SignatureInvalid
+    }
+    return SignatureValid
+}
+
+func looksLikeHTML(b []byte) bool {
+    head := bytes.ToLower(bytes.TrimSpace(b))
+    if len(head) > 512 { head = head[:512] }
+    return bytes.HasPrefix(head, []byte("<!doctype html")) || bytes.Contains(head, []byte("<html"))
+}
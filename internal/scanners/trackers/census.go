@@ -0,0 +1,117 @@
+package trackers
+
+import (
+    "bytes"
+    "net/url"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "golang.org/x/net/html"
+)
+
+// Resource kinds found in landing page markup.
+const (
+    KindScript     = "script"
+    KindIframe     = "iframe"
+    KindImage      = "image"
+    KindPixel      = "pixel" // 0x0 or 1x1 image
+    KindStyle      = "style"
+    KindFont       = "font"
+    KindPreload    = "preload" // preloaded resource of another or unstated type
+    KindPreconnect = "preconnect"
+    KindInline     = "inline" // URL referenced from an inline loader snippet
+)
+
+// Resource is one external reference from the landing page.
+type Resource struct {
+    URL  string `json:"url"`
+    Host string `json:"host"`
+    Kind string `json:"kind"`
+}
+
+var inlineURL = regexp.MustCompile(`(?i)(?:https?:)?//([a-z0-9][a-z0-9.-]*\.[a-z]{2,})(?:[/:?"'\s]|$)`)
+
+// Census lists external resources referenced by page markup, resolved against base.
+func Census(base *url.URL, body []byte) []Resource {
+    var out []Resource
+    add := func(ref, kind string) {
+        ref = strings.TrimSpace(ref)
+        if ref == "" || strings.HasPrefix(ref, "data:") || strings.HasPrefix(ref, "javascript:") { return }
+        u, err := base.Parse(ref)
+        if err != nil || u.Hostname() == "" || (u.Scheme != "http" && u.Scheme != "https") { return }
+        out = append(out, Resource{URL: u.String(), Host: strings.ToLower(u.Hostname()), Kind: kind})
+    }
+
+    z := html.NewTokenizer(bytes.NewReader(body))
+    inScript := false
+    for {
+        tt := z.Next()
+        switch tt {
+        case html.ErrorToken:
+            return out
+        case html.EndTagToken:
+            if name, _ := z.TagName(); string(name) == "script" { inScript = false }
+        case html.TextToken:
+            if !inScript { continue }
+            text := strings.ReplaceAll(string(z.Text()), `\/`, "/")
+            for _, m := range inlineURL.FindAllStringSubmatch(text, -1) {
+                add("https://"+m[1]+"/", KindInline)
+            }
+        case html.StartTagToken, html.SelfClosingTagToken:
+            name, hasAttr := z.TagName()
+            attrs := map[string]string{}
+            for hasAttr {
+                var k, v []byte
+                k, v, hasAttr = z.TagAttr()
+                attrs[string(k)] = string(v)
+            }
+            switch string(name) {
+            case "script":
+                if src := attrs["src"]; src != "" {
+                    add(src, KindScript)
+                } else if tt == html.StartTagToken {
+                    inScript = true
+                }
+            case "iframe":
+                add(attrs["src"], KindIframe)
+            case "img":
+                kind := KindImage
+                if tiny(attrs["width"]) && tiny(attrs["height"]) { kind = KindPixel }
+                add(attrs["src"], kind)
+            case "link":
+                rel := strings.ToLower(attrs["rel"])
+                switch {
+                case strings.Contains(rel, "preconnect") || strings.Contains(rel, "dns-prefetch"):
+                    add(attrs["href"], KindPreconnect)
+                case strings.Contains(rel, "modulepreload"):
+                    add(attrs["href"], KindScript)
+                case strings.Contains(rel, "preload"):
+                    add(attrs["href"], preloadKind(attrs["as"]))
+                case strings.Contains(rel, "stylesheet"):
+                    add(attrs["href"], KindStyle)
+                }
+            }
+        }
+    }
+}
+
+// preloadKind maps a preload's as= destination to a resource kind.
+func preloadKind(as string) string {
+    switch strings.ToLower(strings.TrimSpace(as)) {
+    case "script":
+        return KindScript
+    case "style":
+        return KindStyle
+    case "font":
+        return KindFont
+    case "image":
+        return KindImage
+    }
+    return KindPreload
+}
+
+func tiny(v string) bool {
+    n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(v), "px"))
+    return err == nil && n <= 1
+}
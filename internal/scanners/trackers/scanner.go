@@ -0,0 +1,176 @@
+// Package trackers takes a static census of third-party resources on the landing
+// page, resolves their hosts' CNAME chains and attributes them to tracker entities.
+package trackers
+
+import (
+    "context"
+    "encoding/json"
+    "net/url"
+    "regexp"
+    "sort"
+    "strings"
+
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "trackers"
+
+// HostReport is the evidence row for one third-party host.
+type HostReport struct {
+    Host       string   `json:"host"`
+    Kinds      []string `json:"kinds"`
+    Count      int      `json:"count"`
+    Tracker    bool     `json:"tracker"`
+    CNAME      string   `json:"cname,omitempty"`   // canonical name, when host is an alias
+    Cloaked    bool     `json:"cloaked,omitempty"` // first-party host aliasing a third party
+    Owner      string   `json:"owner,omitempty"`
+    Categories []string `json:"categories,omitempty"`
+}
+
+// Scanner emits trackers.* signals. Without a directory, density and host counts
+// are still reported but owner attribution is skipped; without a resolver, hosts
+// are taken at face value and CNAME-cloaked trackers go unnoticed.
+type Scanner struct {
+    Fetcher   ports.Fetcher
+    Directory ports.TrackerDirectory
+    Resolver  ports.Resolver
+}
+
+func New(fetcher ports.Fetcher, dir ports.TrackerDirectory, resolver ports.Resolver) *Scanner {
+    return &Scanner{Fetcher: fetcher, Directory: dir, Resolver: resolver}
+}
+
+// maxResolve bounds the distinct hosts resolved per page, and maxHops the CNAME chain followed.
+const (
+    maxResolve = 50
+    maxHops    = 8
+)
+
+func (s *Scanner) Name() string { return source }
+
+func (s *Scanner) Scan(ctx context.Context, t ports.ScanTarget) (ports.ScanResult, error) {
+    var res ports.ScanResult
+    resp, err := s.Fetcher.Fetch(ctx, ports.FetchRequest{URL: scanners.LandingURL(t)})
+    if err != nil { return res, err }
+    base, err := url.Parse(resp.URL)
+    if err != nil { return res, err }
+
+    resources := Census(base, resp.Body)
+    cnames := s.resolve(ctx, resources)
+    hosts := map[string]*HostReport{}
+    var third, cloaked int
+    for _, r := range resources {
+        cname := cnames[r.Host]
+        first := scanners.Registrable(r.Host) == t.Domain
+        hidden := first && cname != "" && scanners.Registrable(cname) != t.Domain
+        if first && !hidden { continue }
+        third++
+        h := hosts[r.Host]
+        if h == nil {
+            h = &HostReport{Host: r.Host, CNAME: cname, Cloaked: hidden}
+            hosts[r.Host] = h
+            if hidden { cloaked++ }
+        }
+        h.Count++
+        if !contains(h.Kinds, r.Kind) { h.Kinds = append(h.Kinds, r.Kind) }
+    }
+
+    owners := map[string]bool{}
+    categories := map[string]int{}
+    known := 0
+    reports := make([]HostReport, 0, len(hosts))
+    for _, h := range hosts {
+        if s.Directory != nil {
+            tr, ok := s.Directory.Lookup(h.Host)
+            if !ok && h.CNAME != "" { tr, ok = s.Directory.Lookup(h.CNAME) }
+            if ok {
+                h.Tracker = true
+                h.Owner = tr.DisplayName
+                if h.Owner == "" { h.Owner = tr.Owner }
+                h.Categories = tr.Categories
+                known++
+                if h.Owner != "" { owners[h.Owner] = true }
+                for _, c := range tr.Categories { categories[slug(c)]++ }
+            }
+        }
+        reports = append(reports, *h)
+    }
+    sort.Slice(reports, func(i, j int) bool { return reports[i].Host < reports[j].Host })
+
+    raw, _ := json.Marshal(reports)
+    ev := scanners.NewEvidence("trackers", resp.URL, raw, map[string]any{
+        "url":       resp.URL,
+        "resources": len(resources),
+        "resolved":  s.Resolver != nil,
+        "hosts":     reports,
+    })
+    res.Evidence = append(res.Evidence, ev)
+    add := func(code string, value any, severity string) {
+        res.Signals = append(res.Signals, scanners.NewSignal(code, value, severity, 1, source, ev.Hash))
+    }
+
+    density := 0.0
+    if len(resources) > 0 { density = float64(third) / float64(len(resources)) }
+    add("trackers.third_party.hosts", len(hosts), "info")
+    add("trackers.third_party.density", round2(density), scanners.SeverityIf(density > 0.5, "low"))
+    if s.Resolver != nil { add("trackers.cname_cloaked.count", cloaked, scanners.SeverityIf(cloaked > 0, "medium")) }
+    if s.Directory == nil { return res, nil }
+
+    names := make([]string, 0, len(owners))
+    for o := range owners { names = append(names, o) }
+    sort.Strings(names)
+    ownerSeverity := "info"
+    switch {
+    case len(owners) >= 5:
+        ownerSeverity = "high"
+    case len(owners) > 0:
+        ownerSeverity = "medium"
+    }
+    add("trackers.known.count", known, scanners.SeverityIf(known > 0, "medium"))
+    add("trackers.owners.count", len(owners), ownerSeverity)
+    add("trackers.owners", strings.Join(names, ","), "info")
+    cats := make([]string, 0, len(categories))
+    for c := range categories { cats = append(cats, c) }
+    sort.Strings(cats)
+    for _, c := range cats {
+        add("trackers.category."+c, categories[c], "info")
+    }
+    return res, nil
+}
+
+// resolve follows the CNAME chain of each distinct resource host and returns the
+// canonical names of those that are aliases. Lookup failures leave a host as is.
+func (s *Scanner) resolve(ctx context.Context, resources []Resource) map[string]string {
+    out := map[string]string{}
+    if s.Resolver == nil { return out }
+    seen := map[string]bool{}
+    for _, r := range resources {
+        if seen[r.Host] || len(seen) >= maxResolve || ctx.Err() != nil { continue }
+        seen[r.Host] = true
+        name := r.Host
+        for hop := 0; hop < maxHops; hop++ {
+            target, err := s.Resolver.LookupCNAME(ctx, name)
+            if err != nil || target == "" || target == name { break }
+            name = target
+        }
+        if name != r.Host { out[r.Host] = name }
+    }
+    return out
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug normalizes a Tracker Radar category ("Ad Motivated Tracking") to a code segment.
+func slug(s string) string {
+    return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "_"), "_")
+}
+
+func contains(xs []string, x string) bool {
+    for _, v := range xs {
+        if v == x { return true }
+    }
+    return false
+}
+
+func round2(f float64) float64 { return float64(int(f*100+0.5)) / 100 }
@@ -0,0 +1,137 @@
+// Package compliance infers which privacy regimes likely apply to a site from
+// the signals a scan produced and checks the policy against each regime's
+// required disclosures. It reads signals only; nothing is fetched.
+package compliance
+
+import (
+    "encoding/json"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners"
+)
+
+const source = "compliance"
+
+// Applicability values for compliance.<regime>.applies.
+const (
+    Likely   = "likely"
+    Possible = "possible"
+    Unlikely = "unlikely"
+)
+
+// Checklist item values for compliance.<regime>.<item>.
+const (
+    Pass    = "pass"
+    Fail    = "fail"
+    Unknown = "unknown" // the privacy policy could not be read
+)
+
+// likelyAt is the applicability points at which a regime counts as likely.
+const likelyAt = 3
+
+// Reason is one hint that a regime applies.
+type Reason struct {
+    Hint   string `json:"hint"`
+    Signal string `json:"signal"`
+    Points int    `json:"points"`
+}
+
+// Result is one checklist item's outcome and the signals it was judged on.
+type Result struct {
+    Item    string   `json:"item"`
+    Status  string   `json:"status"`
+    Signals []string `json:"signals"`
+}
+
+// Assessment is one regime's applicability and checklist.
+type Assessment struct {
+    Regime  string   `json:"regime"`
+    Applies string   `json:"applies"`
+    Points  int      `json:"points"`
+    Reasons []Reason `json:"reasons"`
+    Checks  []Result `json:"checks,omitempty"`
+}
+
+// Evaluate assesses every regime against signals and returns a compliance
+// evidence row per regime with its compliance.* signals.
+func Evaluate(signals []domain.Signal) ports.ScanResult {
+    var res ports.ScanResult
+    in := index(signals)
+    for _, r := range regimes {
+        a := Assessment{Regime: r.Code, Reasons: r.applies(in)}
+        for _, reason := range a.Reasons { a.Points += reason.Points }
+        a.Applies = applicability(a.Points)
+        if a.Applies != Unlikely { a.Checks = r.check(in) }
+
+        raw, _ := json.Marshal(a)
+        ev := scanners.NewEvidence(source, "", raw, a)
+        res.Evidence = append(res.Evidence, ev)
+        var used []string
+        for _, reason := range a.Reasons { used = append(used, reason.Signal) }
+        prefix := "compliance." + r.Code + "."
+        confidence := 0.5
+        if a.Applies == Likely { confidence = 0.7 }
+        res.Signals = append(res.Signals, scanners.NewSignal(prefix+"applies", a.Applies, "info", confidence, source, in.refs(ev.Hash, used)...))
+        if a.Applies == Unlikely { continue }
+
+        gaps := 0
+        for _, c := range a.Checks {
+            severity := "info"
+            if c.Status == Fail {
+                gaps++
+                severity = scanners.SeverityIf(a.Applies == Likely, r.severity(c.Item))
+            }
+            res.Signals = append(res.Signals, scanners.NewSignal(prefix+c.Item, c.Status, severity, 0.6, source, in.refs(ev.Hash, c.Signals)...))
+        }
+        res.Signals = append(res.Signals, scanners.NewSignal(prefix+"gaps", gaps, "info", 0.6, source, ev.Hash))
+    }
+    return res
+}
+
+func applicability(points int) string {
+    switch {
+    case points >= likelyAt:
+        return Likely
+    case points > 0:
+        return Possible
+    }
+    return Unlikely
+}
+
+// facts indexes signals by code; a later signal with the same code wins.
+type facts map[string]domain.Signal
+
+func index(signals []domain.Signal) facts {
+    f := facts{}
+    for _, s := range signals { f[s.Code] = s }
+    return f
+}
+
+func (f facts) has(code string) bool { _, ok := f[code]; return ok }
+
+func (f facts) is(code string) bool { b, ok := f[code].Value.(bool); return ok && b }
+
+func (f facts) str(code string) string { s, _ := f[code].Value.(string); return s }
+
+func (f facts) strs(code string) []string { s, _ := f[code].Value.([]string); return s }
+
+func (f facts) ints(code string) []int { n, _ := f[code].Value.([]int); return n }
+
+// readPrivacy reports whether the privacy policy was found and read, which the
+// checklist needs before a missing disclosure can count as a failure.
+func (f facts) readPrivacy() bool { return f.is("policy.privacy.readable") }
+
+// refs returns hash followed by the evidence behind the given signal codes.
+func (f facts) refs(hash string, codes []string) []string {
+    out := []string{hash}
+    seen := map[string]bool{hash: true}
+    for _, c := range codes {
+        for _, r := range f[c].EvidenceRefs {
+            if seen[r] { continue }
+            seen[r] = true
+            out = append(out, r)
+        }
+    }
+    return out
+}
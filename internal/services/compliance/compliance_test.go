@@ -0,0 +1,153 @@
+package compliance
+
+import (
+    "testing"
+
+    "camille/internal/domain"
+)
+
+func sig(code string, value any) domain.Signal {
+    return domain.Signal{Code: code, Value: value, EvidenceRefs: []string{"ev:" + code}}
+}
+
+// evaluate runs Evaluate and indexes its signals by code.
+func evaluate(signals ...domain.Signal) map[string]domain.Signal {
+    out := map[string]domain.Signal{}
+    for _, s := range Evaluate(signals).Signals { out[s.Code] = s }
+    return out
+}
+
+func TestApplicability(t *testing.T) {
+    for _, tc := range []struct {
+        name    string
+        regime  string
+        signals []domain.Signal
+        want    string
+    }{
+        {"gdpr cited", "gdpr", []domain.Signal{sig("policy.law.gdpr.referenced", true)}, Likely},
+        {"gdpr address", "gdpr", []domain.Signal{sig("policy.contact.address.country", "DE")}, Likely},
+        {"gdpr euro prices", "gdpr", []domain.Signal{sig("site.currencies", []string{"EUR"})}, Possible},
+        {"gdpr language", "gdpr", []domain.Signal{sig("site.language", "fr")}, Possible},
+        {"gdpr language and tcf", "gdpr", []domain.Signal{sig("site.language", "fr"), sig("consent.tcf.stub", true), sig("site.currencies", []string{"EUR"})}, Likely},
+        {"gdpr nothing", "gdpr", nil, Unlikely},
+        {"gdpr not for a US address", "gdpr", []domain.Signal{sig("policy.contact.address.country", "US")}, Unlikely},
+        {"uk gdpr cited", "uk_gdpr", []domain.Signal{sig("policy.law.uk_gdpr.referenced", true)}, Likely},
+        {"uk gdpr governing law", "uk_gdpr", []domain.Signal{sig("policy.terms.governing_law", "England and Wales")}, Possible},
+        {"uk gdpr pound prices", "uk_gdpr", []domain.Signal{sig("site.currencies", []string{"GBP"})}, Possible},
+        {"uk gdpr not for an EU address", "uk_gdpr", []domain.Signal{sig("policy.contact.address.country", "FR")}, Unlikely},
+        {"ccpa cited", "ccpa", []domain.Signal{sig("policy.law.ccpa.referenced", true)}, Likely},
+        {"cpra cited", "ccpa", []domain.Signal{sig("policy.law.cpra.referenced", true)}, Likely},
+        {"ccpa california address", "ccpa", []domain.Signal{sig("policy.contact.address.region", "US-CA")}, Likely},
+        {"ccpa do not sell link", "ccpa", []domain.Signal{sig("site.link.do_not_sell", true)}, Likely},
+        {"ccpa dollar prices and gpc", "ccpa", []domain.Signal{sig("site.currencies", []string{"USD"}), sig("privacy.gpc.declared", true)}, Possible},
+        {"ccpa other state", "ccpa", []domain.Signal{sig("policy.contact.address.region", "US-NY")}, Unlikely},
+        {"lgpd cited", "lgpd", []domain.Signal{sig("policy.law.lgpd.referenced", true)}, Likely},
+        {"lgpd address", "lgpd", []domain.Signal{sig("policy.contact.address.country", "BR")}, Likely},
+        {"lgpd portuguese", "lgpd", []domain.Signal{sig("site.language", "pt")}, Possible},
+        {"lgpd real prices and domain", "lgpd", []domain.Signal{sig("site.currencies", []string{"BRL"}), sig("site.tld", "BR")}, Likely},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            got := evaluate(tc.signals...)
+            prefix := "compliance." + tc.regime + "."
+            if v := got[prefix+"applies"].Value; v != tc.want { t.Fatalf("applies = %v, want %v", v, tc.want) }
+            if _, ok := got[prefix+"gaps"]; ok == (tc.want == Unlikely) { t.Errorf("gaps present = %v for %s", ok, tc.want) }
+        })
+    }
+}
+
+func TestChecklist(t *testing.T) {
+    read := sig("policy.privacy.readable", true)
+    unread := sig("policy.privacy.readable", false)
+    gdpr := sig("policy.law.gdpr.referenced", true)
+    uk := sig("policy.law.uk_gdpr.referenced", true)
+    ccpa := sig("policy.law.ccpa.referenced", true)
+    lgpd := sig("policy.law.lgpd.referenced", true)
+    for _, tc := range []struct {
+        name    string
+        check   string
+        signals []domain.Signal
+        want    string
+    }{
+        {"rights unread", "gdpr.rights", []domain.Signal{gdpr, unread, sig("policy.user.rights.access", true)}, Unknown},
+        {"rights missing", "gdpr.rights", []domain.Signal{gdpr}, Unknown},
+        {"rights partial", "gdpr.rights", []domain.Signal{gdpr, read, sig("policy.user.rights.access", true)}, Fail},
+        {"rights all", "gdpr.rights", []domain.Signal{gdpr, read, sig("policy.user.rights.access", true), sig("policy.user.rights.portability", true), sig("policy.user.rights.deletion", true)}, Pass},
+        {"dsr contact form", "gdpr.dsr_contact", []domain.Signal{gdpr, read, sig("policy.contact.dsr.form_present", true)}, Pass},
+        {"dsr contact none", "gdpr.dsr_contact", []domain.Signal{gdpr, read}, Fail},
+        {"retention partial", "gdpr.retention", []domain.Signal{gdpr, read, sig("policy.data.storage.retention.clarity", "partial")}, Pass},
+        {"retention vague", "gdpr.retention", []domain.Signal{gdpr, read, sig("policy.data.storage.retention.clarity", "vague")}, Fail},
+        {"retention in an unread language", "gdpr.retention", []domain.Signal{gdpr, read, sig("policy.data.storage.retention.clarity", "unknown")}, Unknown},
+        {"retention in an unread language, rule found", "gdpr.retention", []domain.Signal{gdpr, read, sig("policy.data.storage.retention.clarity", "unknown"), sig("policy.data.storage.retention.specified", true)}, Pass},
+        {"retention specified", "gdpr.retention", []domain.Signal{gdpr, read, sig("policy.data.storage.retention.clarity", "none"), sig("policy.data.storage.retention.specified", true)}, Pass},
+
+        {"dpo named", "gdpr.dpo_contact", []domain.Signal{gdpr, read, sig("policy.contact.dpo.named", true)}, Pass},
+        {"dpo not appointed", "gdpr.dpo_contact", []domain.Signal{gdpr, read, sig("policy.contact.dpo", false)}, Pass},
+        {"dpo silent", "gdpr.dpo_contact", []domain.Signal{gdpr, read}, Fail},
+        {"dpo unread", "lgpd.dpo_contact", []domain.Signal{lgpd, unread}, Unknown},
+
+        {"transfers safeguarded", "gdpr.transfers", []domain.Signal{gdpr, read, sig("policy.data.transfer.international", true), sig("policy.data.transfer.safeguards", true)}, Pass},
+        {"transfers stated without safeguards", "gdpr.transfers", []domain.Signal{gdpr, read, sig("policy.data.transfer.international", true)}, Fail},
+        {"transfers to a US recipient", "gdpr.transfers", []domain.Signal{gdpr, read, sig("policy.recipients.countries", []string{"FR", "US"})}, Fail},
+        {"transfers within the EU", "gdpr.transfers", []domain.Signal{gdpr, read, sig("policy.recipients.countries", []string{"FR", "IE"})}, Pass},
+        {"uk transfers to the EU", "uk_gdpr.transfers", []domain.Signal{uk, read, sig("policy.recipients.countries", []string{"GB", "IE"})}, Pass},
+        {"uk transfers to the US", "uk_gdpr.transfers", []domain.Signal{uk, read, sig("policy.recipients.countries", []string{"US"})}, Fail},
+        {"lgpd transfers to the EU", "lgpd.transfers", []domain.Signal{lgpd, read, sig("policy.recipients.countries", []string{"IE"})}, Fail},
+        {"transfers unread", "gdpr.transfers", []domain.Signal{gdpr, unread, sig("policy.data.transfer.safeguards", true)}, Unknown},
+
+        {"gdpr children 16", "gdpr.children_consent", []domain.Signal{gdpr, read, sig("policy.children.ages", []int{16})}, Pass},
+        {"gdpr children 12", "gdpr.children_consent", []domain.Signal{gdpr, read, sig("policy.children.ages", []int{12})}, Fail},
+        {"lgpd children 12", "lgpd.children_consent", []domain.Signal{lgpd, read, sig("policy.children.ages", []int{12})}, Pass},
+
+        {"link present", "ccpa.do_not_sell_link", []domain.Signal{ccpa, sig("site.link.do_not_sell", true)}, Pass},
+        {"link not looked for", "ccpa.do_not_sell_link", []domain.Signal{ccpa, read, sig("policy.data.sale.present", true)}, Unknown},
+        {"no link, no sale", "ccpa.do_not_sell_link", []domain.Signal{ccpa, read, sig("site.link.do_not_sell", false), sig("policy.data.sale.present", false)}, Pass},
+        {"no link, sells", "ccpa.do_not_sell_link", []domain.Signal{ccpa, read, sig("site.link.do_not_sell", false), sig("policy.data.sale.present", true)}, Fail},
+        {"no link, silent on sale", "ccpa.do_not_sell_link", []domain.Signal{ccpa, read, sig("site.link.do_not_sell", false)}, Fail},
+        {"no link, denial unread", "ccpa.do_not_sell_link", []domain.Signal{ccpa, unread, sig("site.link.do_not_sell", false), sig("policy.data.sale.present", false)}, Fail},
+        {"sale denied", "ccpa.sale_disclosure", []domain.Signal{ccpa, read, sig("policy.data.sale.present", false)}, Pass},
+        {"sale silent", "ccpa.sale_disclosure", []domain.Signal{ccpa, read}, Fail},
+        {"opt out, no sale", "ccpa.opt_out", []domain.Signal{ccpa, read, sig("policy.data.sale.present", false)}, Pass},
+        {"opt out missing", "ccpa.opt_out", []domain.Signal{ccpa, read, sig("policy.data.sale.present", true)}, Fail},
+        {"children, no sale", "ccpa.children_consent", []domain.Signal{ccpa, read, sig("policy.data.sale.present", false)}, Pass},
+        {"children, sells without age", "ccpa.children_consent", []domain.Signal{ccpa, read, sig("policy.data.sale.present", true)}, Fail},
+        {"children, sells with opt-in age", "ccpa.children_consent", []domain.Signal{ccpa, read, sig("policy.data.sale.present", true), sig("policy.children.ages", []int{13})}, Pass},
+        {"children unread", "ccpa.children_consent", []domain.Signal{ccpa, unread}, Unknown},
+        {"gpc honored", "ccpa.gpc", []domain.Signal{ccpa, sig("privacy.gpc.status", "honored")}, Pass},
+        {"gpc ignored", "ccpa.gpc", []domain.Signal{ccpa, sig("privacy.gpc.status", "ignored")}, Fail},
+        {"gpc not tested", "ccpa.gpc", []domain.Signal{ccpa, read}, Unknown},
+    } {
+        t.Run(tc.name, func(t *testing.T) {
+            got := evaluate(tc.signals...)
+            s, ok := got["compliance."+tc.check]
+            if !ok { t.Fatalf("no compliance.%s signal", tc.check) }
+            if s.Value != tc.want { t.Errorf("%s = %v, want %v", tc.check, s.Value, tc.want) }
+            if s.Source != source { t.Errorf("source = %q", s.Source) }
+        })
+    }
+}
+
+// A failed item is a finding only where the regime likely applies, and every
+// failure counts towards the regime's gaps.
+func TestSeverityAndGaps(t *testing.T) {
+    read := sig("policy.privacy.readable", true)
+    likely := evaluate(sig("policy.law.gdpr.referenced", true), read)
+    if s := likely["compliance.gdpr.legal_bases"]; s.Value != Fail || s.Severity != "medium" { t.Errorf("likely legal_bases = %v/%s", s.Value, s.Severity) }
+    if s := likely["compliance.gdpr.complaint"]; s.Severity != "low" { t.Errorf("likely complaint severity = %s", s.Severity) }
+    if n := likely["compliance.gdpr.gaps"].Value; n != len(gdprItems(nil))-1 { t.Errorf("gaps = %v", n) } // transfers pass with nothing disclosed
+
+    possible := evaluate(sig("site.language", "de"), read)
+    if s := possible["compliance.gdpr.legal_bases"]; s.Value != Fail || s.Severity != "info" { t.Errorf("possible legal_bases = %v/%s", s.Value, s.Severity) }
+}
+
+func TestEvidence(t *testing.T) {
+    res := Evaluate([]domain.Signal{sig("policy.law.gdpr.referenced", true), sig("policy.privacy.readable", true), sig("policy.contact.dpo.named", true)})
+    if len(res.Evidence) != len(regimes) { t.Fatalf("evidence rows = %d, want one per regime", len(res.Evidence)) }
+    for _, ev := range res.Evidence {
+        if ev.SourceType != source { t.Errorf("evidence source = %q", ev.SourceType) }
+    }
+    var dpo []string
+    for _, s := range res.Signals {
+        if s.Code == "compliance.gdpr.dpo_contact" { dpo = s.EvidenceRefs }
+    }
+    if len(dpo) != 2 || dpo[0] != res.Evidence[0].Hash || dpo[1] != "ev:policy.contact.dpo.named" { t.Errorf("dpo refs = %v", dpo) }
+}
@@ -0,0 +1,290 @@
+package compliance
+
+import (
+    "strings"
+
+    "camille/internal/domain"
+    "camille/internal/scanners/geo"
+)
+
+// regime describes a privacy law: which places, currencies and languages
+// suggest it applies, and the disclosures its checklist requires.
+type regime struct {
+    Code       string
+    Laws       []string // policy.law.<code>.referenced codes naming it
+    Place      func(country string) bool
+    Region     string // an establishment region that alone brings it in
+    Currencies map[string]int
+    Languages  []string
+    Extra      func(in facts) []Reason
+    Items      []item
+}
+
+// item is one required disclosure; judge returns its status and the signals it read.
+type item struct {
+    Name     string
+    Severity string
+    judge    func(in facts) (string, []string)
+}
+
+var regimes = []regime{
+    {
+        Code:       "gdpr",
+        Laws:       []string{"gdpr"},
+        Place:      geo.InEU,
+        Currencies: map[string]int{"EUR": 2},
+        Languages:  []string{"de", "fr", "it", "nl", "pl", "sv", "da", "fi", "cs", "el", "hu", "ro", "bg", "hr", "sk", "sl", "et", "lv", "lt", "ga", "mt"},
+        Extra: func(in facts) []Reason {
+            if in.is("consent.tcf.stub") { return []Reason{{Hint: "IAB TCF consent framework", Signal: "consent.tcf.stub", Points: 1}} }
+            return nil
+        },
+        Items: gdprItems(geo.InEU),
+    },
+    {
+        Code:       "uk_gdpr",
+        Laws:       []string{"uk_gdpr"},
+        Place:      func(c string) bool { return c == "GB" },
+        Currencies: map[string]int{"GBP": 2},
+        Items:      gdprItems(func(c string) bool { return c == "GB" || geo.InEU(c) }),
+    },
+    {
+        Code:       "ccpa",
+        Laws:       []string{"ccpa", "cpra"},
+        Region:     "US-CA",
+        Currencies: map[string]int{"USD": 1},
+        Extra: func(in facts) []Reason {
+            var out []Reason
+            if in.is("site.link.do_not_sell") { out = append(out, Reason{Hint: `"Do Not Sell or Share" link`, Signal: "site.link.do_not_sell", Points: 3}) }
+            if in.is("privacy.gpc.declared") { out = append(out, Reason{Hint: "Global Privacy Control declared", Signal: "privacy.gpc.declared", Points: 1}) }
+            return out
+        },
+        Items: []item{
+            {"do_not_sell_link", "medium", doNotSellLink},
+            {"sale_disclosure", "medium", stated("policy.data.sale.present")},
+            {"rights_know", "medium", disclosed("policy.user.rights.access")},
+            {"rights_delete", "medium", disclosed("policy.user.rights.deletion")},
+            {"rights_correct", "low", disclosed("policy.user.rights.correction")},
+            {"opt_out", "medium", optOut},
+            {"non_discrimination", "low", disclosed("policy.user.rights.non_discrimination")},
+            {"retention", "low", retention},
+            {"children_consent", "low", childrenCCPA},
+            {"gpc", "medium", gpc},
+        },
+    },
+    {
+        Code:       "lgpd",
+        Laws:       []string{"lgpd"},
+        Place:      func(c string) bool { return c == "BR" },
+        Currencies: map[string]int{"BRL": 2},
+        Languages:  []string{"pt"},
+        Items: []item{
+            {"controller_identity", "medium", disclosed("policy.contact.controller.named")},
+            {"dpo_contact", "medium", dpo},
+            {"legal_bases", "medium", disclosed("policy.legal_basis.stated")},
+            {"rights", "medium", allOf("policy.user.rights.access", "policy.user.rights.correction", "policy.user.rights.deletion", "policy.user.rights.portability")},
+            {"transfers", "medium", transfers(func(c string) bool { return c == "BR" })},
+            {"dsr_contact", "medium", anyOf("policy.contact.dsr.email_present", "policy.contact.dsr.form_present")},
+            {"children_consent", "low", childrenAge(12, 18)},
+        },
+    },
+}
+
+// gdprItems is the GDPR checklist; the UK GDPR differs only in which
+// countries a transfer leaves for.
+func gdprItems(home func(string) bool) []item {
+    return []item{
+        {"controller_identity", "medium", disclosed("policy.contact.controller.named")},
+        {"dpo_contact", "low", dpo},
+        {"legal_bases", "medium", disclosed("policy.legal_basis.stated")},
+        {"rights", "medium", allOf("policy.user.rights.access", "policy.user.rights.portability", "policy.user.rights.deletion")},
+        {"complaint", "low", disclosed("policy.user.rights.complaint")},
+        {"retention", "medium", retention},
+        {"transfers", "medium", transfers(home)},
+        {"children_consent", "low", childrenAge(13, 16)},
+        {"dsr_contact", "medium", anyOf("policy.contact.dsr.email_present", "policy.contact.dsr.form_present")},
+    }
+}
+
+// applies collects the hints that r applies.
+func (r regime) applies(in facts) []Reason {
+    out := []Reason{}
+    for _, l := range r.Laws {
+        code := "policy.law." + l + ".referenced"
+        if in.is(code) { out = append(out, Reason{Hint: "policy cites " + l, Signal: code, Points: 3}) }
+    }
+    country := in.str("policy.contact.address.country")
+    if r.Place != nil && country != "" && r.Place(country) {
+        out = append(out, Reason{Hint: "establishment address in " + country, Signal: "policy.contact.address.country", Points: 3})
+    }
+    if region := in.str("policy.contact.address.region"); r.Region != "" && region == r.Region {
+        out = append(out, Reason{Hint: "establishment address in " + region, Signal: "policy.contact.address.region", Points: 3})
+    }
+    for _, typ := range domain.PolicyTypes {
+        code := "policy." + typ + ".governing_law"
+        for _, c := range geo.Countries(in.str(code)) {
+            if r.Place != nil && r.Place(c) { out = append(out, Reason{Hint: typ + " governed by the law of " + c, Signal: code, Points: 2}) }
+        }
+        for _, rg := range geo.Regions(in.str(code)) {
+            if rg == r.Region && r.Region != "" { out = append(out, Reason{Hint: typ + " governed by the law of " + rg, Signal: code, Points: 2}) }
+        }
+    }
+    if tld := in.str("site.tld"); r.Place != nil && tld != "" && r.Place(tld) {
+        out = append(out, Reason{Hint: "country-code domain ." + strings.ToLower(tld), Signal: "site.tld", Points: 2})
+    }
+    for _, c := range in.strs("site.currencies") {
+        if p := r.Currencies[c]; p > 0 { out = append(out, Reason{Hint: "prices in " + c, Signal: "site.currencies", Points: p}) }
+    }
+    lang := in.str("site.language")
+    for _, l := range r.Languages {
+        if l == lang { out = append(out, Reason{Hint: "site language " + l, Signal: "site.language", Points: 1}) }
+    }
+    if r.Extra != nil { out = append(out, r.Extra(in)...) }
+    return out
+}
+
+// check runs r's checklist. Items that need the privacy policy are unknown
+// when it was not read.
+func (r regime) check(in facts) []Result {
+    out := make([]Result, 0, len(r.Items))
+    for _, it := range r.Items {
+        status, used := it.judge(in)
+        if used == nil { used = []string{} }
+        out = append(out, Result{Item: it.Name, Status: status, Signals: used})
+    }
+    return out
+}
+
+func (r regime) severity(name string) string {
+    for _, it := range r.Items {
+        if it.Name == name { return it.Severity }
+    }
+    return "low"
+}
+
+// disclosed passes when the policy signal code is true.
+func disclosed(code string) func(facts) (string, []string) {
+    return allOf(code)
+}
+
+// allOf passes when every code is true.
+func allOf(codes ...string) func(facts) (string, []string) {
+    return func(in facts) (string, []string) {
+        if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+        for _, c := range codes {
+            if !in.is(c) { return Fail, codes }
+        }
+        return Pass, codes
+    }
+}
+
+// anyOf passes when one of codes is true.
+func anyOf(codes ...string) func(facts) (string, []string) {
+    return func(in facts) (string, []string) {
+        if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+        for _, c := range codes {
+            if in.is(c) { return Pass, []string{c} }
+        }
+        return Fail, codes
+    }
+}
+
+// stated passes when the policy takes a position on code either way ("we sell"
+// or "we do not sell").
+func stated(code string) func(facts) (string, []string) {
+    return func(in facts) (string, []string) {
+        if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+        if _, ok := in[code].Value.(bool); ok { return Pass, []string{code} }
+        return Fail, []string{code}
+    }
+}
+
+// dpo passes on a named DPO or contact. A policy stating that no DPO was
+// appointed also passes: not every controller must appoint one.
+func dpo(in facts) (string, []string) {
+    if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+    if in.is("policy.contact.dpo.named") { return Pass, []string{"policy.contact.dpo.named"} }
+    if _, ok := in["policy.contact.dpo"].Value.(bool); ok { return Pass, []string{"policy.contact.dpo"} }
+    return Fail, []string{"policy.contact.dpo.named", "policy.contact.dpo"}
+}
+
+func retention(in facts) (string, []string) {
+    codes := []string{"policy.data.storage.retention.clarity", "policy.data.storage.retention.specified"}
+    if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+    clarity := in.str(codes[0])
+    switch clarity {
+    case "specific", "partial":
+        return Pass, codes[:1]
+    }
+    if in.is(codes[1]) { return Pass, codes[1:] }
+    // The schedule could not be read in the policy's language.
+    if clarity == "unknown" { return Unknown, codes }
+    return Fail, codes
+}
+
+// transfers passes when the policy names safeguards, or discloses no transfer
+// out of home either in prose or through its recipients' countries.
+func transfers(home func(string) bool) func(facts) (string, []string) {
+    return func(in facts) (string, []string) {
+        codes := []string{"policy.data.transfer.safeguards", "policy.data.transfer.international", "policy.recipients.countries"}
+        if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+        if in.is(codes[0]) { return Pass, codes[:1] }
+        abroad := in.is(codes[1])
+        for _, c := range in.strs(codes[2]) {
+            if !home(c) { abroad = true }
+        }
+        if abroad { return Fail, codes }
+        return Pass, codes[1:]
+    }
+}
+
+// childrenAge passes when the policy states a consent age between lo and hi.
+func childrenAge(lo, hi int) func(facts) (string, []string) {
+    return func(in facts) (string, []string) {
+        code := "policy.children.ages"
+        if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+        for _, a := range in.ints(code) {
+            if a >= lo && a <= hi { return Pass, []string{code} }
+        }
+        return Fail, []string{code}
+    }
+}
+
+// childrenCCPA requires opt-in consent below 16 only of businesses that sell.
+func childrenCCPA(in facts) (string, []string) {
+    if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+    if !in.is("policy.data.sale.present") { return Pass, []string{"policy.data.sale.present"} }
+    return childrenAge(13, 16)(in)
+}
+
+// sells reports whether the policy admits selling or does not deny it.
+func sells(in facts) bool {
+    b, ok := in["policy.data.sale.present"].Value.(bool)
+    return b || !ok
+}
+
+// doNotSellLink needs the landing page link unless the policy denies selling.
+func doNotSellLink(in facts) (string, []string) {
+    codes := []string{"site.link.do_not_sell", "policy.data.sale.present"}
+    if in.is(codes[0]) { return Pass, codes[:1] }
+    if !in.has(codes[0]) { return Unknown, codes[:1] }
+    if in.readPrivacy() && !sells(in) { return Pass, codes[1:] }
+    return Fail, codes
+}
+
+func optOut(in facts) (string, []string) {
+    codes := []string{"policy.user.rights.opt_out_sale", "policy.data.sale.present"}
+    if !in.readPrivacy() { return Unknown, []string{"policy.privacy.readable"} }
+    if in.is(codes[0]) || !sells(in) { return Pass, codes }
+    return Fail, codes
+}
+
+func gpc(in facts) (string, []string) {
+    code := "privacy.gpc.status"
+    switch in.str(code) {
+    case "honored":
+        return Pass, []string{code}
+    case "ignored":
+        return Fail, []string{code}
+    }
+    return Unknown, []string{code}
+}
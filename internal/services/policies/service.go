@@ -0,0 +1,90 @@
+// Package policies serves the stored versions of a domain's policy documents,
+// classified diffs between them and the recipients the policies name.
+package policies
+
+import (
+    "context"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/scanners/policy"
+    "camille/internal/scanners/policy/recipients"
+    "camille/internal/scanners/policy/rules"
+)
+
+type Service struct {
+    versions   ports.PolicyVersionRepository
+    recipients ports.RecipientRepository
+    rules      *rules.Catalog
+}
+
+// New serves versions and recipient lists from the repositories and classifies
+// diffs with catalog.
+func New(versions ports.PolicyVersionRepository, recipients ports.RecipientRepository, catalog *rules.Catalog) *Service {
+    return &Service{versions: versions, recipients: recipients, rules: catalog}
+}
+
+// Versions lists a domain's versions of typ, most recently seen first.
+func (s *Service) Versions(ctx context.Context, registrable, typ string) ([]ports.PolicyVersion, error) {
+    if !knownType(typ) { return nil, ErrNotFound }
+    vs, err := s.versions.PolicyVersions(ctx, registrable, typ)
+    if err != nil { return nil, err }
+    if len(vs) == 0 { return nil, ErrNotFound }
+    return vs, nil
+}
+
+// Diff compares two versions; to defaults to the current one and from to the
+// version seen before it. ErrNotFound covers unknown versions and a to with no
+// predecessor.
+func (s *Service) Diff(ctx context.Context, registrable, typ, from, to string) (ports.PolicyDiff, error) {
+    var d ports.PolicyDiff
+    if from == "" || to == "" {
+        vs, err := s.Versions(ctx, registrable, typ)
+        if err != nil { return d, err }
+        if to == "" { to = vs[0].ID }
+        if from == "" {
+            for i, v := range vs {
+                if v.ID == to && i+1 < len(vs) { from = vs[i+1].ID }
+            }
+            if from == "" { return d, ErrNotFound }
+        }
+    }
+    a, err := s.load(ctx, registrable, typ, from)
+    if err != nil { return d, err }
+    b, err := s.load(ctx, registrable, typ, to)
+    if err != nil { return d, err }
+    return policy.Compare(s.rules, typ, a, b), nil
+}
+
+// Recipients returns the latest recipient list and, when an earlier scan stored
+// one, the changes since.
+func (s *Service) Recipients(ctx context.Context, registrable string) (ports.RecipientList, error) {
+    var out ports.RecipientList
+    snaps, err := s.recipients.RecipientSnapshots(ctx, registrable, 2)
+    if err != nil { return out, err }
+    if len(snaps) == 0 { return out, ErrNotFound }
+    out.Current = snaps[0]
+    if len(snaps) > 1 {
+        out.Previous = &snaps[1]
+        out.Changes = recipients.Changes(snaps[1].Recipients, snaps[0].Recipients)
+    }
+    return out, nil
+}
+
+func (s *Service) load(ctx context.Context, registrable, typ, id string) (ports.PolicyVersion, error) {
+    if !knownType(typ) { return ports.PolicyVersion{}, ErrNotFound }
+    v, found, err := s.versions.PolicyVersion(ctx, registrable, typ, id)
+    if err == nil && !found { err = ErrNotFound }
+    return v, err
+}
+
+func knownType(typ string) bool {
+    for _, t := range domain.PolicyTypes {
+        if t == typ { return true }
+    }
+    return false
+}
+
+var ErrNotFound = errString("not found")
+type errString string
+func (e errString) Error() string { return string(e) }
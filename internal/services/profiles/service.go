@@ -8,10 +8,14 @@ import (
 )
 
 type Service struct {
-    scores ports.ScoreRepository
+    scores    ports.ScoreRepository
+    retention ports.RetentionRepository
 }
 
-func New(scores ports.ScoreRepository) *Service { return &Service{scores: scores} }
+// New builds the profile service; retention may be nil to leave schedules out.
+func New(scores ports.ScoreRepository, retention ports.RetentionRepository) *Service {
+    return &Service{scores: scores, retention: retention}
+}
 
 func (s *Service) GetLatest(ctx context.Context, domain string) (any, error) {
     exists, score, err := s.scores.GetLatestByDomain(ctx, domain)
@@ -27,9 +31,35 @@ func (s *Service) GetLatest(ctx context.Context, domain string) (any, error) {
         Scores:  api.Scores{Privacy: score.Privacy, Security: score.Security, Governance: score.Governance, Esg: score.Esg},
         Badges:  &score.Badges,
     }
+    if s.retention != nil {
+        entries, found, err := s.retention.LatestRetention(ctx, domain)
+        if err != nil {
+            return nil, err
+        }
+        if found {
+            out := make([]api.RetentionEntry, 0, len(entries))
+            for _, e := range entries { out = append(out, retentionEntry(e)) }
+            prof.Retention = &out
+        }
+    }
     return prof, nil
 }
 
+func retentionEntry(e ports.RetentionEntry) api.RetentionEntry {
+    out := api.RetentionEntry{
+        Category: e.Category, Period: e.Period, Quote: e.Quote,
+        SpanStart: e.SpanStart, SpanEnd: e.SpanEnd, SectionUrl: e.SectionURL,
+        LegalBasis: opt(e.LegalBasis), Trigger: opt(e.Trigger), DocType: opt(e.DocType), Section: opt(e.Section),
+    }
+    if e.Days > 0 { out.Days = &e.Days }
+    return out
+}
+
+func opt(s string) *string {
+    if s == "" { return nil }
+    return &s
+}
+
 var ErrNotFound = errString("not found")
 type errString string
 func (e errString) Error() string { return string(e) }
@@ -18,7 +18,8 @@ func New(domains ports.DomainRepository, scans ports.ScanRepository) *Service {
     return &Service{domains: domains, scans: scans}
 }
 
-func (s *Service) Enqueue(ctx context.Context, rawurl string) (string, error) {
+// Enqueue creates a scan of rawurl on behalf of tenant (empty when anonymous).
+func (s *Service) Enqueue(ctx context.Context, rawurl, tenant string) (string, error) {
     u, err := url.Parse(rawurl)
     if err != nil {
         return "", err
@@ -32,7 +33,7 @@ func (s *Service) Enqueue(ctx context.Context, rawurl string) (string, error) {
     if err != nil {
         return "", err
     }
-    scanID, err := s.scans.Create(ctx, domainID, rawurl)
+    scanID, err := s.scans.Create(ctx, domainID, rawurl, tenant)
     if err != nil {
         return "", err
     }
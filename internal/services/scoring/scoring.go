@@ -0,0 +1,208 @@
+// Package scoring computes deterministic sub-scores and badges from normalized signals.
+package scoring
+
+import (
+    "camille/internal/domain"
+    "camille/internal/ports"
+)
+
+// MethodVersion is written to scores.method_version; bump when rules or weights change.
+const MethodVersion = "v1"
+
+const (
+    Privacy    = "privacy"
+    Security   = "security"
+    Governance = "governance"
+    Esg        = "esg"
+)
+
+// Weights per category (SCOPE §7). Categories without signals are unknown and excluded.
+var Weights = map[string]float64{Privacy: 0.40, Security: 0.20, Governance: 0.25, Esg: 0.15}
+
+// baseline is the starting sub-score for a category once any of its signals is observed.
+const baseline = 50
+
+// Rule awards points to a category when a signal's value matches.
+type Rule struct {
+    Code     string
+    Category string
+    Match    func(v any) bool
+    Points   int
+}
+
+// Badge is awarded when every required predicate holds over the signal set.
+type Badge struct {
+    Name     string
+    Requires func(s Set) bool
+}
+
+var Rules = []Rule{
+    {Code: "security.txt.present", Category: Security, Match: IsTrue, Points: 5},
+    {Code: "security.txt.valid", Category: Security, Match: IsFalse, Points: -2},
+    {Code: "security.txt.expired", Category: Security, Match: IsTrue, Points: -3},
+    {Code: "security.txt.signature", Category: Security, Match: Equals("invalid"), Points: -2},
+
+    {Code: "email.spf.present", Category: Security, Match: IsTrue, Points: 3},
+    {Code: "email.spf.present", Category: Security, Match: IsFalse, Points: -4},
+    {Code: "email.spf.all", Category: Security, Match: Equals("pass"), Points: -5},
+    {Code: "email.spf.all", Category: Security, Match: Equals("fail"), Points: 2},
+    {Code: "email.spf.lookups.exceeded", Category: Security, Match: IsTrue, Points: -2},
+    {Code: "email.dmarc.present", Category: Security, Match: IsTrue, Points: 3},
+    {Code: "email.dmarc.present", Category: Security, Match: IsFalse, Points: -4},
+    {Code: "email.dmarc.policy", Category: Security, Match: Equals("reject"), Points: 4},
+    {Code: "email.dmarc.policy", Category: Security, Match: Equals("quarantine"), Points: 2},
+    {Code: "email.mta_sts.mode", Category: Security, Match: Equals("enforce"), Points: 3},
+    {Code: "email.mta_sts.mode", Category: Security, Match: Equals("testing"), Points: 1},
+    {Code: "email.mta_sts.mx.match", Category: Security, Match: IsFalse, Points: -2},
+    {Code: "email.tls_rpt.present", Category: Security, Match: IsTrue, Points: 1},
+
+    {Code: "dns.dnssec.signed", Category: Security, Match: IsTrue, Points: 2},
+    {Code: "dns.dnssec.validated", Category: Security, Match: IsTrue, Points: 2},
+    {Code: "dns.caa.present", Category: Security, Match: IsTrue, Points: 2},
+    {Code: "dns.caa.issuer_authorized", Category: Security, Match: IsFalse, Points: -3},
+    {Code: "dns.ns.diverse", Category: Security, Match: IsFalse, Points: -1},
+    {Code: "dns.cname.dangling", Category: Security, Match: Positive, Points: -10},
+
+    {Code: "cookies.count", Category: Privacy, Match: Always, Points: 0},
+    {Code: "cookies.preconsent.identifiers", Category: Privacy, Match: Positive, Points: -8},
+    {Code: "cookies.tracking.count", Category: Privacy, Match: Positive, Points: -5},
+    {Code: "cookies.third_party.count", Category: Privacy, Match: Positive, Points: -3},
+    {Code: "cookies.long_lived.count", Category: Privacy, Match: Positive, Points: -2},
+    {Code: "cookies.insecure.count", Category: Security, Match: Positive, Points: -3},
+    {Code: "cookies.samesite.none_insecure", Category: Security, Match: Positive, Points: -2},
+    {Code: "cookies.session.httponly_missing", Category: Security, Match: Positive, Points: -3},
+
+    {Code: "trackers.third_party.density", Category: Privacy, Match: Above(0.5), Points: -3},
+    {Code: "trackers.owners.count", Category: Privacy, Match: Positive, Points: -4},
+    {Code: "trackers.owners.count", Category: Privacy, Match: Above(4), Points: -4},
+
+    {Code: "consent.reject_all.first_layer", Category: Privacy, Match: IsTrue, Points: 3},
+    {Code: "consent.reject_all.first_layer", Category: Privacy, Match: IsFalse, Points: -4},
+    {Code: "consent.vendors.count", Category: Privacy, Match: Above(100), Points: -3},
+
+    {Code: "privacy.gpc.declared", Category: Privacy, Match: IsTrue, Points: 1},
+    {Code: "privacy.gpc.status", Category: Privacy, Match: Equals("honored"), Points: 5},
+    {Code: "privacy.gpc.status", Category: Privacy, Match: Equals("ignored"), Points: -5},
+
+    {Code: "policy.privacy.found", Category: Governance, Match: IsTrue, Points: 5},
+    {Code: "policy.privacy.found", Category: Governance, Match: IsFalse, Points: -15},
+    {Code: "policy.terms.found", Category: Governance, Match: IsTrue, Points: 2},
+    {Code: "policy.cookies.found", Category: Governance, Match: IsTrue, Points: 1},
+
+    {Code: "policy.data.sale.present", Category: Privacy, Match: IsTrue, Points: -10},
+    {Code: "policy.data.sale.present", Category: Privacy, Match: IsFalse, Points: 3},
+    {Code: "policy.data.sharing.third_parties", Category: Privacy, Match: IsTrue, Points: -3},
+    {Code: "policy.ai.training.userdata", Category: Privacy, Match: IsTrue, Points: -5},
+    {Code: "policy.ai.training.userdata", Category: Privacy, Match: IsFalse, Points: 2},
+    {Code: "policy.data.storage.retention.specified", Category: Privacy, Match: IsTrue, Points: 3},
+    {Code: "policy.data.storage.retention.specified", Category: Privacy, Match: IsFalse, Points: -2},
+    {Code: "policy.data.storage.retention.indefinite", Category: Privacy, Match: IsTrue, Points: -5},
+    {Code: "policy.data.storage.retention.clarity", Category: Privacy, Match: Equals("vague"), Points: -1},
+    {Code: "policy.data.storage.retention.basis_stated", Category: Governance, Match: IsTrue, Points: 1},
+    {Code: "policy.user.rights.deletion.channel.email_present", Category: Privacy, Match: IsTrue, Points: 2},
+    {Code: "policy.user.rights.access", Category: Privacy, Match: IsTrue, Points: 1},
+    {Code: "policy.user.rights.portability", Category: Privacy, Match: IsTrue, Points: 1},
+    {Code: "policy.children.restrictions.stated", Category: Governance, Match: IsTrue, Points: 1},
+    {Code: "policy.security.measures.stated", Category: Governance, Match: IsTrue, Points: 1},
+    {Code: "policy.contact.dpo", Category: Governance, Match: IsTrue, Points: 2},
+    {Code: "policy.privacy.dated", Category: Governance, Match: IsFalse, Points: -1},
+    {Code: "policy.privacy.stale", Category: Governance, Match: IsTrue, Points: -3},
+    {Code: "policy.contact.controller.named", Category: Governance, Match: IsTrue, Points: 1},
+    {Code: "policy.subprocessors.found", Category: Governance, Match: IsTrue, Points: 1},
+    {Code: "policy.contact.dsr.email.mx_valid", Category: Privacy, Match: IsFalse, Points: -3},
+}
+
+var Badges = []Badge{
+    {Name: "security.txt present", Requires: func(s Set) bool {
+        return s.True("security.txt.present") && s.True("security.txt.valid") && !s.True("security.txt.expired")
+    }},
+    {Name: "DMARC enforced", Requires: func(s Set) bool {
+        p := s["email.dmarc.policy"]
+        return (p == "reject" || p == "quarantine") && s["email.dmarc.pct"] == 100
+    }},
+    {Name: "MTA-STS enforced", Requires: func(s Set) bool {
+        return s["email.mta_sts.mode"] == "enforce" && s.True("email.mta_sts.valid") && !IsFalse(s["email.mta_sts.mx.match"])
+    }},
+    {Name: "DNSSEC signed", Requires: func(s Set) bool {
+        return s.True("dns.dnssec.signed") && s.True("dns.dnssec.validated")
+    }},
+}
+
+// Set indexes signals by code; the last value for a code wins.
+type Set map[string]any
+
+func Index(sigs []domain.Signal) Set {
+    s := Set{}
+    for _, sig := range sigs { s[sig.Code] = sig.Value }
+    return s
+}
+
+func (s Set) True(code string) bool { return IsTrue(s[code]) }
+
+func (s Set) Has(code string) bool { _, ok := s[code]; return ok }
+
+// Compute returns clamped sub-scores, the weighted overall and earned badges.
+func Compute(sigs []domain.Signal) (ports.Scores, []string) {
+    set := Index(sigs)
+    totals := map[string]int{}
+    seen := map[string]bool{}
+    for _, r := range Rules {
+        v, ok := set[r.Code]
+        if !ok { continue }
+        seen[r.Category] = true
+        if r.Match(v) { totals[r.Category] += r.Points }
+    }
+
+    sub := func(cat string) int {
+        if !seen[cat] { return 0 }
+        return clamp(baseline + totals[cat])
+    }
+    out := ports.Scores{Privacy: sub(Privacy), Security: sub(Security), Governance: sub(Governance), Esg: sub(Esg)}
+
+    var weighted, weight float64
+    for cat, w := range Weights {
+        if !seen[cat] { continue }
+        weighted += w * float64(sub(cat))
+        weight += w
+    }
+    if weight > 0 { out.Overall = clamp(int(weighted/weight + 0.5)) }
+
+    badges := []string{}
+    for _, b := range Badges {
+        if b.Requires(set) { badges = append(badges, b.Name) }
+    }
+    return out, badges
+}
+
+func IsTrue(v any) bool { b, ok := v.(bool); return ok && b }
+
+func IsFalse(v any) bool { b, ok := v.(bool); return ok && !b }
+
+// Always matches; used to mark a category as observed without awarding points.
+func Always(any) bool { return true }
+
+// Positive matches numeric values above zero.
+func Positive(v any) bool { return Above(0)(v) }
+
+// Above matches numeric values strictly greater than min.
+func Above(min float64) func(any) bool {
+    return func(v any) bool {
+        switch n := v.(type) {
+        case int:
+            return float64(n) > min
+        case float64:
+            return n > min
+        }
+        return false
+    }
+}
+
+func Equals(want string) func(any) bool {
+    return func(v any) bool { s, ok := v.(string); return ok && s == want }
+}
+
+func clamp(v int) int {
+    if v < 0 { return 0 }
+    if v > 100 { return 100 }
+    return v
+}
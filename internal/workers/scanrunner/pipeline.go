@@ -0,0 +1,61 @@
+package scanrunner
+
+import (
+    "context"
+    "log"
+
+    "camille/internal/domain"
+    "camille/internal/ports"
+    "camille/internal/services/compliance"
+    "camille/internal/services/scoring"
+)
+
+// Pipeline runs site scanners for a scan, persists their evidence and signals,
+// and writes the deterministic score. Scanner errors are logged and skipped. The
+// compliance assessment runs last, over every scanner's signals.
+type Pipeline struct {
+    Jobs     ports.JobRepository
+    Scans    ports.ScanRepository
+    Evidence ports.EvidenceRepository
+    Signals  ports.SignalsRepository
+    Scores   ports.ScoreRepository
+    Scanners []ports.SiteScanner
+}
+
+func (p *Pipeline) Process(ctx context.Context, scanID string) error {
+    target, err := p.Scans.Target(ctx, scanID)
+    if err != nil { return err }
+
+    var all []domain.Signal
+    stored := map[string]bool{}
+    steps := float64(len(p.Scanners) + 1)
+    for i, sc := range p.Scanners {
+        res, err := sc.Scan(ctx, target)
+        if err != nil {
+            if ctx.Err() != nil { return ctx.Err() }
+            log.Printf("scan %s: scanner %s: %v", scanID, sc.Name(), err)
+            continue
+        }
+        if err := p.store(ctx, scanID, target, res, stored); err != nil { return err }
+        all = append(all, res.Signals...)
+        if err := p.Jobs.UpdateScanProgress(ctx, scanID, float64(i+1)/steps); err != nil { return err }
+    }
+
+    res := compliance.Evaluate(all)
+    if err := p.store(ctx, scanID, target, res, stored); err != nil { return err }
+    all = append(all, res.Signals...)
+
+    scores, badges := scoring.Compute(all)
+    if err := p.Scores.UpsertScore(ctx, target.DomainID, scores, badges, scoring.MethodVersion); err != nil { return err }
+    return p.Jobs.UpdateScanProgress(ctx, scanID, 1.0)
+}
+
+// store persists a result's evidence, skipping hashes already stored for the scan, then its signals.
+func (p *Pipeline) store(ctx context.Context, scanID string, target ports.ScanTarget, res ports.ScanResult, stored map[string]bool) error {
+    for _, ev := range res.Evidence {
+        if stored[ev.Hash] { continue }
+        if _, err := p.Evidence.AddEvidence(ctx, scanID, ev); err != nil { return err }
+        stored[ev.Hash] = true
+    }
+    return p.Signals.UpsertSignals(ctx, target.DomainID, scanID, res.Signals)
+}